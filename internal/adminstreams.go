@@ -0,0 +1,62 @@
+package internal
+
+import "fmt"
+
+// ────────────────────────────────
+// BROWSER-ONLY STREAMS
+// ────────────────────────────────
+
+// adminMode is how the Streams column shows admin streams, which can only be
+// opened in the browser.
+type adminMode int
+
+const (
+	adminShown adminMode = iota
+	// adminCollapsed replaces them with a single row counting them.
+	adminCollapsed
+	adminHidden
+	adminModeCount
+)
+
+var adminModeNames = [...]string{"shown", "collapsed", "hidden"}
+
+func (a adminMode) String() string { return adminModeNames[a] }
+
+// parseAdminMode reads a saved mode; unknown names show admin streams.
+func parseAdminMode(name string) adminMode {
+	for i, n := range adminModeNames {
+		if n == name {
+			return adminMode(i)
+		}
+	}
+	return adminShown
+}
+
+// foldAdminStreams drops admin streams from the visible list unless they
+// are shown, and sets the collapsed summary row.
+func (m *Model) foldAdminStreams(visible []Stream) []Stream {
+	if m.adminStreams == adminShown {
+		m.streams.SetFooter("")
+		return visible
+	}
+	kept := make([]Stream, 0, len(visible))
+	for _, st := range visible {
+		if !isAdminStream(st) {
+			kept = append(kept, st)
+		}
+	}
+	footer := ""
+	if n := len(visible) - len(kept); m.adminStreams == adminCollapsed && n > 0 {
+		footer = fmt.Sprintf("Browser Only (%d) – B to expand", n)
+	}
+	m.streams.SetFooter(footer)
+	return kept
+}
+
+// cycleAdminStreams moves admin streams from shown to collapsed to hidden
+// and back.
+func (m *Model) cycleAdminStreams() {
+	m.adminStreams = (m.adminStreams + 1) % adminModeCount
+	m.applyStreams()
+	m.status = "Browser-only streams " + m.adminStreams.String()
+}
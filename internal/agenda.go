@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// AGENDA
+// ────────────────────────────────
+
+const (
+	// agendaDays is how many days, today included, the agenda covers.
+	agendaDays = 7
+	// agendaTTL is how long the all-matches list is reused before the agenda
+	// fetches it again.
+	agendaTTL = 5 * time.Minute
+)
+
+// allSportID asks the API for every sport's matches in one list.
+const allSportID = "all"
+
+type agendaLoadedMsg struct {
+	Matches []Match
+}
+
+// agendaModel is the state of the agenda view: upcoming matches across all
+// sports, shown one day at a time and grouped by hour.
+type agendaModel struct {
+	matches []Match
+	loaded  time.Time
+	// day is the shown day, counted from today.
+	day  int
+	list *ListColumn[Match]
+}
+
+type agendaKeys struct {
+	PrevDay, NextDay, Today key.Binding
+}
+
+func defaultAgendaKeys() agendaKeys {
+	return agendaKeys{
+		PrevDay: key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "previous day")),
+		NextDay: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "next day")),
+		Today:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "today")),
+	}
+}
+
+func newAgendaModel(kickoff *kickoffClock) agendaModel {
+	list := NewListColumn[Match]("Agenda", func(mt Match) string {
+		now := time.Now()
+		when := time.UnixMilli(mt.Date).Local().Format("15:04")
+		if kickoff.relative {
+			when = fmt.Sprintf("%-*s", kickoffWidth, relativeKickoff(mt, now))
+		}
+		text := fmt.Sprintf("%s  %s (%s)", when, matchDisplayTitle(mt), mt.Category)
+		if mt.Provider != "" {
+			text += " [" + mt.Provider + "]"
+		}
+		if status := matchStatusLabel(mt, now); status == liveBadge && !kickoff.relative {
+			text += "  " + status
+		}
+		return text
+	})
+	list.SetSeparator(matchHourSeparator)
+	list.SetDecorator(func(mt Match, text string) string {
+		if kickoff.relative {
+			return colorLiveBadge(relativeKickoff(mt, time.Now()), text)
+		}
+		// The badge ends the row when times are absolute.
+		badge := "  " + liveBadge
+		if matchStatusLabel(mt, time.Now()) != liveBadge || !strings.HasSuffix(text, badge) {
+			return text
+		}
+		return strings.TrimSuffix(text, liveBadge) + liveBadgeStyle.Render(liveBadge)
+	})
+	return agendaModel{list: list}
+}
+
+// matchHourSeparator starts a group for each hour of the day.
+func matchHourSeparator(prev, curr Match) (string, bool) {
+	hour := time.UnixMilli(curr.Date).Local().Format("15:00")
+	if prev.Date == 0 || time.UnixMilli(prev.Date).Local().Format("15:00") != hour {
+		return hour, true
+	}
+	return "", false
+}
+
+// agendaDay returns which day of the agenda a match belongs to, counted
+// from today in local time. Matches still live from an earlier day count as
+// today.
+func agendaDay(mt Match, now time.Time) int {
+	start := time.UnixMilli(mt.Date).Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if start.Before(today) {
+		if now.Sub(start) < liveWindow {
+			return 0
+		}
+		return -1
+	}
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, now.Location())
+	// Round so a daylight saving change does not shift the count.
+	return int((day.Sub(today) + 12*time.Hour) / (24 * time.Hour))
+}
+
+// agendaDayLabel names a day of the agenda.
+func agendaDayLabel(day int, now time.Time) string {
+	switch day {
+	case 0:
+		return "Today"
+	case 1:
+		return "Tomorrow"
+	}
+	return now.AddDate(0, 0, day).Format("Mon Jan 2")
+}
+
+// openAgenda switches to the agenda view, fetching every sport's matches
+// when they are missing or stale.
+func (m *Model) openAgenda() tea.Cmd {
+	m.currentView = viewAgenda
+	m.showAgendaDay(m.agenda.day)
+	if m.agenda.matches == nil || time.Since(m.agenda.loaded) > agendaTTL {
+		return m.track(opAgenda, opAgenda, "Loading the agenda", m.fetchAgenda(false))
+	}
+	return nil
+}
+
+// fetchAgenda loads every sport's matches from the all-matches endpoint.
+func (m Model) fetchAgenda(fresh bool) tea.Cmd {
+	provider := m.provider
+	return safeCmd("fetch agenda", m.crash, func() tea.Msg {
+		matches, err := provider.ListMatches(apiContext(fresh), allSportID)
+		if err != nil {
+			return errorMsg(err)
+		}
+		return agendaLoadedMsg{Matches: matches}
+	})
+}
+
+func (m *Model) handleAgendaLoaded(msg agendaLoadedMsg) {
+	now := time.Now()
+	var upcoming []Match
+	for _, mt := range msg.Matches {
+		if mt.Date > 0 {
+			if day := agendaDay(mt, now); day >= 0 && day < agendaDays {
+				upcoming = append(upcoming, mt)
+			}
+		}
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool { return upcoming[i].Date < upcoming[j].Date })
+	m.agenda.matches = upcoming
+	m.agenda.loaded = now
+	m.lastError = nil
+	m.status = fmt.Sprintf("Loaded %d matches over the next %d days", len(upcoming), agendaDays)
+	m.refreshAgendaDay()
+}
+
+// showAgendaDay shows one day of the agenda with the cursor on its first
+// match.
+func (m *Model) showAgendaDay(day int) {
+	m.agenda.day = min(max(day, 0), agendaDays-1)
+	m.agenda.list.SetItems(m.agendaMatches(m.agenda.day, time.Now()))
+	m.agenda.list.SetFooter(m.agendaFooter())
+}
+
+// refreshAgendaDay re-reads the shown day, keeping the cursor on its match.
+func (m *Model) refreshAgendaDay() {
+	m.agenda.list.ReplaceItems(m.agendaMatches(m.agenda.day, time.Now()), func(a, b Match) bool { return a.ID == b.ID })
+	m.agenda.list.SetFooter(m.agendaFooter())
+}
+
+func (m Model) agendaMatches(day int, now time.Time) []Match {
+	var out []Match
+	for _, mt := range m.agenda.matches {
+		if agendaDay(mt, now) == day {
+			out = append(out, mt)
+		}
+	}
+	return out
+}
+
+func (m Model) agendaFooter() string {
+	if len(m.agenda.list.Items()) > 0 {
+		return ""
+	}
+	if m.agenda.matches == nil {
+		return "loading…"
+	}
+	return "no matches"
+}
+
+// updateAgenda handles keys while the agenda is open: ←/→ change the day,
+// Enter opens the match's streams, and r fetches the agenda again.
+func (m *Model) updateAgenda(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.agenda.list.CursorUp()
+	case key.Matches(msg, m.keys.Down):
+		m.agenda.list.CursorDown()
+	case key.Matches(msg, m.keys.PageUp):
+		m.agenda.list.Jump(jumpPageUp)
+	case key.Matches(msg, m.keys.PageDown):
+		m.agenda.list.Jump(jumpPageDown)
+	case key.Matches(msg, m.agendaKeys.PrevDay):
+		m.showAgendaDay(m.agenda.day - 1)
+	case key.Matches(msg, m.agendaKeys.NextDay):
+		m.showAgendaDay(m.agenda.day + 1)
+	case key.Matches(msg, m.agendaKeys.Today):
+		m.showAgendaDay(0)
+	case key.Matches(msg, m.keys.Refresh):
+		return m.track(opAgenda, opAgenda, "Refreshing the agenda", m.fetchAgenda(true))
+	case key.Matches(msg, m.keys.Enter):
+		mt, ok := m.agenda.list.Selected()
+		if !ok {
+			return nil
+		}
+		m.currentView = viewMain
+		return m.openMatch(mt)
+	}
+	return nil
+}
+
+// renderAgendaDays is the row of day tabs, the shown one highlighted, each
+// with its number of matches.
+func (m Model) renderAgendaDays(now time.Time) string {
+	counts := make([]int, agendaDays)
+	for _, mt := range m.agenda.matches {
+		if day := agendaDay(mt, now); day >= 0 && day < agendaDays {
+			counts[day]++
+		}
+	}
+	tabs := make([]string, agendaDays)
+	for day := range tabs {
+		label := fmt.Sprintf("%s (%d)", agendaDayLabel(day, now), counts[day])
+		if day == m.agenda.day {
+			tabs[day] = m.styles.Title.Render("[" + label + "]")
+		} else {
+			tabs[day] = m.styles.Subtle.Render(" " + label + " ")
+		}
+	}
+	return strings.Join(tabs, " ")
+}
+
+func (m Model) renderAgendaView() string {
+	now := time.Now()
+	m.agenda.list.SetTitle(agendaDayLabel(m.agenda.day, now))
+	header := m.styles.Title.Render("Agenda")
+	hint := m.styles.Subtle.Render(m.styles.Text("←/→ day · t today · ↑/↓ select · Enter open streams · r refresh · Esc back"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.renderAgendaDays(now),
+		m.agenda.list.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
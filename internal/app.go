@@ -2,12 +2,19 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -20,7 +27,32 @@ type keyMap struct {
 	Up, Down, Left, Right key.Binding
 	Enter, Quit, Refresh  key.Binding
 	OpenBrowser, OpenMPV  key.Binding
-	Help                  key.Binding
+	Cast                  key.Binding
+	Remind, Reminders     key.Binding
+	Star, StarTeam        key.Binding
+	StarAwayTeam          key.Binding
+	HDOnly, LanguageOnly  key.Binding
+	AdminStreams          key.Binding
+	History, Agenda       key.Binding
+	Kickoff               key.Binding
+	LiveOnly, Upcoming    key.Binding
+	Category, Teams       key.Binding
+	HideFinished          key.Binding
+	Sort                  key.Binding
+	Fullscreen            key.Binding
+	InstantPlay           key.Binding
+	Record, Recordings    key.Binding
+	Download              key.Binding
+	Players, Retry        key.Binding
+	Queue, Jobs, Check    key.Binding
+	Copy, CopyEmbed       key.Binding
+	Filter, Search        key.Binding
+	Details, Help         key.Binding
+	Cancel, Log           key.Binding
+	PageUp, PageDown      key.Binding
+	Top, Bottom           key.Binding
+	Tab, NewTab, CloseTab key.Binding
+	Slot, LaunchSlot      key.Binding
 }
 
 type helpKeyMap struct {
@@ -30,32 +62,80 @@ type helpKeyMap struct {
 
 func defaultKeys() keyMap {
 	return keyMap{
-		Up:          key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
-		Down:        key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
-		Left:        key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "focus left")),
-		Right:       key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "focus right")),
-		Enter:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
-		OpenBrowser: key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in browser")),
-		OpenMPV:     key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "open in mpv")),
-		Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
-		Refresh:     key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
-		Help:        key.NewBinding(key.WithKeys("f1", "?"), key.WithHelp("F1/?", "toggle help")),
+		Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:         key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		Left:         key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "focus left")),
+		Right:        key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "focus right")),
+		Enter:        key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		OpenBrowser:  key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in browser")),
+		OpenMPV:      key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "open in mpv")),
+		Cast:         key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "cast")),
+		Quit:         key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		Refresh:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		Remind:       key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "remind me")),
+		Reminders:    key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "reminders")),
+		Star:         key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "star match")),
+		StarTeam:     key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "star home team")),
+		StarAwayTeam: key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "star away team")),
+		HDOnly:       key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "HD only")),
+		LanguageOnly: key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "my languages")),
+		AdminStreams: key.NewBinding(key.WithKeys("B"), key.WithHelp("B", "browser-only streams")),
+		Sort:         key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "sort column")),
+		History:      key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "watch history")),
+		Agenda:       key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "agenda")),
+		Kickoff:      key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "relative times")),
+		LiveOnly:     key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "live now only")),
+		Upcoming:     key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "upcoming only")),
+		HideFinished: key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "hide finished")),
+		Category:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "category")),
+		Teams:        key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "browse teams")),
+		Fullscreen:   key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "play fullscreen")),
+		InstantPlay:  key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "instant play")),
+		Record:       key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "record")),
+		Recordings:   key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "recordings")),
+		Download:     key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "download")),
+		Players:      key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "players")),
+		Retry:        key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "retry player")),
+		Queue:        key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "queue extraction")),
+		Check:        key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "check streams")),
+		Jobs:         key.NewBinding(key.WithKeys("J"), key.WithHelp("J", "jobs")),
+		Copy:         key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy URL")),
+		CopyEmbed:    key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy embed URL")),
+		Filter:       key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		Search:       key.NewBinding(key.WithKeys("ctrl+f"), key.WithHelp("ctrl+f", "search all")),
+		Details:      key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "match details")),
+		Help:         key.NewBinding(key.WithKeys("f1", "?"), key.WithHelp("F1/?", "toggle help")),
+		Cancel:       key.NewBinding(key.WithKeys("ctrl+x"), key.WithHelp("ctrl+x", "cancel extraction")),
+		Log:          key.NewBinding(key.WithKeys("ctrl+l"), key.WithHelp("ctrl+l", "debug log")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up")),
+		PageDown:     key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "page down")),
+		Top:          key.NewBinding(key.WithKeys("home"), key.WithHelp("home/gg", "top")),
+		Bottom:       key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("end/G", "bottom")),
+		Tab:          key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "switch tab")),
+		NewTab:       key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "new tab")),
+		CloseTab:     key.NewBinding(key.WithKeys("ctrl+w"), key.WithHelp("ctrl+w", "close tab")),
+		Slot:         key.NewBinding(key.WithKeys("m"), key.WithHelp("m 1-9", "put in slot")),
+		LaunchSlot:   key.NewBinding(key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"), key.WithHelp("alt+1-9", "launch slot")),
 	}
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Enter, k.OpenBrowser, k.OpenMPV, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Enter, k.Filter, k.OpenBrowser, k.OpenMPV, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.OpenBrowser, k.OpenMPV, k.Refresh, k.Help, k.Quit},
+		{k.Up, k.Down, k.Left, k.Right, k.Filter, k.Search},
+		{k.PageUp, k.PageDown, k.Top, k.Bottom, k.Tab, k.NewTab, k.CloseTab},
+		{k.Enter, k.OpenBrowser, k.OpenMPV, k.Cast, k.Slot, k.LaunchSlot, k.Refresh, k.Help, k.Quit},
+		{k.Remind, k.Reminders, k.HDOnly, k.LanguageOnly, k.AdminStreams, k.Sort, k.Fullscreen, k.InstantPlay, k.History, k.Agenda, k.Teams, k.Kickoff},
+		{k.Record, k.Download, k.Recordings, k.Players, k.Queue, k.Jobs, k.Check, k.Copy, k.CopyEmbed, k.Details, k.Cancel, k.Log},
+		{k.Star, k.StarTeam, k.StarAwayTeam, k.LiveOnly, k.Upcoming, k.HideFinished, k.Category},
 	}
 }
 
 func (h helpKeyMap) ShortHelp() []key.Binding {
-	bindings := []key.Binding{h.base.Up, h.base.Down, h.base.Left, h.base.Right, h.base.Enter, h.base.OpenBrowser}
+	bindings := []key.Binding{h.base.Up, h.base.Down, h.base.Left, h.base.Right, h.base.Enter, h.base.Filter, h.base.OpenBrowser}
 	if h.showMPV {
 		bindings = append(bindings, h.base.OpenMPV)
 	}
@@ -66,13 +146,18 @@ func (h helpKeyMap) ShortHelp() []key.Binding {
 func (h helpKeyMap) FullHelp() [][]key.Binding {
 	row2 := []key.Binding{h.base.Enter, h.base.OpenBrowser}
 	if h.showMPV {
-		row2 = append(row2, h.base.OpenMPV)
+		row2 = append(row2, h.base.OpenMPV, h.base.Cast)
 	}
+	row2 = append(row2, h.base.Slot, h.base.LaunchSlot)
 	row2 = append(row2, h.base.Refresh, h.base.Help, h.base.Quit)
 
 	return [][]key.Binding{
-		{h.base.Up, h.base.Down, h.base.Left, h.base.Right},
+		{h.base.Up, h.base.Down, h.base.Left, h.base.Right, h.base.Filter, h.base.Search},
+		{h.base.PageUp, h.base.PageDown, h.base.Top, h.base.Bottom, h.base.Tab, h.base.NewTab, h.base.CloseTab},
 		row2,
+		{h.base.Remind, h.base.Reminders, h.base.HDOnly, h.base.LanguageOnly, h.base.AdminStreams, h.base.Sort, h.base.Fullscreen, h.base.InstantPlay, h.base.History, h.base.Agenda, h.base.Teams, h.base.Kickoff},
+		{h.base.Record, h.base.Download, h.base.Recordings, h.base.Players, h.base.Queue, h.base.Jobs, h.base.Check, h.base.Copy, h.base.CopyEmbed, h.base.Details, h.base.Cancel, h.base.Log},
+		{h.base.Star, h.base.StarTeam, h.base.StarAwayTeam, h.base.LiveOnly, h.base.Upcoming, h.base.HideFinished, h.base.Category},
 	}
 }
 
@@ -83,13 +168,19 @@ func (h helpKeyMap) FullHelp() [][]key.Binding {
 type (
 	sportsLoadedMsg  []Sport
 	matchesLoadedMsg struct {
+		SportID string
 		Matches []Match
 		Title   string
 	}
-	streamsLoadedMsg []Stream
-	errorMsg         error
-	launchStreamMsg  struct{ URL string }
-	debugLogMsg      string
+	streamsLoadedMsg struct {
+		MatchID string
+		Streams []Stream
+		// Partial is set when some of the match's sources failed.
+		Partial *StreamSourcesError
+	}
+	errorMsg        error
+	launchStreamMsg struct{ URL string }
+	debugLogMsg     string
 )
 
 type focusCol int
@@ -104,6 +195,18 @@ const (
 const (
 	viewMain viewMode = iota
 	viewHelp
+	viewReminders
+	viewSearch
+	viewHistory
+	viewRecordings
+	viewJobs
+	viewPlayers
+	viewQuality
+	viewLog
+	viewAgenda
+	viewCategories
+	viewTeams
+	viewCast
 )
 
 func formatViewerCount(count int) string {
@@ -124,23 +227,115 @@ func formatViewerCount(count int) string {
 	return fmt.Sprintf("%d", count)
 }
 
-func reorderStreams(streams []Stream) []Stream {
+// formatSourceCount labels how many stream sources a match lists, so fixtures
+// without feeds yet are easy to skip.
+func formatSourceCount(n int) string {
+	if n == 0 {
+		return "(no src)"
+	}
+	return fmt.Sprintf("(%d src)", n)
+}
+
+// viewerHeatStyle colors a viewer count by its share of the busiest item in the
+// list: green for the quieter third, yellow for the middle, red for the top.
+func viewerHeatStyle(count, max int) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	switch {
+	case count <= 0 || max <= 0:
+		return style.Foreground(lipgloss.Color("243"))
+	case count*3 >= max*2:
+		return style.Foreground(lipgloss.Color("9"))
+	case count*3 >= max:
+		return style.Foreground(lipgloss.Color("11"))
+	default:
+		return style.Foreground(lipgloss.Color("10"))
+	}
+}
+
+// colorViewerLabel colors the first occurrence of label in text, leaving the
+// text unchanged when truncation has cut the label off.
+func colorViewerLabel(text, label string, count, max int) string {
+	idx := strings.Index(text, label)
+	if label == "" || idx < 0 {
+		return text
+	}
+	return text[:idx] + viewerHeatStyle(count, max).Render(label) + text[idx+len(label):]
+}
+
+func maxMatchViewers(matches []Match) int {
+	max := 0
+	for _, mt := range matches {
+		if mt.Viewers > max {
+			max = mt.Viewers
+		}
+	}
+	return max
+}
+
+func maxStreamViewers(streams []Stream) int {
+	max := 0
+	for _, st := range streams {
+		if st.Viewers > max {
+			max = st.Viewers
+		}
+	}
+	return max
+}
+
+// reorderStreams ranks streams by the configured preference, keeping the API
+// order within a rank, and moves admin (browser-only) streams to the end.
+func reorderStreams(streams []Stream, pref streamPreference) []Stream {
 	if len(streams) == 0 {
 		return streams
 	}
+	out := slices.Clone(streams)
+	slices.SortStableFunc(out, func(a, b Stream) int {
+		if isAdminStream(a) != isAdminStream(b) {
+			if isAdminStream(a) {
+				return 1
+			}
+			return -1
+		}
+		return pref.rank(a) - pref.rank(b)
+	})
+	return out
+}
 
-	regular := make([]Stream, 0, len(streams))
-	admin := make([]Stream, 0)
+func isAdminStream(st Stream) bool { return strings.EqualFold(st.Source, "admin") }
 
-	for _, st := range streams {
-		if strings.EqualFold(st.Source, "admin") {
-			admin = append(admin, st)
-			continue
+// streamInLanguages reports whether a stream is in one of languages.
+func streamInLanguages(st Stream, languages []string) bool {
+	for _, lang := range languages {
+		if strings.EqualFold(strings.TrimSpace(st.Language), strings.TrimSpace(lang)) {
+			return true
 		}
-		regular = append(regular, st)
 	}
+	return false
+}
 
-	return append(regular, admin...)
+// autoPickStream chooses the stream to play when the user did not pick one:
+// the first that can be handed to mpv, in the most preferred language when
+// languages is set. Streams in other languages are never picked then.
+func autoPickStream(streams []Stream, languages []string) (Stream, bool) {
+	playable := func(st Stream) bool {
+		return !strings.EqualFold(st.Source, "admin") && st.EmbedURL != ""
+	}
+	if len(languages) == 0 {
+		for _, st := range streams {
+			if playable(st) {
+				return st, true
+			}
+		}
+		return Stream{}, false
+	}
+	for _, lang := range languages {
+		for _, st := range streams {
+			if playable(st) && strings.EqualFold(strings.TrimSpace(st.Language), lang) {
+				return st, true
+			}
+		}
+	}
+	return Stream{}, false
 }
 
 // ────────────────────────────────
@@ -148,88 +343,445 @@ func reorderStreams(streams []Stream) []Stream {
 // ────────────────────────────────
 
 type Model struct {
+	opts        Options
 	apiClient   *Client
+	provider    Provider
 	styles      Styles
 	keys        keyMap
 	help        help.Model
 	focus       focusCol
 	lastError   error
 	currentView viewMode
+	extract     extractFunc
 
 	sports  *ListColumn[Sport]
 	matches *ListColumn[Match]
 	streams *ListColumn[Stream]
 
-	status        string
-	debugLines    []string
-	TerminalWidth int
+	status   string
+	progress progress
+	prefetch *streamPrefetcher
+	requests *requestTracker
+	// sportPrefetch fetches the highlighted sport's matches ahead of Enter.
+	sportPrefetch *sportPrefetcher
+	// kickoff is how match rows show start times.
+	kickoff *kickoffClock
+	// kickoffAlerts tracks the favorites announced as live.
+	kickoffAlerts *kickoffAlerts
+	// hooks run the configured hooks; nil without any.
+	hooks *hookRunner
+	// extracted maps embed URLs to the playlists extracted from them this
+	// session.
+	extracted map[string]string
+	// health is how each embed URL fared in the last stream check.
+	health map[string]streamHealth
+	// extractions cancels in-flight extractions by their tracker target.
+	extractions  map[string]context.CancelFunc
+	matchCache   map[string]matchesLoadedMsg
+	shownSport   string
+	shownMatch   string
+	openedMatch  Match
+	allMatches   []Match
+	matchesTitle string
+	matchesStale bool
+	// tabs are the open tabs' workspaces; tab is the active one, whose
+	// workspace is in the fields above while it is active.
+	tabs         []workspace
+	tab          int
+	matchSort    matchSort
+	matchFilter  matchFilter
+	allStreams   []Stream
+	streamsStale bool
+	hdOnly       bool
+	languageOnly bool
+	adminStreams adminMode
+	streamSort   streamSort
+	streamPref   streamPreference
+	showDetails  bool
+	detailsWidth int
+
+	// matchCategory limits the Matches column to one category (league) of
+	// the shown sport; "" shows them all.
+	matchCategory string
+	categories    *ListColumn[categoryOption]
+
+	defaultSportDone bool
+
+	filtering      bool
+	filterInput    textinput.Model
+	search         searchModel
+	latestRelease  string
+	schedule       *scheduleStore
+	favorites      *favoritesStore
+	slots          *slotsStore
+	pendingSlot    bool
+	watched        *historyStore
+	history        *ListColumn[historyEntry]
+	historyKeys    historyKeys
+	agenda         agendaModel
+	teams          teamsModel
+	agendaKeys     agendaKeys
+	playing        playbackState
+	recorder       *recorder
+	recordings     *ListColumn[recordingInfo]
+	recordingKeys  recordingKeys
+	jobs           *jobManager
+	jobList        *ListColumn[jobInfo]
+	jobKeys        jobKeys
+	players        *playerTracker
+	playerList     *ListColumn[playerInfo]
+	playerKeys     playerKeys
+	retryPlayer    playerInfo
+	watchdogCount  map[string]int
+	quality        *ListColumn[hlsVariant]
+	castTargets    *ListColumn[castTarget]
+	castKeys       castKeys
+	castPending    pendingLaunch
+	castScanning   bool
+	castRelay      *castRelay
+	pendingLaunch  pendingLaunch
+	playbackKeys   playbackKeys
+	reminderKeys   reminderKeys
+	reminderCursor int
+	notifier       *desktopNotifier
+	presence       *discordPresence
+	debugLines     []string
+	debugDropped   int
+	logView        logView
+	crash          *crashTrail
+	ui             *uiLogger
+	logFile        *debugFile
+	art            *artCache
+	// remote is the SSH session the TUI is served to, if any; the
+	// clipboard is reached through it.
+	remote         io.Writer
+	pendingG       bool
+	TerminalWidth  int
+	TerminalHeight int
 }
 
 // ────────────────────────────────
 // ENTRY POINT
 // ────────────────────────────────
 
-func Run(debug bool) error {
-	p := tea.NewProgram(New(debug), tea.WithAltScreen())
-	_, err := p.Run()
+func Run(opts Options) (err error) {
+	if err := opts.checkTUI(); err != nil {
+		return err
+	}
+	var script []keyStep
+	if opts.KeyScript != "" {
+		if script, err = loadKeyScript(opts.KeyScript); err != nil {
+			return err
+		}
+	}
+
+	m := New(opts)
+	// Panics are handled by recoverCrash instead of Bubble Tea so a crash
+	// report can be written after the terminal has been restored.
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithoutCatchPanics())
+	m.ui.attach(p)
+	if len(script) > 0 {
+		go playKeyScript(p, script)
+	}
+	defer m.shutdown()
+	defer recoverCrash(p, m.crash, &err)
+	_, err = p.Run()
 	return err
 }
 
-func New(debug bool) Model {
-	base := BaseURLFromEnv()
-	client := NewClient(base, 15*time.Second)
+// checkTUI rejects settings the TUI cannot start with.
+func (o Options) checkTUI() error {
+	if err := o.checkProxy(); err != nil {
+		return err
+	}
+	if err := o.checkImages(); err != nil {
+		return err
+	}
+	return o.checkLogLevel()
+}
+
+// shutdown releases what a Model started once its program has exited.
+func (m Model) shutdown() {
+	if m.opts.KillPlayersOnQuit {
+		m.players.StopAll()
+	}
+	m.jobs.Close()
+	m.recorder.StopAll()
+	m.castRelay.Close()
+	m.presence.Close()
+	m.notifier.Close()
+	m.logFile.Close()
+}
+
+// userStores are the stores a Model keeps the user's data in. Sessions
+// served over SSH share one set, so they do not overwrite each other's saves.
+type userStores struct {
+	schedule  *scheduleStore
+	watched   *historyStore
+	favorites *favoritesStore
+	slots     *slotsStore
+	// notes says which stores are kept in memory only, for the debug pane.
+	notes []string
+}
+
+// openUserStores loads the stores from the data directory.
+func openUserStores() userStores {
+	var s userStores
+	var err error
+	if s.schedule, err = openScheduleStore(); err != nil {
+		s.notes = append(s.notes, fmt.Sprintf("(reminders not persisted: %v)", err))
+	}
+	if s.watched, err = openHistoryStore(); err != nil {
+		s.notes = append(s.notes, fmt.Sprintf("(watch history not persisted: %v)", err))
+	}
+	if s.favorites, err = openFavoritesStore(); err != nil {
+		s.notes = append(s.notes, fmt.Sprintf("(favorites not persisted: %v)", err))
+	}
+	if s.slots, err = openSlotsStore(); err != nil {
+		s.notes = append(s.notes, fmt.Sprintf("(quick-launch slots not persisted: %v)", err))
+	}
+	return s
+}
+
+func New(opts Options) Model {
+	return newModel(opts, openUserStores())
+}
+
+// newModel builds a Model keeping the user's data in stores.
+func newModel(opts Options, stores userStores) Model {
+	opts = opts.withDefaults()
+	base := ResolveBaseURL(opts.BaseURL)
+	client := NewClient(base, opts.APITimeout)
+	client.SetMirrors(opts.Mirrors)
+	client.SetViewCountURL(opts.ViewCountURL)
+	extract := extractFunc(extractM3U8)
+	if opts.Demo {
+		client = newDemoClient(opts.APITimeout)
+		extract = demoExtract
+	}
 	styles := NewStyles()
+	if opts.ASCII {
+		styles = NewASCIIStyles()
+	}
+	styles.Glyphs = styles.Glyphs.Merge(opts.Glyphs)
+	kickoff := &kickoffClock{relative: opts.RelativeTimes}
 
 	m := Model{
-		apiClient:   client,
-		styles:      styles,
-		keys:        defaultKeys(),
-		help:        help.New(),
-		focus:       focusSports,
-		currentView: viewMain,
-		debugLines:  []string{},
+		opts:          opts,
+		apiClient:     client,
+		extract:       extract,
+		styles:        styles,
+		keys:          defaultKeys(),
+		help:          newHelp(styles),
+		progress:      newProgress(styles),
+		prefetch:      newStreamPrefetcher(),
+		sportPrefetch: newSportPrefetcher(),
+		kickoff:       kickoff,
+		kickoffAlerts: newKickoffAlerts(),
+		requests:      newRequestTracker(),
+		extractions:   map[string]context.CancelFunc{},
+		extracted:     map[string]string{},
+		health:        map[string]streamHealth{},
+		watchdogCount: map[string]int{},
+		matchCache:    map[string]matchesLoadedMsg{},
+		focus:         focusSports,
+		currentView:   viewMain,
+		debugLines:    []string{},
+		logView:       newLogView(),
+		crash:         newCrashTrail(),
+		ui:            &uiLogger{},
+		reminderKeys:  defaultReminderKeys(),
+		filterInput:   newFilterInput(),
+		search:        newSearchModel(kickoff),
+		history:       newHistoryList(),
+		agenda:        newAgendaModel(kickoff),
+		categories:    newCategoryList(),
+		agendaKeys:    defaultAgendaKeys(),
+		historyKeys:   defaultHistoryKeys(),
+		playbackKeys:  defaultPlaybackKeys(),
+		recordings:    newRecordingsList(),
+		recordingKeys: defaultRecordingKeys(),
+		jobList:       newJobsList(),
+		jobKeys:       defaultJobKeys(),
+		playerList:    newPlayersList(),
+		playerKeys:    defaultPlayerKeys(),
+		quality:       newQualityList(),
+		castTargets:   newCastList(),
+		castKeys:      defaultCastKeys(),
+	}
+
+	ui := m.ui
+	m.notifier = newDesktopNotifier(func(tag, action string) {
+		ui.Send(notificationActionMsg{Tag: tag, Action: action})
+	})
+	m.recorder = newRecorder(opts.FFmpegPath, opts.RecordDir, opts.DownloadDir, opts.proxy(), func(r recordingInfo) {
+		ui.Send(recordingEndedMsg(r))
+	})
+	m.jobs = newJobManager(func(j jobInfo) {
+		ui.Send(jobUpdatedMsg(j))
+	})
+	m.players = newPlayerTracker(func(p playerInfo, err error) {
+		ui.Send(playerExitedMsg{Info: p, Err: err})
+	})
+	m.presence = newDiscordPresence(opts.DiscordClientID, m.ui.Log)
+	m.castRelay = newCastRelay(opts.CastPort, opts.proxy(), opts.headerPolicy())
+
+	m.streamPref = parseStreamPreference(opts.StreamPreference)
+	if prefs, err := loadViewPrefs(); err == nil {
+		m.hdOnly = prefs.HDOnly
+		m.languageOnly = prefs.LanguageOnly
+		m.adminStreams = parseAdminMode(prefs.AdminStreams)
+		m.matchSort = parseMatchSort(prefs.MatchSort)
+		m.matchFilter = parseMatchFilter(prefs.MatchFilter)
+		m.streamSort = parseStreamSort(prefs.StreamSort)
+	} else {
+		m.debugLines = append(m.debugLines, fmt.Sprintf("(view preferences ignored: %v)", err))
 	}
 
-	if debug {
+	m.schedule = stores.schedule
+	m.watched = stores.watched
+	m.favorites = stores.favorites
+	m.slots = stores.slots
+	m.debugLines = append(m.debugLines, stores.notes...)
+
+	extras, err := opts.extraProviders()
+	if err != nil {
+		m.debugLines = append(m.debugLines, fmt.Sprintf("(extra providers ignored: %v)", err))
+		extras = nil
+	}
+	cache, err := openResponseCache(opts.CacheTTL, opts.DiskCache && !opts.Demo)
+	if err != nil {
+		m.debugLines = append(m.debugLines, fmt.Sprintf("(API cache kept in memory only: %v)", err))
+	}
+	// Extra providers speaking the streamed API get the same settings.
+	clients := []*Client{client}
+	for _, p := range extras {
+		if c, ok := p.(*Client); ok {
+			clients = append(clients, c)
+		}
+	}
+	for _, c := range clients {
+		c.SetStrict(opts.Strict)
+		c.SetRetries(opts.APIRetries)
+		c.SetLogger(m.ui.Log)
+		if !opts.Demo {
+			c.SetProxy(opts.proxy())
+		}
+		c.SetCache(cache)
+		if opts.SchemaCheck {
+			c.SetSchemaWarnings(m.ui.Log)
+		}
+		if opts.Debug {
+			c.SetTracer(m.ui.Log)
+		}
+	}
+	if opts.SchemaCheck {
+		m.debugLines = append(m.debugLines, "(API schema drift checks enabled)")
+	}
+	m.provider = newProviderSet(client, extras, m.ui.Log)
+	if m.hooks, err = opts.hookRunner(m.ui.Log); err != nil {
+		m.debugLines = append(m.debugLines, fmt.Sprintf("(hooks ignored: %v)", err))
+	}
+	if len(extras) > 0 {
+		m.debugLines = append(m.debugLines, fmt.Sprintf("(providers: %s)", m.provider.Name()))
+	}
+
+	if opts.Demo {
+		m.debugLines = append(m.debugLines, "(demo mode: fixture data, fake extractor)")
+	} else {
+		// Demo fixtures have no pictures to fetch.
+		m.art = newArtCache(graphicsFromEnv(opts.Images))
+	}
+
+	if opts.Debug {
 		m.debugLines = append(m.debugLines, "(debug logging enabled)")
 	}
+	if level := opts.fileLogLevel(); level != levelOff {
+		if f, err := openDebugFile(opts.LogFile, level); err == nil {
+			m.logFile = f
+			m.debugLines = append(m.debugLines, fmt.Sprintf("(logging %s and above to %s)", level, f.path))
+		} else {
+			m.debugLines = append(m.debugLines, fmt.Sprintf("(log file unavailable: %v)", err))
+		}
+	}
 
 	m.sports = NewListColumn[Sport]("Sports", func(s Sport) string { return s.Name })
-	m.matches = NewListColumn[Match]("Popular Matches", func(mt Match) string {
-		when := time.UnixMilli(mt.Date).Local().Format("Jan 2 15:04")
+	m.teams = newTeamsModel(kickoff, m.favorites)
+	m.matches = m.newMatchesColumn()
+	m.streams = m.newStreamsColumn()
+	m.tabs = []workspace{m.saveWorkspace()}
+
+	m.status = fmt.Sprintf("Using API %s", base)
+	if opts.Demo {
+		m.status = "Using the bundled demo fixtures"
+	}
+	m.progress.start(opSports, "Loading sports")
+	m.progress.start(opMatches, "Loading popular matches")
+	m.requests.begin(opSports, opSports)
+	m.requests.begin(opMatches, matchesTarget(popularSportID))
+	return m
+}
+
+// newMatchesColumn builds a Matches column; every tab has its own.
+func (m Model) newMatchesColumn() *ListColumn[Match] {
+	favs, kickoff := m.favorites, m.kickoff
+	tagProviders := multiProvider(m.provider)
+	matches := NewListColumn[Match]("Popular Matches", func(mt Match) string {
 		title := mt.Title
 		if mt.Teams != nil && mt.Teams.Home != nil && mt.Teams.Away != nil {
 			title = fmt.Sprintf("%s vs %s", mt.Teams.Home.Name, mt.Teams.Away.Name)
 		}
+		if favs.Matches(mt) {
+			title = "★ " + title
+		}
+		if tagProviders && mt.Provider != "" {
+			title = "[" + mt.Provider + "] " + title
+		}
 
 		viewers := ""
 		if mt.Viewers > 0 {
 			viewers = fmt.Sprintf(" (%s viewers)", formatViewerCount(mt.Viewers))
 		}
-
-		return fmt.Sprintf("%s  %s%s (%s)", when, title, viewers, mt.Category)
+		return fmt.Sprintf("%s  %s%s (%s) %s", kickoff.label(mt, time.Now()), title, viewers, mt.Category, formatSourceCount(len(mt.Sources)))
 	})
-	m.matches.SetSeparator(func(prev, curr Match) (string, bool) {
-		currDay := time.UnixMilli(curr.Date).Local().Format("Jan 2")
-		prevDay := ""
-		if prev.Date != 0 {
-			prevDay = time.UnixMilli(prev.Date).Local().Format("Jan 2")
-		}
-
-		if prevDay == "" || prevDay != currDay {
-			return currDay, true
+	peak := 0
+	matches.OnItems(func(items []Match) { peak = maxMatchViewers(items) })
+	matches.SetDecorator(func(mt Match, text string) string {
+		text = colorLiveBadge(kickoff.label(mt, time.Now()), text)
+		if mt.Viewers <= 0 {
+			return text
 		}
-		return "", false
+		label := fmt.Sprintf("(%s viewers)", formatViewerCount(mt.Viewers))
+		return colorViewerLabel(text, label, mt.Viewers, peak)
 	})
-	m.streams = NewListColumn[Stream]("Streams", func(st Stream) string {
+	return matches
+}
+
+// newStreamsColumn builds a Streams column; every tab has its own.
+func (m Model) newStreamsColumn() *ListColumn[Stream] {
+	health := m.health
+	streams := NewListColumn[Stream]("Streams", func(st Stream) string {
 		quality := "SD"
 		if st.HD {
 			quality = "HD"
 		}
 		viewers := formatViewerCount(st.Viewers)
-		return fmt.Sprintf("#%d %s (%s) – %s — (%s viewers)", st.StreamNo, st.Language, quality, st.Source, viewers)
+		text := fmt.Sprintf("#%d %s (%s) – %s — (%s viewers)", st.StreamNo, st.Language, quality, st.Source, viewers)
+		if h, ok := health[st.EmbedURL]; ok {
+			text = h.icon() + " " + text
+			if label := h.label(); label != "" {
+				text += " · " + label
+			}
+		}
+		return text
+	})
+	peak := 0
+	streams.OnItems(func(items []Stream) { peak = maxStreamViewers(items) })
+	streams.SetDecorator(func(st Stream, text string) string {
+		label := fmt.Sprintf("(%s viewers)", formatViewerCount(st.Viewers))
+		return colorViewerLabel(text, label, st.Viewers, peak)
 	})
-	m.streams.SetSeparator(func(prev, curr Stream) (string, bool) {
+	streams.SetSeparator(func(prev, curr Stream) (string, bool) {
 		isAdmin := strings.EqualFold(curr.Source, "admin")
 		wasAdmin := strings.EqualFold(prev.Source, "admin")
 		if isAdmin && !wasAdmin {
@@ -237,9 +789,7 @@ func New(debug bool) Model {
 		}
 		return "", false
 	})
-
-	m.status = fmt.Sprintf("Using API %s | Loading sports and matches…", base)
-	return m
+	return streams
 }
 
 // ────────────────────────────────
@@ -247,18 +797,112 @@ func New(debug bool) Model {
 // ────────────────────────────────
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.fetchSports(), m.fetchPopularMatches())
+	cmds := []tea.Cmd{
+		// New started the progress entries for these fetches, leaving the
+		// spinner's first tick to be sent from here.
+		m.progress.spinner.Tick,
+		trackedCmd(opSports, opSports, m.fetchSports(false)),
+		trackedCmd(opMatches, matchesTarget(popularSportID), m.fetchPopularMatches()),
+		m.checkSchedule(),
+		m.checkPlayerCmd(),
+		m.checkMirrors(),
+		scheduleTick(),
+		liveTick(),
+	}
+	if m.opts.kickoffAlertsEnabled() {
+		cmds = append(cmds, kickoffAlertTick())
+	}
+	if !m.opts.NoUpdateCheck && !m.opts.Demo && !updateChecksDisabled() {
+		cmds = append(cmds, safeCmd("update check", m.crash, checkForUpdate(m.opts.Version, m.opts.proxy())))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m Model) View() string {
 	switch m.currentView {
 	case viewHelp:
 		return m.renderHelpPanel()
+	case viewReminders:
+		return m.renderRemindersPanel()
+	case viewSearch:
+		return m.renderSearchView()
+	case viewHistory:
+		return m.renderHistoryView()
+	case viewRecordings:
+		return m.renderRecordingsView()
+	case viewJobs:
+		return m.renderJobsView()
+	case viewPlayers:
+		return m.renderPlayersView()
+	case viewQuality:
+		return m.renderQualityView()
+	case viewLog:
+		return m.renderLogView()
+	case viewAgenda:
+		return m.renderAgendaView()
+	case viewCategories:
+		return m.renderCategoryView()
+	case viewTeams:
+		return m.renderTeamsView()
+	case viewCast:
+		return m.renderCastView()
 	default:
 		return m.renderMainView()
 	}
 }
 
+// layoutColumns sizes the main view's columns to the terminal, making room
+// for the details column when it is shown.
+func (m *Model) layoutColumns() {
+	debugPaneHeight := 7
+	statusHeight := 1
+	helpHeight := 2
+	reservedHeight := debugPaneHeight + statusHeight + helpHeight
+	if len(m.tabs) > 1 {
+		reservedHeight++ // tab bar
+	}
+	usableHeight := m.TerminalHeight - reservedHeight
+	if usableHeight < 5 {
+		usableHeight = 5
+	}
+	totalAvailableWidth := int(float64(m.TerminalWidth) * 0.95)
+	borderPadding := 4
+	totalBorderSpace := borderPadding * 3
+	if m.showDetails {
+		// The details box also needs its border and the gap before it.
+		totalBorderSpace += borderPadding + 3
+	}
+	availableWidth := totalAvailableWidth - totalBorderSpace
+
+	// Allocate widths with weights: Sports=3, Matches=10, Streams=5 (18 total)
+	// Streams gain an additional ~20% width by borrowing space from Matches.
+	// The details column takes its share mostly from Matches.
+	weightTotal := 18
+	sportsWeight, matchesWeight, streamsWeight, detailsWeight := 3, 10, 5, 0
+	if m.showDetails {
+		weightTotal = 20
+		matchesWeight, streamsWeight, detailsWeight = 7, 4, 6
+	}
+	unit := availableWidth / weightTotal
+	remainder := availableWidth - (unit * weightTotal)
+
+	sportsWidth := unit * sportsWeight
+	matchesWidth := unit * matchesWeight
+	streamsWidth := unit * streamsWeight
+
+	// Assign any leftover pixels to the widest column (matches) to keep alignment.
+	matchesWidth += remainder
+
+	m.sports.SetWidth(sportsWidth + borderPadding)
+	m.matches.SetWidth(matchesWidth + borderPadding)
+	m.streams.SetWidth(streamsWidth + borderPadding)
+	m.detailsWidth = unit*detailsWeight + borderPadding
+
+	m.sports.SetHeight(usableHeight)
+	m.matches.SetHeight(usableHeight)
+	m.streams.SetHeight(usableHeight)
+}
+
 func (m Model) renderMainView() string {
 	gap := lipgloss.NewStyle().MarginRight(1)
 	sportsCol := gap.Render(m.sports.View(m.styles, m.focus == focusSports))
@@ -266,11 +910,37 @@ func (m Model) renderMainView() string {
 	streamsCol := m.streams.View(m.styles, m.focus == focusStreams)
 
 	cols := lipgloss.JoinHorizontal(lipgloss.Top, sportsCol, matchesCol, streamsCol)
+	if m.showDetails {
+		art := m.detailsArt()
+		left := lipgloss.JoinHorizontal(lipgloss.Top, sportsCol, matchesCol, gap.Render(streamsCol))
+		cols = lipgloss.JoinHorizontal(lipgloss.Top, left, m.renderDetailsColumn(art))
+		// The picture sits inside the details box's border and padding.
+		cols = m.art.place(cols, art, detailsArtTop, lipgloss.Width(left)+2)
+	}
 	colsWidth := lipgloss.Width(cols)
 	debugPane := m.renderDebugPane(colsWidth)
 	status := m.renderStatusLine()
 	keys := helpKeyMap{base: m.keys, showMPV: m.canUseMPVShortcut()}
-	return lipgloss.JoinVertical(lipgloss.Left, cols, debugPane, status, m.help.View(keys))
+	footer := m.styles.Text(m.help.View(keys))
+	if m.playing.active() {
+		footer = m.renderPlaybackBar()
+	}
+	if bar := m.renderTabBar(); bar != "" {
+		cols = lipgloss.JoinVertical(lipgloss.Left, bar, cols)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, cols, debugPane, status, footer)
+}
+
+// newHelp returns the short/full help renderer, with ASCII separators when the
+// styles call for them.
+func newHelp(styles Styles) help.Model {
+	h := help.New()
+	if styles.ASCII {
+		h.ShortSeparator = " | "
+		h.FullSeparator = "   "
+		h.Ellipsis = "..."
+	}
+	return h
 }
 
 func (m Model) canUseMPVShortcut() bool {
@@ -282,11 +952,32 @@ func (m Model) canUseMPVShortcut() bool {
 
 func (m Model) renderStatusLine() string {
 	focusLabel := m.currentFocusLabel()
-	statusText := fmt.Sprintf("%s  | Focus: %s (←/→)", m.status, focusLabel)
+	current := m.status
+	if m.progress.active() {
+		current = m.progress.View()
+	}
+	statusText := fmt.Sprintf("%s  | Focus: %s (←/→)  | API: %s", current, focusLabel, apiHost(m.apiClient.Base()))
+	if i, n := m.apiClient.MirrorPosition(); n > 1 {
+		statusText += fmt.Sprintf(" (mirror %d of %d)", i, n)
+	}
+	if m.latestRelease != "" {
+		statusText += fmt.Sprintf("  | %s available: streamed-tui self-update", m.latestRelease)
+	}
+	if m.filtering {
+		return m.styles.Status.Render(m.filterInput.View() + m.styles.Text(fmt.Sprintf("  | Filtering %s (Enter keep, Esc clear)", focusLabel)))
+	}
 	if m.lastError != nil {
-		return m.styles.Error.Render(fmt.Sprintf("⚠️  %v  | Focus: %s (Esc to dismiss)", m.lastError, focusLabel))
+		return m.styles.Error.Render(m.styles.Text(fmt.Sprintf("⚠️  %v  | Focus: %s (Esc to dismiss)", m.lastError, focusLabel)))
 	}
-	return m.styles.Status.Render(statusText)
+	return m.styles.Status.Render(m.styles.Text(statusText))
+}
+
+// apiHost trims the scheme from a base URL for compact display.
+func apiHost(base string) string {
+	if u, err := url.Parse(base); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return base
 }
 
 func (m Model) currentFocusLabel() string {
@@ -306,14 +997,56 @@ func (m Model) renderHelpPanel() string {
 	header := m.styles.Title.Render("Keybindings Help")
 	bindings := [][]string{
 		{"↑/↓ or k/j", "Navigate list"},
+		{"PgUp / PgDn", "Move a page up / down"},
+		{"Home/gg, End/G", "Jump to the first / last item"},
+		{"Shift+N", "Open a tab on the match list, with the highlighted match when Matches is focused"},
+		{"1-9", "Switch to that tab"},
+		{"Ctrl+W", "Close the tab"},
+		{"M then 1-9", "Put the highlighted stream or match in a quick-launch slot (again: empty it)"},
+		{"Alt+1-9", "Launch a slot's stream, or the best stream of its match, from any view"},
 		{"←/→ or h/l", "Move focus between columns"},
 		{"Enter", "Select / Open"},
 		{"O", "Open in browser"},
 		{"P", "Open in mpv"},
-		{"R", "Refresh"},
+		{"Shift+K", "Cast the highlighted stream to a Chromecast or DLNA renderer"},
+		{"R", "Refresh sports and matches, skipping the cache"},
+		{"A", "Remind me before the highlighted match starts"},
+		{"Shift+A", "Manage reminders (snooze, lead time, cancel)"},
+		{"Shift+H", "Hide SD streams"},
+		{"Shift+L", "Show only streams in the configured languages"},
+		{"Shift+B", "Show, collapse, or hide browser-only (admin) streams"},
+		{"Shift+S", "Sort matches (time, viewers, title) or streams (ranked, viewers, HD, language, health)"},
+		{"Shift+F", "Play the highlighted stream fullscreen"},
+		{"Shift+I", "Instant play: extract and play the highlighted match's best stream"},
+		{"W", "Watch history (Enter launches a stream again)"},
+		{"Shift+C", "Agenda of the week's matches by day and hour (←/→ change the day)"},
+		{"B", "Browse teams with live or upcoming matches and their matches across sports"},
+		{"N / U / F", "Show only live matches / ones starting soon / hide finished ones (again: all)"},
+		{"V", "Pick a category (league) of the shown sport to narrow the matches to"},
+		{"Ctrl+T", "Show kickoff times relative to now (\"in 45m\", \"2h ago\") or as dates"},
+		{"Shift+R", "Record the highlighted stream with ffmpeg"},
+		{"D", "Download the highlighted stream with yt-dlp"},
+		{"Shift+D", "Recordings and downloads (Enter plays the file, X stops)"},
+		{"E", "Queue the highlighted stream for extraction"},
+		{"C", "Check every stream of the match and rank them by health"},
+		{"Shift+J", "Extraction jobs (Enter plays a found stream, X cancels or removes)"},
+		{"Y / Shift+Y", "Copy the stream's .m3u8 URL once extracted (else its embed URL) / its embed URL"},
+		{"Ctrl+L", "Full-screen debug log (/ searches, y copies a line)"},
+		{"I", "Show / hide details of the highlighted match"},
+		{"S", "Star the highlighted match"},
+		{"T / Shift+T", "Star the match's home / away team"},
+		{"Space", "Pause / resume the running mpv"},
+		{"[ / ]", "Seek the running mpv back / forward 10s"},
+		{"- / +", "Running mpv volume down / up"},
+		{"Shift+X", "Stop the running mpv"},
+		{"Shift+P", "Running players (Enter controls one from the playback bar, X stops it)"},
+		{"Ctrl+R", "Extract and play again the stream whose player just failed"},
 		{"Q", "Quit"},
 		{"F1 / ?", "Toggle this help"},
-		{"Esc", "Return to main view"},
+		{"/", "Filter the focused column (Enter keeps, Esc clears)"},
+		{"Ctrl+F", "Fuzzy search matches across all sports"},
+		{"Esc", "Return to main view / clear filter / cancel extraction"},
+		{"Ctrl+X", "Cancel the running extraction (kills the browser/runner)"},
 	}
 
 	var sb strings.Builder
@@ -326,11 +1059,11 @@ func (m Model) renderHelpPanel() string {
 	sb.WriteString("Press Esc to return.")
 
 	panel := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(m.styles.Border).
 		BorderForeground(lipgloss.Color("#FA8072")).
 		Padding(1, 2).
 		Width(int(float64(m.TerminalWidth) * 0.95)).
-		Render(sb.String())
+		Render(m.styles.Text(sb.String()))
 
 	return panel
 }
@@ -350,7 +1083,7 @@ func (m Model) renderDebugPane(widthHint int) string {
 		lines = append(lines, "")
 	}
 
-	content := strings.Join(lines, "\n")
+	content := m.styles.Text(strings.Join(lines, "\n"))
 	width := widthHint
 	if width == 0 {
 		width = int(float64(m.TerminalWidth) * 0.95)
@@ -361,7 +1094,7 @@ func (m Model) renderDebugPane(widthHint int) string {
 
 	return lipgloss.NewStyle().
 		Width(width).
-		Border(lipgloss.RoundedBorder()).
+		Border(m.styles.Border).
 		Padding(0, 1).
 		Render(header + "\n" + content)
 }
@@ -374,52 +1107,80 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case debugLogMsg:
-		m.debugLines = append(m.debugLines, string(msg))
-		if len(m.debugLines) > 200 {
-			m.debugLines = m.debugLines[len(m.debugLines)-200:]
-		}
+		m.crash.Add(string(msg))
+		m.logFile.Write(string(msg))
+		m.appendDebugLine(string(msg))
 		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.TerminalWidth = msg.Width
-		debugPaneHeight := 7
-		statusHeight := 1
-		helpHeight := 2
-		reservedHeight := debugPaneHeight + statusHeight + helpHeight
-		usableHeight := msg.Height - reservedHeight
-		if usableHeight < 5 {
-			usableHeight = 5
-		}
+		m.TerminalHeight = msg.Height
+		m.layoutColumns()
+		m.art.resize()
 		totalAvailableWidth := int(float64(msg.Width) * 0.95)
-		borderPadding := 4
-		totalBorderSpace := borderPadding * 3
-		availableWidth := totalAvailableWidth - totalBorderSpace
-
-		// Allocate widths with weights: Sports=3, Matches=10, Streams=5 (18 total)
-		// Streams gain an additional ~20% width by borrowing space from Matches.
-		weightTotal := 18
-		unit := availableWidth / weightTotal
-		remainder := availableWidth - (unit * weightTotal)
-
-		sportsWidth := unit * 3
-		matchesWidth := unit * 10
-		streamsWidth := unit * 5
-
-		// Assign any leftover pixels to the widest column (matches) to keep alignment.
-		matchesWidth += remainder
-
-		m.sports.SetWidth(sportsWidth + borderPadding)
-		m.matches.SetWidth(matchesWidth + borderPadding)
-		m.streams.SetWidth(streamsWidth + borderPadding)
-
-		m.sports.SetHeight(usableHeight)
-		m.matches.SetHeight(usableHeight)
-		m.streams.SetHeight(usableHeight)
+
+		// Search view: title, input, hint, and status surround the results.
+		m.search.results.SetWidth(totalAvailableWidth)
+		m.search.results.SetHeight(msg.Height - 4)
+		m.history.SetWidth(totalAvailableWidth)
+		m.history.SetHeight(msg.Height - 5)
+		m.recordings.SetWidth(totalAvailableWidth)
+		m.recordings.SetHeight(msg.Height - 5)
+		m.jobList.SetWidth(totalAvailableWidth)
+		m.jobList.SetHeight(msg.Height - 5)
+		m.playerList.SetWidth(totalAvailableWidth)
+		m.playerList.SetHeight(msg.Height - 5)
+		m.logView.lines.SetWidth(totalAvailableWidth)
+		m.logView.lines.SetHeight(msg.Height - 5 - logPreviewRows)
+		m.quality.SetWidth(totalAvailableWidth)
+		m.quality.SetHeight(msg.Height - 5)
+		m.agenda.list.SetWidth(totalAvailableWidth)
+		m.agenda.list.SetHeight(msg.Height - 6)
+		m.categories.SetWidth(totalAvailableWidth)
+		m.teams.list.SetWidth(totalAvailableWidth * 2 / 5)
+		m.teams.list.SetHeight(msg.Height - 6)
+		m.teams.matches.SetWidth(totalAvailableWidth - totalAvailableWidth*2/5 - 1)
+		m.teams.matches.SetHeight(msg.Height - 6)
+		m.categories.SetHeight(msg.Height - 5)
+		m.castTargets.SetWidth(totalAvailableWidth)
+		m.castTargets.SetHeight(msg.Height - 5)
 		return m, nil
 
 	case tea.KeyMsg:
+		// Slots launch from every view.
+		if key.Matches(msg, m.keys.LaunchSlot) {
+			n, _ := slotNumber(msg)
+			return m, m.launchSlot(n)
+		}
+		if m.filtering {
+			return m, m.updateFilter(msg)
+		}
+		if m.currentView == viewSearch {
+			return m, m.updateSearch(msg)
+		}
+		if m.currentView == viewLog {
+			return m, m.updateLogView(msg)
+		}
+		if m.currentView == viewTeams {
+			return m, m.updateTeams(msg)
+		}
+		if m.pendingSlot {
+			m.pendingSlot = false
+			if n, ok := slotNumber(msg); ok {
+				return m, m.assignSlot(n)
+			}
+			m.status = "No slot picked"
+			return m, nil
+		}
 		switch {
 		case msg.String() == "esc":
+			if m.currentView == viewMain && m.focusedFilter() != "" {
+				m.setFocusedFilter("")
+				return m, nil
+			}
+			if m.currentView == viewMain && m.cancelExtractions() > 0 {
+				return m, nil
+			}
 			m.currentView = viewMain
 			return m, nil
 
@@ -432,14 +1193,94 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.currentView == viewReminders {
+			return m, m.updateReminders(msg)
+		}
+		if m.currentView == viewHistory {
+			return m, m.updateHistory(msg)
+		}
+		if m.currentView == viewAgenda {
+			return m, m.updateAgenda(msg)
+		}
+		if m.currentView == viewRecordings {
+			return m, m.updateRecordings(msg)
+		}
+		if m.currentView == viewJobs {
+			return m, m.updateJobs(msg)
+		}
+		if m.currentView == viewPlayers {
+			return m, m.updatePlayers(msg)
+		}
+		if m.currentView == viewQuality {
+			return m, m.updateQualityPicker(msg)
+		}
+		if m.currentView == viewCategories {
+			return m, m.updateCategoryPicker(msg)
+		}
+		if m.currentView == viewCast {
+			return m, m.updateCastPicker(msg)
+		}
 		if m.currentView != viewMain {
 			return m, nil
 		}
+		if m.playing.active() {
+			if cmd, ok := m.updatePlayback(msg); ok {
+				return m, cmd
+			}
+		}
+
+		// A single "g" waits for a second one, as in vim.
+		top := msg.String() == "g" && m.pendingG
+		m.pendingG = msg.String() == "g" && !top
 
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
+		case top || key.Matches(msg, m.keys.Top):
+			return m, m.jump(jumpTop)
+
+		case key.Matches(msg, m.keys.Bottom):
+			return m, m.jump(jumpBottom)
+
+		case key.Matches(msg, m.keys.PageUp):
+			return m, m.jump(jumpPageUp)
+
+		case key.Matches(msg, m.keys.PageDown):
+			return m, m.jump(jumpPageDown)
+
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.refresh()
+
+		case key.Matches(msg, m.keys.Tab):
+			return m, m.switchTab(int(msg.String()[0] - '1'))
+
+		case key.Matches(msg, m.keys.NewTab):
+			return m, m.newTab()
+
+		case key.Matches(msg, m.keys.CloseTab):
+			return m, m.closeTab()
+
+		case key.Matches(msg, m.keys.Slot):
+			m.startSlotAssign()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Cancel):
+			if m.cancelExtractions() == 0 {
+				m.status = "No extraction to cancel"
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Details):
+			m.toggleDetails()
+			return m, m.loadMatchArt()
+
+		case key.Matches(msg, m.keys.Filter):
+			return m, m.startFilter()
+
+		case key.Matches(msg, m.keys.Search):
+			return m, m.openSearch()
+
 		case key.Matches(msg, m.keys.Left):
 			if m.focus > focusSports {
 				m.focus--
@@ -456,8 +1297,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.focus {
 			case focusSports:
 				m.sports.CursorUp()
+				return m, m.scheduleMatchPrefetch()
 			case focusMatches:
 				m.matches.CursorUp()
+				return m, tea.Batch(m.scheduleStreamPrefetch(), m.loadMatchArt())
 			case focusStreams:
 				m.streams.CursorUp()
 			}
@@ -467,8 +1310,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.focus {
 			case focusSports:
 				m.sports.CursorDown()
+				return m, m.scheduleMatchPrefetch()
 			case focusMatches:
 				m.matches.CursorDown()
+				return m, tea.Batch(m.scheduleStreamPrefetch(), m.loadMatchArt())
 			case focusStreams:
 				m.streams.CursorDown()
 			}
@@ -479,15 +1324,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case focusSports:
 				if sport, ok := m.sports.Selected(); ok {
 					m.lastError = nil
-					m.status = fmt.Sprintf("Loading matches for %s…", sport.Name)
 					m.streams.SetItems(nil)
-					return m, m.fetchMatchesForSport(sport)
+					m.allStreams = nil
+					m.shownMatch = ""
+					// Show what we saw earlier this session right away and
+					// refresh it in the background; a recent prefetch is
+					// not stale.
+					if cached, ok := m.matchCache[strings.ToLower(sport.ID)]; ok {
+						m.showMatches(cached, !m.sportPrefetch.fresh(sport.ID, m.opts.CacheTTL.Matches))
+					}
+					return m, m.track(opMatches, matchesTarget(sport.ID), fmt.Sprintf("Loading matches for %s", sport.Name), m.fetchMatchesForSport(sport, false))
 				}
 			case focusMatches:
 				if mt, ok := m.matches.Selected(); ok {
-					m.lastError = nil
-					m.status = fmt.Sprintf("Loading streams for %s…", mt.Title)
-					return m, m.fetchStreamsForMatch(mt)
+					return m, m.openMatch(mt)
 				}
 			case focusStreams:
 				if st, ok := m.streams.Selected(); ok {
@@ -501,12 +1351,197 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					return m, tea.Batch(
 						m.logToUI(fmt.Sprintf("Attempting extractor for %s", st.EmbedURL)),
-						m.runExtractor(st),
+						m.launchStream(st, m.currentMatch(), m.opts.Fullscreen, false),
 					)
 				}
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Remind):
+			if m.focus == focusMatches {
+				return m, m.armReminder()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.InstantPlay) && m.focus == focusMatches:
+			if mt, ok := m.matches.Selected(); ok {
+				return m, m.instantPlay(mt)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Fullscreen):
+			if m.focus != focusStreams {
+				return m, nil
+			}
+			st, ok := m.streams.Selected()
+			if !ok || strings.EqualFold(st.Source, "admin") {
+				return m, nil
+			}
+			return m, tea.Batch(
+				m.logToUI(fmt.Sprintf("Attempting extractor for %s (fullscreen)", st.EmbedURL)),
+				m.launchStream(st, m.currentMatch(), true, false),
+			)
+
+		case key.Matches(msg, m.keys.Cast):
+			if m.focus != focusStreams {
+				return m, nil
+			}
+			st, ok := m.streams.Selected()
+			if !ok || isAdminStream(st) {
+				return m, nil
+			}
+			return m, m.openCastPicker(st, m.currentMatch())
+
+		case key.Matches(msg, m.keys.Kickoff):
+			m.toggleRelativeTimes()
+			return m, nil
+
+		case key.Matches(msg, m.keys.HDOnly):
+			m.hdOnly = !m.hdOnly
+			m.applyStreams()
+			if m.hdOnly {
+				m.status = fmt.Sprintf("Showing HD streams only (%d of %d)", len(m.streams.Items()), len(m.allStreams))
+			} else {
+				m.status = fmt.Sprintf("Showing all %d streams", len(m.allStreams))
+			}
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.LanguageOnly):
+			if len(m.opts.Languages) == 0 {
+				m.status = "Set languages in the config (or --languages) to filter streams by language"
+				return m, nil
+			}
+			m.languageOnly = !m.languageOnly
+			m.applyStreams()
+			if m.languageOnly {
+				m.status = fmt.Sprintf("Showing %s streams only (%d of %d)", strings.Join(m.opts.Languages, "/"), len(m.streams.Items()), len(m.allStreams))
+			} else {
+				m.status = fmt.Sprintf("Showing streams in every language (%d of %d)", len(m.streams.Items()), len(m.allStreams))
+			}
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.LiveOnly):
+			m.setMatchFilter(filterLive)
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.Upcoming):
+			m.setMatchFilter(filterUpcoming)
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.HideFinished):
+			m.setMatchFilter(filterUnfinished)
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.Category):
+			m.openCategoryPicker()
+			return m, nil
+
+		case key.Matches(msg, m.keys.AdminStreams):
+			m.cycleAdminStreams()
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.Sort):
+			if m.focus == focusStreams {
+				m.cycleStreamSort()
+			} else {
+				m.cycleMatchSort()
+			}
+			return m, m.savePrefs()
+
+		case key.Matches(msg, m.keys.Reminders):
+			m.openReminders()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Agenda):
+			return m, m.openAgenda()
+
+		case key.Matches(msg, m.keys.Teams):
+			return m, m.openTeams()
+
+		case key.Matches(msg, m.keys.History):
+			m.openHistory()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Record):
+			if m.focus != focusStreams {
+				return m, nil
+			}
+			st, ok := m.streams.Selected()
+			if !ok || strings.EqualFold(st.Source, "admin") {
+				return m, nil
+			}
+			return m, tea.Batch(
+				m.logToUI(fmt.Sprintf("Attempting extractor for %s (recording)", st.EmbedURL)),
+				m.recordStream(st, m.currentMatch()),
+			)
+
+		case key.Matches(msg, m.keys.Download):
+			if m.focus != focusStreams {
+				return m, nil
+			}
+			st, ok := m.streams.Selected()
+			if !ok || isAdminStream(st) {
+				return m, nil
+			}
+			return m, tea.Batch(
+				m.logToUI(fmt.Sprintf("Attempting extractor for %s (download)", st.EmbedURL)),
+				m.downloadStream(st, m.currentMatch()),
+			)
+
+		case key.Matches(msg, m.keys.Recordings):
+			return m, m.openRecordings()
+
+		case key.Matches(msg, m.keys.Players):
+			return m, m.openPlayers()
+
+		case key.Matches(msg, m.keys.Retry):
+			return m, m.retryLastPlayer()
+
+		case key.Matches(msg, m.keys.Queue):
+			if m.focus != focusStreams {
+				return m, nil
+			}
+			if st, ok := m.streams.Selected(); ok {
+				return m, m.queueStream(st, m.currentMatch())
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Check):
+			if m.focus != focusStreams {
+				return m, nil
+			}
+			return m, m.checkStreams()
+
+		case key.Matches(msg, m.keys.Jobs):
+			m.openJobs()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Log):
+			m.openLogView()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Copy), key.Matches(msg, m.keys.CopyEmbed):
+			st, ok := m.streams.Selected()
+			if m.focus != focusStreams || !ok || st.EmbedURL == "" {
+				return m, nil
+			}
+			if u, ok := m.extracted[st.EmbedURL]; ok && key.Matches(msg, m.keys.Copy) {
+				return m, copyToClipboard(".m3u8 URL", u, m.remote)
+			}
+			return m, copyToClipboard("embed URL", st.EmbedURL, m.remote)
+
+		case key.Matches(msg, m.keys.Star):
+			if m.focus == focusMatches {
+				return m, m.toggleFavoriteMatch()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.StarTeam), key.Matches(msg, m.keys.StarAwayTeam):
+			if m.focus == focusMatches {
+				return m, m.toggleFavoriteTeam(key.Matches(msg, m.keys.StarAwayTeam))
+			}
+			return m, nil
+
 		case key.Matches(msg, m.keys.OpenBrowser):
 			if m.focus == focusStreams {
 				if st, ok := m.streams.Selected(); ok && st.EmbedURL != "" {
@@ -519,37 +1554,242 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case spinner.TickMsg:
+		return m, m.progress.update(msg)
+
+	case progressPhaseMsg:
+		m.progress.setPhase(msg.key, msg.phase)
+		return m, nil
+
+	case opDoneMsg:
+		if cancel, ok := m.extractions[msg.target]; ok {
+			cancel()
+			delete(m.extractions, msg.target)
+		}
+		if !m.requests.finish(msg.key, msg.target) {
+			// A newer request for the same operation superseded this one;
+			// its progress entry and result belong to the newer request.
+			return m, m.logToUI(fmt.Sprintf("Dropped stale response for %s", msg.target))
+		}
+		m.progress.done(msg.key)
+		return m.Update(msg.msg)
+
+	case allMatchesLoadedMsg:
+		return m, m.handleAllMatchesLoaded(msg)
+
+	case agendaLoadedMsg:
+		m.handleAgendaLoaded(msg)
+		return m, nil
+
+	case teamsLoadedMsg:
+		m.handleTeamsLoaded(msg)
+		return m, nil
+
+	case updateAvailableMsg:
+		m.latestRelease = msg.Latest
+		return m, nil
+
+	case mirrorsCheckedMsg:
+		m.mirrorsChecked(msg)
+		return m, nil
+
+	case liveTickMsg:
+		return m, m.refreshLiveStatus()
+
+	case scheduleTickMsg:
+		return m, tea.Batch(m.checkSchedule(), scheduleTick())
+
+	case kickoffAlertTickMsg:
+		return m, m.pollFavoriteKickoffs()
+
+	case favoritesKickedOffMsg:
+		return m, m.handleFavoritesKickedOff(msg)
+
+	case jobsDueMsg:
+		return m, m.handleJobsDue(msg)
+
+	case notificationActionMsg:
+		return m, m.openFromNotification(msg)
+
+	case autoplayStreamsMsg:
+		return m, m.handleAutoplayStreams(msg)
+
+	case instantPlayMsg:
+		return m, m.handleInstantPlay(msg)
+
+	case prefetchTickMsg:
+		return m, m.startStreamPrefetch(msg)
+
+	case sportPrefetchTickMsg:
+		return m, m.startMatchPrefetch(msg)
+
+	case matchesPrefetchedMsg:
+		return m, m.handleMatchesPrefetched(msg)
+
+	case streamsPrefetchedMsg:
+		return m, m.handleStreamsPrefetched(msg)
+
 	case sportsLoadedMsg:
-		sports := prependPopularSport(msg)
-		m.sports.SetItems(sports)
+		sports := prependFavoritesSport(prependPopularSport(msg))
+		if len(m.sports.Items()) > 0 {
+			// A refresh keeps the cursor on the same sport.
+			m.sports.ReplaceItems(sports, func(a, b Sport) bool { return a.ID == b.ID })
+		} else {
+			m.sports.SetItems(sports)
+			// Popular is what is shown on startup, so start the cursor there.
+			m.sports.Select(func(s Sport) bool { return strings.EqualFold(s.ID, popularSportID) })
+		}
 		m.lastError = nil
-		m.status = fmt.Sprintf("Loaded %d sports – pick one with Enter or stay on Popular Matches", len(sports))
-		return m, nil
+		m.status = fmt.Sprintf("Loaded %d sports – pick one with Enter or stay on Popular Matches", len(msg))
+		return m, m.openDefaultSport()
 
 	case matchesLoadedMsg:
-		m.matches.SetTitle(msg.Title)
-		m.matches.SetItems(msg.Matches)
+		m.matchCache[msg.SportID] = msg
+		m.sportPrefetch.fetched[msg.SportID] = time.Now()
+		if m.showMatchesInTabs(msg) {
+			return m, nil
+		}
+		m.showMatches(msg, false)
 		m.lastError = nil
-		m.status = fmt.Sprintf("Loaded %d matches – choose one to load streams", len(msg.Matches))
+		return m, tea.Batch(m.scheduleStreamPrefetch(), m.loadMatchArt())
+
+	case artLoadedMsg:
+		m.storeMatchArt(msg)
 		return m, nil
 
 	case streamsLoadedMsg:
-		m.streams.SetItems(msg)
+		if i := m.streamsTab(msg.MatchID); i >= 0 {
+			m.inTab(i, func() { m.showStreams(msg.MatchID, msg.Streams, false) })
+			m.status = fmt.Sprintf("Loaded %d streams in tab %d", len(msg.Streams), i+1)
+		} else {
+			m.showStreams(msg.MatchID, msg.Streams, false)
+		}
 		m.lastError = nil
-		m.status = fmt.Sprintf("Loaded %d streams – Enter to launch mpv, o to open in browser", len(msg))
-		m.focus = focusStreams
+		if msg.Partial != nil {
+			// Not cached, so opening the match again retries the failed
+			// sources.
+			m.status += " (" + failedSourcesNote(msg.Partial) + ")"
+			return m, m.logToUI(fmt.Sprintf("[streams] %v", msg.Partial))
+		}
+		m.prefetch.store(msg.MatchID, msg.Streams)
 		return m, nil
 
+	case playerStartedMsg:
+		return m, m.startPlayback(msg)
+
+	case playbackTickMsg:
+		if msg.Socket != m.playing.ipc.path {
+			return m, nil
+		}
+		return m, m.pollPlayback(true)
+
+	case playbackStatusMsg:
+		return m, m.handlePlaybackStatus(msg)
+
+	case qualityPickMsg:
+		m.openQualityPicker(msg)
+		return m, nil
+
+	case recordingStartedMsg:
+		m.lastError = nil
+		if msg.Download {
+			m.status = fmt.Sprintf("⬇ Downloading %s to %s", msg.Title, msg.Path)
+		} else {
+			m.status = fmt.Sprintf("⏺ Recording %s to %s", msg.Title, msg.Path)
+		}
+		return m, nil
+
+	case recordingEndedMsg:
+		if msg.State == recordingFailed {
+			m.status = fmt.Sprintf("%s of %s failed: %v", recordingInfo(msg).noun(), msg.Title, msg.Err)
+		} else {
+			m.status = fmt.Sprintf("%s of %s saved (%s): %s", recordingInfo(msg).noun(), msg.Title, formatByteSize(msg.Size), msg.Path)
+		}
+		if m.currentView == viewRecordings {
+			m.refreshRecordings()
+		}
+		return m, nil
+
+	case streamExtractedMsg:
+		m.extracted[msg.EmbedURL] = msg.URL
+		return m, nil
+
+	case clipboardCopiedMsg:
+		m.status = msg.status()
+		return m, nil
+
+	case jobUpdatedMsg:
+		return m, m.jobUpdated(jobInfo(msg))
+
+	case streamHealthMsg:
+		m.health[msg.EmbedURL] = msg.Health
+		m.applyStreams()
+		return m, nil
+
+	case streamsCheckedMsg:
+		m.streamsChecked(msg)
+		return m, nil
+
+	case castTargetsMsg:
+		return m, m.castTargetsFound(msg)
+
+	case castStartedMsg:
+		m.lastError = nil
+		m.status = fmt.Sprintf("📺 Casting %s to %s", msg.Title, msg.Device)
+		return m, nil
+
+	case playlistDeadMsg:
+		m.status = fmt.Sprintf("Stream #%d looks dead, not playing it: %s", msg.Stream.StreamNo, msg.Probe)
+		return m, nil
+
+	case playerExitedMsg:
+		return m, tea.Batch(m.playerExited(msg), m.syncPresence())
+
+	case playersTickMsg:
+		if m.currentView != viewPlayers {
+			return m, nil
+		}
+		m.refreshPlayers()
+		return m, playersTick()
+
+	case recordingsTickMsg:
+		if m.currentView != viewRecordings {
+			return m, nil
+		}
+		m.refreshRecordings()
+		return m, recordingsTick()
+
 	case launchStreamMsg:
 		m.lastError = nil
 		m.status = fmt.Sprintf("🎥 Launched mpv: %s", msg.URL)
 		return m, nil
 
+	case playerMissingMsg:
+		m.lastError = msg.err
+		m.status = "No player found – streams cannot be played until one is installed"
+		return m, nil
+
 	case errorMsg:
 		m.lastError = msg
 		m.status = "Encountered an error while contacting the API"
 		return m, nil
 	}
+	// Cursor blink and other textinput messages.
+	if m.filtering {
+		var cmd tea.Cmd
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		return m, cmd
+	}
+	if m.currentView == viewSearch {
+		var cmd tea.Cmd
+		m.search.input, cmd = m.search.input.Update(msg)
+		return m, cmd
+	}
+	if m.logView.searching {
+		var cmd tea.Cmd
+		m.logView.input, cmd = m.logView.input.Update(msg)
+		return m, cmd
+	}
 	return m, nil
 }
 
@@ -557,45 +1797,223 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // FETCHERS
 // ────────────────────────────────
 
-func (m Model) fetchSports() tea.Cmd {
-	return func() tea.Msg {
-		sports, err := m.apiClient.GetSports(context.Background())
+// apiContext returns the context for an API request; fresh skips cached
+// responses, as Refresh does.
+func apiContext(fresh bool) context.Context {
+	if fresh {
+		return bypassCache(context.Background())
+	}
+	return context.Background()
+}
+
+func (m Model) fetchSports(fresh bool) tea.Cmd {
+	return safeCmd("fetch sports", m.crash, func() tea.Msg {
+		sports, err := m.provider.ListSports(apiContext(fresh))
 		if err != nil {
 			return errorMsg(err)
 		}
 		return sportsLoadedMsg(sports)
-	}
+	})
 }
 
 func (m Model) fetchPopularMatches() tea.Cmd {
-	return func() tea.Msg {
-		matches, err := m.apiClient.GetPopularMatches(context.Background())
+	return safeCmd("fetch popular matches", m.crash, func() tea.Msg {
+		matches, err := m.provider.ListMatches(context.Background(), popularSportID)
 		if err != nil {
 			return errorMsg(err)
 		}
-		return matchesLoadedMsg{Matches: matches, Title: "Popular Matches"}
+		return matchesLoadedMsg{SportID: popularSportID, Matches: matches, Title: "Popular Matches"}
+	})
+}
+
+func (m Model) fetchMatchesForSport(s Sport, fresh bool) tea.Cmd {
+	if strings.EqualFold(s.ID, favoritesSportID) {
+		return m.fetchFavoriteMatches(fresh)
 	}
+	return safeCmd("fetch matches", m.crash, func() tea.Msg {
+		matches, err := m.provider.ListMatches(apiContext(fresh), s.ID)
+		if err != nil {
+			return errorMsg(err)
+		}
+		return matchesLoadedMsg{SportID: strings.ToLower(s.ID), Matches: matches, Title: matchesTitle(s)}
+	})
 }
 
-func (m Model) fetchMatchesForSport(s Sport) tea.Cmd {
-	return func() tea.Msg {
-		get := func() ([]Match, error) {
-			if strings.EqualFold(s.ID, "popular") {
-				return m.apiClient.GetPopularMatches(context.Background())
-			}
-			return m.apiClient.GetMatchesBySport(context.Background(), s.ID)
+// matchesTitle is the Matches column title for a sport.
+func matchesTitle(s Sport) string {
+	if strings.EqualFold(s.ID, popularSportID) {
+		return "Popular Matches"
+	}
+	return fmt.Sprintf("Matches (%s)", s.Name)
+}
+
+// staleSuffix marks a column showing cached data while a refresh is running.
+const staleSuffix = " · stale"
+
+// showMatches renders a match list. When the same sport is already shown the
+// refreshed list is diffed in, keeping the cursor on the same match.
+func (m *Model) showMatches(msg matchesLoadedMsg, stale bool) {
+	if m.shownSport != msg.SportID {
+		m.matches.SetFilter("")
+		m.matches.SetItems(nil)
+		m.matchCategory = ""
+	}
+	m.shownSport = msg.SportID
+	m.allMatches = msg.Matches
+	m.matchesTitle = msg.Title
+	m.matchesStale = stale
+	m.applyMatches()
+	m.status = fmt.Sprintf("Loaded %d matches – choose one to load streams", len(msg.Matches))
+	if shown := len(m.matches.Items()); shown < len(msg.Matches) {
+		m.status = fmt.Sprintf("Loaded %d matches, %d %s – choose one to load streams", len(msg.Matches), shown, m.matchFiltersLabel())
+	}
+	if stale {
+		m.status = fmt.Sprintf("Showing %d cached matches – refreshing…", len(msg.Matches))
+	}
+}
+
+// showStreams renders a stream list and moves focus to it, diffing refreshed
+// data into the list when the same match is already shown.
+func (m *Model) showStreams(matchID string, streams []Stream, stale bool) {
+	if m.shownMatch != matchID {
+		m.streams.SetFilter("")
+		m.streams.SetItems(nil)
+	}
+	m.shownMatch = matchID
+	m.allStreams = streams
+	m.streamsStale = stale
+	m.applyStreams()
+	m.focus = focusStreams
+	m.status = fmt.Sprintf("Loaded %d streams – Enter to launch mpv, o to open in browser", len(streams))
+	if stale {
+		m.status = fmt.Sprintf("Showing %d cached streams – refreshing…", len(streams))
+	}
+}
+
+// openMatch shows a match's streams, from the cache when fresh, and fetches
+// them otherwise.
+func (m *Model) openMatch(mt Match) tea.Cmd {
+	m.lastError = nil
+	m.openedMatch = mt
+	streams, fresh, cached := m.prefetch.lookup(mt.ID)
+	if cached {
+		m.showStreams(mt.ID, streams, !fresh)
+		if fresh {
+			return nil
 		}
+	}
+	m.prefetch.cancelInFlight()
+	return m.track(opStreams, "streams:"+mt.ID, fmt.Sprintf("Loading streams for %s", mt.Title), m.fetchStreamsForMatch(mt))
+}
 
-		matches, err := get()
-		if err != nil {
-			return errorMsg(err)
+// currentMatch returns the match whose streams are shown.
+func (m Model) currentMatch() Match {
+	if m.openedMatch.ID == m.shownMatch {
+		return m.openedMatch
+	}
+	return Match{ID: m.shownMatch}
+}
+
+// applyStreams shows the current match's streams through the active filters,
+// keeping the cursor on the same stream where possible.
+func (m *Model) applyStreams() {
+	title := "Streams"
+	var filters []string
+	if m.hdOnly {
+		filters = append(filters, "HD only")
+	}
+	languages := m.opts.Languages
+	if m.languageOnly && len(languages) > 0 {
+		filters = append(filters, strings.Join(languages, "/"))
+	} else {
+		languages = nil
+	}
+	if m.adminStreams == adminHidden {
+		filters = append(filters, "no browser-only")
+	}
+	visible := m.allStreams
+	if len(filters) > 0 || m.adminStreams != adminShown {
+		visible = make([]Stream, 0, len(m.allStreams))
+		for _, st := range m.allStreams {
+			if (!m.hdOnly || st.HD) && (languages == nil || streamInLanguages(st, languages)) {
+				visible = append(visible, st)
+			}
 		}
-		title := fmt.Sprintf("Matches (%s)", s.Name)
-		if strings.EqualFold(s.ID, "popular") {
-			title = "Popular Matches"
+	}
+	if len(filters) > 0 {
+		title += " (" + strings.Join(filters, ", ") + ")"
+	}
+	visible = m.foldAdminStreams(visible)
+	if m.streamSort != sortStreamsRanked {
+		title += " · by " + m.streamSort.String()
+		visible = sortStreams(visible, m.streamSort, m.health)
+	}
+	if m.streamsStale {
+		title += staleSuffix
+	}
+	m.streams.SetTitle(title)
+	m.streams.ReplaceItems(visible, func(a, b Stream) bool { return a.EmbedURL == b.EmbedURL })
+}
+
+// openDefaultSport selects the configured default sport once the sports list
+// first arrives and loads its matches in place of Popular.
+func (m *Model) openDefaultSport() tea.Cmd {
+	want := strings.TrimSpace(m.opts.DefaultSport)
+	if want == "" || m.defaultSportDone {
+		return nil
+	}
+	m.defaultSportDone = true
+	if !m.sports.Select(func(s Sport) bool {
+		return strings.EqualFold(s.ID, want) || strings.EqualFold(s.Name, want)
+	}) {
+		return m.logToUI(fmt.Sprintf("Default sport %q not found", want))
+	}
+	sport, _ := m.sports.Selected()
+	if strings.EqualFold(sport.ID, popularSportID) {
+		return nil
+	}
+	return m.track(opMatches, matchesTarget(sport.ID), fmt.Sprintf("Loading matches for %s", sport.Name), m.fetchMatchesForSport(sport, false))
+}
+
+// jump moves the focused column's cursor by a page or to either end. Landing
+// on another sport or match starts prefetching it, as Up and Down do.
+func (m *Model) jump(to listJump) tea.Cmd {
+	switch m.focus {
+	case focusSports:
+		m.sports.Jump(to)
+		return m.scheduleMatchPrefetch()
+	case focusMatches:
+		m.matches.Jump(to)
+		return tea.Batch(m.scheduleStreamPrefetch(), m.loadMatchArt())
+	case focusStreams:
+		m.streams.Jump(to)
+	}
+	return nil
+}
+
+// refresh reloads the sports list and the match list on screen, bypassing
+// the response cache.
+func (m *Model) refresh() tea.Cmd {
+	m.lastError = nil
+	sport := Sport{ID: popularSportID, Name: "Popular"}
+	for _, s := range m.sports.Items() {
+		if m.shownSport != "" && strings.EqualFold(s.ID, m.shownSport) {
+			sport = s
+			break
 		}
-		return matchesLoadedMsg{Matches: matches, Title: title}
 	}
+	return tea.Batch(
+		m.track(opSports, opSports, "Refreshing sports", m.fetchSports(true)),
+		m.track(opMatches, matchesTarget(sport.ID), fmt.Sprintf("Refreshing matches for %s", sport.Name), m.fetchMatchesForSport(sport, true)),
+	)
+}
+
+// popularSportID is the pseudo-sport that lists popular matches across sports.
+const popularSportID = "popular"
+
+// matchesTarget identifies a match list request for in-flight tracking.
+func matchesTarget(sportID string) string {
+	return "matches:" + strings.ToLower(sportID)
 }
 
 func prependPopularSport(sports []Sport) []Sport {
@@ -609,55 +2027,268 @@ func prependPopularSport(sports []Sport) []Sport {
 }
 
 func (m Model) fetchStreamsForMatch(mt Match) tea.Cmd {
-	return func() tea.Msg {
-		streams, err := m.apiClient.GetStreamsForMatch(context.Background(), mt)
+	return safeCmd("fetch streams", m.crash, func() tea.Msg {
+		streams, err := m.provider.ListStreams(context.Background(), mt)
+		partial, err := partialStreams(err)
 		if err != nil {
 			return errorMsg(err)
 		}
-		return streamsLoadedMsg(reorderStreams(streams))
+		return streamsLoadedMsg{MatchID: mt.ID, Streams: reorderStreams(streams, m.streamPref), Partial: partial}
+	})
+}
+
+// partialStreams sorts a GetStreamsForMatch error into failed sources that
+// still left others to show, and an error that nothing could be loaded.
+func partialStreams(err error) (*StreamSourcesError, error) {
+	var srcErr *StreamSourcesError
+	if errors.As(err, &srcErr) && len(srcErr.Failed) < srcErr.Total {
+		return srcErr, nil
 	}
+	return nil, err
+}
+
+// failedSourcesNote summarizes failed sources for the status line.
+func failedSourcesNote(e *StreamSourcesError) string {
+	names := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		names[i] = f.Source.Source
+	}
+	return fmt.Sprintf("%d of %d sources failed: %s", len(e.Failed), e.Total, strings.Join(names, ", "))
 }
 
 // ────────────────────────────────
 // EXTRACTOR (chromedp integration)
 // ────────────────────────────────
 
-func (m Model) runExtractor(st Stream) tea.Cmd {
-	return func() tea.Msg {
+// launchStream extracts a stream of mt and plays it, tracking progress.
+// Automatic launches, which nobody is waiting at the keyboard for, never
+// open the quality picker.
+func (m *Model) launchStream(st Stream, mt Match, fullscreen, automatic bool) tea.Cmd {
+	return m.trackExtraction("extract:"+st.EmbedURL, fmt.Sprintf("Extracting stream #%d", st.StreamNo), func(ctx context.Context) tea.Cmd {
+		return m.runExtractor(ctx, st, m.fallbackStreams(st, mt), mt, fullscreen, automatic)
+	})
+}
+
+// fallbackStreams returns the streams to try when extracting st fails: up to
+// FallbackStreams of those listed after it in the Streams column, wrapping
+// around, without browser-only ones. Only the shown match's streams are
+// known, so other matches get none.
+func (m Model) fallbackStreams(st Stream, mt Match) []Stream {
+	if m.opts.FallbackStreams <= 0 || mt.ID == "" || mt.ID != m.shownMatch {
+		return nil
+	}
+	items := m.streams.Items()
+	idx := slices.IndexFunc(items, func(s Stream) bool { return s.EmbedURL == st.EmbedURL })
+	if idx < 0 {
+		return nil
+	}
+	var out []Stream
+	for i := 1; i < len(items) && len(out) < m.opts.FallbackStreams; i++ {
+		if s := items[(idx+i)%len(items)]; !isAdminStream(s) && s.EmbedURL != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// trackExtraction tracks an extraction like any other operation and keeps
+// its cancel function so the user can stop it.
+func (m *Model) trackExtraction(target, label string, run func(context.Context) tea.Cmd) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := m.track(opExtract, target, label, run(ctx))
+	if cmd == nil {
+		cancel()
+		return nil
+	}
+	m.extractions[target] = cancel
+	return cmd
+}
+
+// cancelExtractions stops every extraction in flight, killing the runner or
+// browser with its context, and returns how many there were.
+func (m *Model) cancelExtractions() int {
+	n := len(m.extractions)
+	for target, cancel := range m.extractions {
+		cancel()
+		delete(m.extractions, target)
+	}
+	if n > 0 {
+		m.status = fmt.Sprintf("Canceled %d extraction(s)", n)
+	}
+	return n
+}
+
+func (m Model) runExtractor(ctx context.Context, st Stream, fallbacks []Stream, mt Match, fullscreen, automatic bool) tea.Cmd {
+	return safeCmd("extractor", m.crash, func() tea.Msg {
 		if st.EmbedURL == "" {
 			return debugLogMsg("Extractor aborted: empty embed URL")
 		}
 
-		logcb := func(line string) {
-			m.debugLines = append(m.debugLines, line)
-			if len(m.debugLines) > 200 {
-				m.debugLines = m.debugLines[len(m.debugLines)-200:]
-			}
-		}
+		logcb := m.ui.Log
 
-		logcb(fmt.Sprintf("[extractor] Starting puppeteer extractor for %s", st.EmbedURL))
+		player, err := m.opts.newPlayer()
+		if err != nil {
+			logcb(fmt.Sprintf("[player] ❌ %v", err))
+			return debugLogMsg(fmt.Sprintf("Player error: %v", err))
+		}
+		if err := m.opts.checkQuality(); err != nil {
+			return errorMsg(err)
+		}
 
-		m3u8, hdrs, err := extractM3U8Lite(st.EmbedURL, func(line string) {
-			m.debugLines = append(m.debugLines, line)
-		})
+		st, res, err := m.extractWithFallback(ctx, append([]Stream{st}, fallbacks...), logcb)
+		if ctx.Err() != nil {
+			return debugLogMsg(fmt.Sprintf("Extraction of stream #%d canceled", st.StreamNo))
+		}
 		if err != nil {
-			logcb(fmt.Sprintf("[extractor] ❌ %v", err))
 			return debugLogMsg(fmt.Sprintf("Extractor failed: %v", err))
 		}
 
-		logcb(fmt.Sprintf("[extractor] ✅ Found M3U8: %s", m3u8))
-		if len(hdrs) > 0 {
-			logcb(fmt.Sprintf("[extractor] Captured %d headers", len(hdrs)))
+		if m.opts.probeEnabled() {
+			probeCtx, cancel := context.WithTimeout(ctx, m.opts.APITimeout)
+			pr := probePlaylist(probeCtx, proxyHTTPClient(m.opts.proxy()), res.URL, res.Headers)
+			cancel()
+			if ctx.Err() != nil {
+				return debugLogMsg(fmt.Sprintf("Extraction of stream #%d canceled", st.StreamNo))
+			}
+			if !pr.ok() {
+				logcb(fmt.Sprintf("[probe] ❌ %s: %s", res.URL, pr))
+				return playlistDeadMsg{Stream: st, Probe: pr}
+			}
+			logcb(fmt.Sprintf("[probe] playlist answered %s", pr))
+		}
+		ev := newHookEvent(hookStreamExtracted, mt, st, fmt.Sprintf("Extracted stream #%d of %s via %s", st.StreamNo, matchDisplayTitle(mt), res.Backend))
+		ev.URL = res.URL
+		m.hooks.Fire(ev)
+
+		p := pendingLaunch{Stream: st, Match: mt, Fullscreen: fullscreen, Result: res}
+		variantCtx, cancel := context.WithTimeout(ctx, m.opts.APITimeout)
+		quality := m.opts.Quality
+		if automatic {
+			quality = unattendedQuality(quality)
 		}
+		pick, ask := selectVariant(variantCtx, proxyHTTPClient(m.opts.proxy()), quality, &p, logcb)
+		cancel()
+		if ctx.Err() != nil {
+			return debugLogMsg(fmt.Sprintf("Extraction of stream #%d canceled", st.StreamNo))
+		}
+		if ask {
+			return pick
+		}
+		return m.launchPending(player, p, logcb)
+	})
+}
+
+// playPending launches a stream whose quality was picked in the picker.
+func (m Model) playPending(p pendingLaunch) tea.Cmd {
+	return safeCmd("player", m.crash, func() tea.Msg {
+		player, err := m.opts.newPlayer()
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("Player error: %v", err))
+		}
+		return m.launchPending(player, p, m.ui.Log)
+	})
+}
+
+// launchPending hands an extracted stream to the player and records it in
+// the watch history.
+func (m Model) launchPending(player Player, p pendingLaunch, logcb func(string)) tea.Msg {
+	st, mt, res := p.Stream, p.Match, p.Result
+	m3u8, hdrs := res.URL, res.Headers
+	req := playRequest{URL: m3u8, Headers: hdrs, Fullscreen: p.Fullscreen, Title: matchDisplayTitle(mt), Proxy: m.opts.proxy()}
+	if _, ok := player.(mpvPlayer); ok {
+		req.IPCSocket = mpvSocketPath()
+	}
+	req.Started = func(cmd *exec.Cmd) {
+		m.players.Add(playerInfo{Name: player.Name(), Title: req.Title, Stream: st, Match: mt, Socket: req.IPCSocket, Fullscreen: req.Fullscreen}, cmd)
+		m.presence.Sync(m.players.List())
+	}
+	m.ui.Send(progressPhaseMsg{key: opExtract, phase: "launching " + player.Name()})
+	if err := player.Launch(req, logcb); err != nil {
+		logcb(fmt.Sprintf("[%s] ❌ %v", player.Name(), err))
+		return debugLogMsg(fmt.Sprintf("Player error: %v", err))
+	}
+
+	logcb(fmt.Sprintf("[%s] ▶ Streaming started for %s", player.Name(), st.EmbedURL))
+	ev := newHookEvent(hookPlayerLaunched, mt, st, fmt.Sprintf("Playing %s in %s", req.Title, player.Name()))
+	ev.URL, ev.Player = m3u8, player.Name()
+	m.hooks.Fire(ev)
+	if p.JobID != 0 {
+		m.jobs.Played(p.JobID)
+	}
+	if req.IPCSocket != "" {
+		m.ui.Send(playerStartedMsg{Socket: req.IPCSocket, Title: req.Title})
+	}
+	title := matchDisplayTitle(mt)
+	if title == "" {
+		title = st.EmbedURL
+	}
+	if err := m.watched.Record(historyEntry{
+		MatchID:    mt.ID,
+		MatchTitle: title,
+		Source:     st.Source,
+		StreamNo:   st.StreamNo,
+		Language:   st.Language,
+		HD:         st.HD,
+		EmbedURL:   st.EmbedURL,
+		M3U8Host:   m3u8Host(m3u8),
+		LaunchedAt: time.Now(),
+	}); err != nil {
+		logcb(fmt.Sprintf("[history] save failed: %v", err))
+	}
+	return debugLogMsg(fmt.Sprintf("Extractor completed via %s in %s", res.Backend, formatElapsed(res.Elapsed)))
+}
 
-		if err := LaunchMPVWithHeaders(m3u8, hdrs, logcb, false); err != nil {
-			logcb(fmt.Sprintf("[mpv] ❌ %v", err))
-			return debugLogMsg(fmt.Sprintf("MPV error: %v", err))
+// streamExtractedMsg remembers the playlist found for an embed URL so it can
+// be copied.
+type streamExtractedMsg struct {
+	EmbedURL string
+	URL      string
+}
+
+// extractWithFallback extracts the first of streams that works, logging each
+// fallback attempt, and returns the stream the result came from.
+func (m Model) extractWithFallback(ctx context.Context, streams []Stream, logcb func(string)) (Stream, extractResult, error) {
+	var err error
+	for i, st := range streams {
+		if i > 0 {
+			logcb(fmt.Sprintf("[fallback] stream #%d failed, trying stream #%d (%s), attempt %d/%d",
+				streams[i-1].StreamNo, st.StreamNo, st.Source, i+1, len(streams)))
+		}
+		var res extractResult
+		res, err = m.extractStream(ctx, st, opExtract, logcb)
+		if err == nil || ctx.Err() != nil {
+			return st, res, err
 		}
+	}
+	if len(streams) > 1 {
+		err = fmt.Errorf("all %d streams failed, last: %w", len(streams), err)
+	}
+	return streams[0], extractResult{}, err
+}
+
+// extractStream runs the extractor chain for st, reporting its phases to the
+// progress indicator under opKey and its log lines to logcb.
+func (m Model) extractStream(ctx context.Context, st Stream, opKey string, logcb func(string)) (extractResult, error) {
+	logcb(fmt.Sprintf("[extractor] Starting extractor (%s) for %s", strings.Join(m.opts.Extractors, ", "), st.EmbedURL))
+
+	extractOpts := m.opts.extractOptions()
+	extractOpts.OnPhase = func(phase string) {
+		m.ui.Send(progressPhaseMsg{key: opKey, phase: phase})
+	}
+	// Each backend attempt gets its own deadline inside the chain. Its log
+	// lines reach the debug pane while it runs rather than when it returns.
+	res, err := m.extract(ctx, st.EmbedURL, extractOpts, logcb)
+	if err != nil {
+		logcb(fmt.Sprintf("[extractor] ❌ %v", err))
+		return res, err
+	}
 
-		logcb(fmt.Sprintf("[mpv] ▶ Streaming started for %s", st.EmbedURL))
-		return debugLogMsg("Extractor completed successfully")
+	logcb(fmt.Sprintf("[extractor] ✅ Found M3U8 via %s in %s: %s", res.Backend, formatElapsed(res.Elapsed), res.URL))
+	m.ui.Send(streamExtractedMsg{EmbedURL: st.EmbedURL, URL: res.URL})
+	if len(res.Headers) > 0 {
+		logcb(fmt.Sprintf("[extractor] Captured %d headers", len(res.Headers)))
 	}
+	return res, nil
 }
 
 // ────────────────────────────────
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// asciiBorder replaces the rounded box-drawing border in --ascii mode.
+var asciiBorder = lipgloss.Border{
+	Top:          "-",
+	Bottom:       "-",
+	Left:         "|",
+	Right:        "|",
+	TopLeft:      "+",
+	TopRight:     "+",
+	BottomLeft:   "+",
+	BottomRight:  "+",
+	MiddleLeft:   "+",
+	MiddleRight:  "+",
+	Middle:       "+",
+	MiddleTop:    "+",
+	MiddleBottom: "+",
+}
+
+// asciiGlyphs are the column glyphs used in --ascii mode.
+func asciiGlyphs() Glyphs {
+	return Glyphs{Cursor: ">", Focus: "*", Separator: "-", Ellipsis: "..."}
+}
+
+// asciiReplacer maps the non-ASCII characters used in status messages, help
+// text, and debug lines to plain equivalents. Single-width characters map to a
+// single character so column widths are preserved.
+var asciiReplacer = strings.NewReplacer(
+	"⚠️", "!!",
+	"⚠", "!!",
+	"🌐", "[web]",
+	"🎥", "[mpv]",
+	"📺", "[cast]",
+	"✅", "[ok]",
+	"❌", "[x]",
+	"▶", ">",
+	"⏸", "=",
+	"⏺", "(o)",
+	"⬇", "v",
+	"▸", ">",
+	"…", "...",
+	"–", "-",
+	"—", "-",
+	"─", "-",
+	"│", "|",
+	"•", "*",
+	"★", "*",
+	"✓", "+",
+	"✗", "x",
+	"◑", "~",
+	"←", "<",
+	"→", ">",
+	"↑", "^",
+	"↓", "v",
+	"️", "",
+)
+
+// toASCII strips the decorative non-ASCII characters the UI emits.
+func toASCII(s string) string {
+	return asciiReplacer.Replace(s)
+}
@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ────────────────────────────────
+// RESPONSE CACHE
+// ────────────────────────────────
+
+const (
+	defaultSportsCacheTTL  = time.Hour
+	defaultMatchesCacheTTL = time.Minute
+	defaultViewersCacheTTL = 30 * time.Second
+)
+
+// CacheTTLs sets how long API responses are reused before they are fetched
+// again. Zero turns caching off for that kind of response.
+type CacheTTLs struct {
+	Sports  time.Duration
+	Matches time.Duration
+	Viewers time.Duration
+}
+
+// cacheKind groups the endpoints that share a TTL. Stream lists are not
+// cached here; the prefetcher keeps its own short-lived copy.
+type cacheKind int
+
+const (
+	cacheNone cacheKind = iota
+	cacheSports
+	cacheMatches
+	cacheViewers
+)
+
+// cacheKindOf classifies a request URL. The base URL may carry a path
+// prefix, so only the end of the path is compared.
+func cacheKindOf(rawURL string) cacheKind {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return cacheNone
+	}
+	switch {
+	case strings.HasSuffix(u.Path, "/popular-viewcount"):
+		return cacheViewers
+	case strings.HasSuffix(u.Path, "/api/sports"):
+		return cacheSports
+	case strings.Contains(u.Path, "/api/matches/"):
+		return cacheMatches
+	}
+	return cacheNone
+}
+
+func (t CacheTTLs) of(kind cacheKind) time.Duration {
+	switch kind {
+	case cacheSports:
+		return t.Sports
+	case cacheMatches:
+		return t.Matches
+	case cacheViewers:
+		return t.Viewers
+	}
+	return 0
+}
+
+// cachedResponse is a response body that decoded successfully. It is also
+// the on-disk format.
+type cachedResponse struct {
+	URL         string          `json:"url"`
+	Fetched     time.Time       `json:"fetched"`
+	ContentType string          `json:"content_type"`
+	Body        json.RawMessage `json:"body"`
+}
+
+// responseCache keeps API responses in memory and, when dir is set, in one
+// file per URL so they survive restarts. It is safe for concurrent use.
+type responseCache struct {
+	ttl CacheTTLs
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+func newResponseCache(ttl CacheTTLs, dir string) *responseCache {
+	return &responseCache{ttl: ttl, dir: dir, entries: map[string]cachedResponse{}}
+}
+
+// openResponseCache returns the cache for the TUI's API client, with the
+// on-disk copy under the cache directory when disk is set.
+func openResponseCache(ttl CacheTTLs, disk bool) (*responseCache, error) {
+	if !disk {
+		return newResponseCache(ttl, ""), nil
+	}
+	dir, err := ensureAppDir(cacheDir, "api")
+	if err != nil {
+		return newResponseCache(ttl, ""), err
+	}
+	return newResponseCache(ttl, dir), nil
+}
+
+// lookup returns the cached response for rawURL if it is younger than its
+// TTL.
+func (c *responseCache) lookup(rawURL string) (cachedResponse, bool) {
+	if c == nil {
+		return cachedResponse{}, false
+	}
+	ttl := c.ttl.of(cacheKindOf(rawURL))
+	if ttl <= 0 {
+		return cachedResponse{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[rawURL]
+	if !ok && c.dir != "" {
+		if e, ok = c.load(rawURL); ok {
+			c.entries[rawURL] = e
+		}
+	}
+	if !ok || time.Since(e.Fetched) > ttl {
+		return cachedResponse{}, false
+	}
+	return e, true
+}
+
+// store remembers a response. Only the disk write can fail, and the entry is
+// kept in memory either way.
+func (c *responseCache) store(rawURL, contentType string, body []byte) error {
+	if c == nil || c.ttl.of(cacheKindOf(rawURL)) <= 0 {
+		return nil
+	}
+	e := cachedResponse{URL: rawURL, Fetched: time.Now(), ContentType: contentType, Body: body}
+	c.mu.Lock()
+	c.entries[rawURL] = e
+	c.mu.Unlock()
+	if c.dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	path := c.path(rawURL)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// load reads an entry from disk. Unreadable files are treated as misses.
+func (c *responseCache) load(rawURL string) (cachedResponse, bool) {
+	data, err := os.ReadFile(c.path(rawURL))
+	if err != nil {
+		return cachedResponse{}, false
+	}
+	var e cachedResponse
+	if json.Unmarshal(data, &e) != nil || e.URL != rawURL {
+		return cachedResponse{}, false
+	}
+	return e, true
+}
+
+func (c *responseCache) path(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:12])+".json")
+}
+
+type cacheBypassKey struct{}
+
+// bypassCache makes requests sent with the returned context skip cached
+// responses. What they fetch still replaces the cached copy.
+func bypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
@@ -0,0 +1,234 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// CASTING
+// ────────────────────────────────
+
+const (
+	// castScanTime is how long discovery waits for devices to answer.
+	castScanTime = 3 * time.Second
+	// castTimeout bounds handing a stream to a device.
+	castTimeout = 20 * time.Second
+	// castContentType is what receivers are told they are loading.
+	castContentType = "application/x-mpegURL"
+)
+
+// castTarget is a device on the LAN that plays a stream from a URL.
+type castTarget interface {
+	Name() string
+	Kind() string
+	// Addr is the device's host and port.
+	Addr() string
+	Cast(ctx context.Context, media castMedia) error
+}
+
+// castMedia is a relayed stream ready for a device.
+type castMedia struct {
+	URL   string
+	Title string
+}
+
+// discoverCastTargets looks for Chromecasts and DLNA renderers at once for
+// castScanTime. Devices found by either are returned even when the other
+// search failed.
+func discoverCastTargets(ctx context.Context) ([]castTarget, error) {
+	ctx, cancel := context.WithTimeout(ctx, castScanTime)
+	defer cancel()
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		targets []castTarget
+		errs    []string
+	)
+	for name, discover := range map[string]func(context.Context) ([]castTarget, error){
+		"mDNS": discoverChromecasts,
+		"SSDP": discoverDLNA,
+	} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found, err := discover(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			targets = append(targets, found...)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			}
+		}()
+	}
+	wg.Wait()
+	if len(targets) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("device discovery failed: %s", strings.Join(errs, "; "))
+	}
+	return targets, nil
+}
+
+func castTargetLabel(t castTarget) string {
+	return fmt.Sprintf("%-10s %s  (%s)", t.Kind(), t.Name(), t.Addr())
+}
+
+// ────────────────────────────────
+// CAST PICKER
+// ────────────────────────────────
+
+type (
+	castTargetsMsg struct {
+		Targets []castTarget
+		Err     error
+	}
+	castStartedMsg struct {
+		Title  string
+		Device string
+	}
+)
+
+type castKeys struct {
+	Rescan key.Binding
+}
+
+func defaultCastKeys() castKeys {
+	return castKeys{
+		Rescan: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "scan again")),
+	}
+}
+
+func newCastList() *ListColumn[castTarget] {
+	return NewListColumn[castTarget]("Cast devices", castTargetLabel)
+}
+
+// openCastPicker remembers the stream to cast and lists the devices, scanning
+// for them the first time.
+func (m *Model) openCastPicker(st Stream, mt Match) tea.Cmd {
+	m.castPending = pendingLaunch{Stream: st, Match: mt}
+	m.currentView = viewCast
+	if len(m.castTargets.Items()) > 0 {
+		return nil
+	}
+	return m.scanCastTargets()
+}
+
+// scanCastTargets searches the LAN for devices.
+func (m *Model) scanCastTargets() tea.Cmd {
+	m.castScanning = true
+	m.status = "Looking for Chromecast and DLNA devices…"
+	return safeCmd("cast discovery", m.crash, func() tea.Msg {
+		targets, err := discoverCastTargets(context.Background())
+		return castTargetsMsg{Targets: targets, Err: err}
+	})
+}
+
+func (m *Model) castTargetsFound(msg castTargetsMsg) tea.Cmd {
+	m.castScanning = false
+	if msg.Err != nil {
+		m.lastError = msg.Err
+		return nil
+	}
+	m.castTargets.ReplaceItems(msg.Targets, func(a, b castTarget) bool { return a.Addr() == b.Addr() })
+	m.status = fmt.Sprintf("Found %d cast device(s)", len(msg.Targets))
+	cmds := make([]tea.Cmd, 0, len(msg.Targets))
+	for _, t := range msg.Targets {
+		cmds = append(cmds, m.logToUI("[cast] found "+castTargetLabel(t)))
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateCastPicker handles keys while the cast picker is open.
+func (m *Model) updateCastPicker(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.castTargets.CursorUp()
+	case key.Matches(msg, m.keys.Down):
+		m.castTargets.CursorDown()
+	case key.Matches(msg, m.castKeys.Rescan):
+		if !m.castScanning {
+			return m.scanCastTargets()
+		}
+	case key.Matches(msg, m.keys.Enter):
+		t, ok := m.castTargets.Selected()
+		if !ok {
+			return nil
+		}
+		p := m.castPending
+		m.castPending = pendingLaunch{}
+		m.currentView = viewMain
+		return tea.Batch(
+			m.logToUI(fmt.Sprintf("Attempting extractor for %s (cast to %s)", p.Stream.EmbedURL, t.Name())),
+			m.castStream(p.Stream, p.Match, t),
+		)
+	}
+	return nil
+}
+
+// castStream extracts a stream and hands it to a device through the relay.
+func (m *Model) castStream(st Stream, mt Match, t castTarget) tea.Cmd {
+	return m.trackExtraction("cast:"+st.EmbedURL, fmt.Sprintf("Extracting stream #%d for %s", st.StreamNo, t.Name()), func(ctx context.Context) tea.Cmd {
+		return m.runCaster(ctx, st, m.fallbackStreams(st, mt), mt, t)
+	})
+}
+
+func (m Model) runCaster(ctx context.Context, st Stream, fallbacks []Stream, mt Match, t castTarget) tea.Cmd {
+	return safeCmd("caster", m.crash, func() tea.Msg {
+		if st.EmbedURL == "" {
+			return debugLogMsg("Cast aborted: empty embed URL")
+		}
+		logcb := m.ui.Log
+		st, res, err := m.extractWithFallback(ctx, append([]Stream{st}, fallbacks...), logcb)
+		if ctx.Err() != nil {
+			return debugLogMsg(fmt.Sprintf("Extraction of stream #%d canceled", st.StreamNo))
+		}
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("Extractor failed: %v", err))
+		}
+		relayed, err := m.castRelay.Register(res, t.Addr())
+		if err != nil {
+			return errorMsg(err)
+		}
+		logcb(fmt.Sprintf("[cast] relaying %s as %s", res.URL, relayed))
+		title := matchDisplayTitle(mt)
+		if title == "" {
+			title = fmt.Sprintf("%s stream %d", st.Source, st.StreamNo)
+		}
+		m.ui.Send(progressPhaseMsg{key: opExtract, phase: "casting to " + t.Name()})
+		castCtx, cancel := context.WithTimeout(ctx, castTimeout)
+		err = t.Cast(castCtx, castMedia{URL: relayed, Title: title})
+		cancel()
+		if err != nil {
+			m.castRelay.Forget(t.Addr())
+			logcb(fmt.Sprintf("[cast] ❌ %s: %v", t.Name(), err))
+			return errorMsg(fmt.Errorf("cast to %s: %w", t.Name(), err))
+		}
+		logcb(fmt.Sprintf("[cast] ▶ %s playing on %s %s", title, t.Kind(), t.Name()))
+		return castStartedMsg{Title: title, Device: t.Name()}
+	})
+}
+
+func (m Model) renderCastView() string {
+	title := matchDisplayTitle(m.castPending.Match)
+	if title == "" {
+		title = fmt.Sprintf("stream #%d", m.castPending.Stream.StreamNo)
+	}
+	header := m.styles.Title.Render(m.styles.Text("Cast " + title + " to"))
+	list := m.castTargets.View(m.styles, true)
+	if len(m.castTargets.Items()) == 0 {
+		empty := "No devices found – r scans again"
+		if m.castScanning {
+			empty = "Scanning the network…"
+		}
+		list = m.styles.Subtle.Render(m.styles.Text(empty))
+	}
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter cast · r scan again · Esc cancel"))
+	body := lipgloss.JoinVertical(lipgloss.Left, header, list, hint)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
@@ -0,0 +1,292 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ────────────────────────────────
+// CAST RELAY
+// ────────────────────────────────
+
+// castRelay serves extracted streams to cast receivers on the LAN. A
+// Chromecast or DLNA renderer cannot send the captured User-Agent, Origin,
+// and Referer, so the relay fetches the playlist and its segments with them
+// and rewrites the playlist's URIs to point back at itself. It listens on
+// first use and keeps serving until the TUI quits.
+type castRelay struct {
+	port   int
+	client *http.Client
+	policy headerPolicy
+
+	mu      sync.Mutex
+	ln      net.Listener
+	streams map[string]*castSession
+}
+
+// castSessionIdle is how long a relayed stream stays available once its
+// receiver stopped asking for it.
+const castSessionIdle = 10 * time.Minute
+
+// castSession is a stream relayed to one receiver. Only hosts it has been
+// seen to use, the playlist's own and the ones its playlists reference, are
+// fetched for it, so the relay cannot be used to reach anything else.
+type castSession struct {
+	receiver string
+	headers  []playbackHeader
+	hosts    map[string]bool
+	lastUsed time.Time
+}
+
+// castURIAttr matches the URI attribute of tags such as #EXT-X-KEY and
+// #EXT-X-MEDIA.
+var castURIAttr = regexp.MustCompile(`URI="([^"]*)"`)
+
+// newCastRelay returns a relay listening on port, or on a random port when
+// port is 0.
+func newCastRelay(port int, proxy string, policy headerPolicy) *castRelay {
+	return &castRelay{
+		port:    port,
+		client:  proxyHTTPClient(proxy),
+		policy:  policy,
+		streams: map[string]*castSession{},
+	}
+}
+
+// Register makes an extracted stream available to a receiver at
+// receiverAddr and returns the URL the receiver should load. The relay
+// listens on every interface; the URL uses the address of the interface that
+// reaches the receiver. A receiver plays one stream at a time, so the stream
+// it was relayed before is dropped.
+func (r *castRelay) Register(res extractResult, receiverAddr string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ln == nil {
+		ln, err := net.Listen("tcp", fmt.Sprintf(":%d", r.port))
+		if err != nil {
+			return "", fmt.Errorf("cast relay: %w", err)
+		}
+		r.ln = ln
+		go http.Serve(ln, r)
+	}
+	localIP, err := localAddrFor(receiverAddr)
+	if err != nil {
+		return "", fmt.Errorf("cast relay: %w", err)
+	}
+	var raw [12]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(raw[:])
+	u, err := url.Parse(res.URL)
+	if err != nil {
+		return "", fmt.Errorf("cast relay: %w", err)
+	}
+	r.forgetLocked(receiverAddr)
+	r.streams[token] = &castSession{
+		receiver: receiverAddr,
+		headers:  r.policy.forward(res.URL, res.Headers),
+		hosts:    map[string]bool{u.Host: true},
+		lastUsed: time.Now(),
+	}
+
+	port := r.ln.Addr().(*net.TCPAddr).Port
+	base := "http://" + net.JoinHostPort(localIP, strconv.Itoa(port)) + "/cast/" + token + "/"
+	return relayURL(base, res.URL), nil
+}
+
+// Forget stops relaying to the receiver at receiverAddr, as when casting to
+// it failed.
+func (r *castRelay) Forget(receiverAddr string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.forgetLocked(receiverAddr)
+}
+
+// forgetLocked drops the receiver's stream along with every stream left
+// idle. r.mu must be held.
+func (r *castRelay) forgetLocked(receiverAddr string) {
+	for token, s := range r.streams {
+		if s.receiver == receiverAddr || time.Since(s.lastUsed) > castSessionIdle {
+			delete(r.streams, token)
+		}
+	}
+}
+
+// session returns the stream a token stands for if upstream is on one of
+// its hosts, marking it used.
+func (r *castRelay) session(token, upstream string) (*castSession, bool) {
+	u, err := url.Parse(upstream)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.streams[token]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(s.lastUsed) > castSessionIdle {
+		delete(r.streams, token)
+		return nil, false
+	}
+	if !s.hosts[u.Host] {
+		return nil, false
+	}
+	s.lastUsed = time.Now()
+	return s, true
+}
+
+// allow lets a stream fetch from more hosts, those its playlists reference.
+func (r *castRelay) allow(s *castSession, hosts []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, h := range hosts {
+		s.hosts[h] = true
+	}
+}
+
+// Close stops serving.
+func (r *castRelay) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ln != nil {
+		r.ln.Close()
+		r.ln = nil
+	}
+}
+
+// localAddrFor returns the local IP address used to reach addr.
+func localAddrFor(addr string) (string, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+}
+
+// relayURL points at upstream through the relay at base, keeping the
+// upstream file name so receivers that go by the extension still can.
+func relayURL(base, upstream string) string {
+	name := "stream"
+	if u, err := url.Parse(upstream); err == nil && path.Base(u.Path) != "/" && path.Base(u.Path) != "." {
+		name = path.Base(u.Path)
+	}
+	return base + url.PathEscape(name) + "?u=" + url.QueryEscape(upstream)
+}
+
+func (r *castRelay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// The Default Media Receiver loads HLS with XHR and needs CORS.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	if req.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	rest, ok := strings.CutPrefix(req.URL.Path, "/cast/")
+	token, _, _ := strings.Cut(rest, "/")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	upstream := req.URL.Query().Get("u")
+	sess, known := r.session(token, upstream)
+	if !known {
+		http.NotFound(w, req)
+		return
+	}
+
+	up, err := http.NewRequestWithContext(req.Context(), http.MethodGet, upstream, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, h := range sess.headers {
+		up.Header.Set(h.Name, h.Value)
+	}
+	if rng := req.Header.Get("Range"); rng != "" {
+		up.Header.Set("Range", rng)
+	}
+	resp, err := r.client.Do(up)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	body := bufio.NewReader(resp.Body)
+	head, _ := body.Peek(7)
+	if resp.StatusCode == http.StatusOK && string(head) == "#EXTM3U" {
+		playlist, err := io.ReadAll(io.LimitReader(body, maxPlaylistBytes))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		base := "/cast/" + token + "/"
+		if host := req.Host; host != "" {
+			base = "http://" + host + base
+		}
+		out, hosts := rewritePlaylist(resp.Request.URL, string(playlist), base)
+		r.allow(sess, append(hosts, resp.Request.URL.Host))
+		w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+		w.Header().Set("Content-Length", strconv.Itoa(len(out)))
+		_, _ = io.WriteString(w, out)
+		return
+	}
+	for _, k := range []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges"} {
+		if v := resp.Header.Get(k); v != "" {
+			w.Header().Set(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, body)
+}
+
+// rewritePlaylist points every URI of an HLS playlist, segment lines and
+// URI attributes alike, at the relay at base, resolving them against the
+// playlist's own URL first. It also returns the hosts the URIs are on.
+func rewritePlaylist(playlistURL *url.URL, body, base string) (string, []string) {
+	var hosts []string
+	resolve := func(ref string) string {
+		if u, err := playlistURL.Parse(ref); err == nil {
+			ref = u.String()
+			hosts = append(hosts, u.Host)
+		}
+		return relayURL(base, ref)
+	}
+	var out bytes.Buffer
+	sc := bufio.NewScanner(strings.NewReader(body))
+	sc.Buffer(make([]byte, 64*1024), maxPlaylistBytes)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			line = castURIAttr.ReplaceAllStringFunc(line, func(attr string) string {
+				return `URI="` + resolve(castURIAttr.FindStringSubmatch(attr)[1]) + `"`
+			})
+		default:
+			line = resolve(line)
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	return out.String(), hosts
+}
@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCastRelayServe(t *testing.T) {
+	// The playlist lives on one host and names segments on another, which
+	// the relay only learns about by fetching the playlist.
+	segments := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Referer") != "https://embed.example/" {
+			http.Error(w, "no referer", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("segment"))
+	}))
+	defer segments.Close()
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("should not be reached"))
+	}))
+	defer elsewhere.Close()
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/live/index.m3u8" {
+			_, _ = w.Write([]byte("#EXTM3U\n#EXTINF:4,\nseg0.ts\n#EXTINF:4,\n" + segments.URL + "/seg1.ts\n"))
+			return
+		}
+		_, _ = w.Write([]byte("segment"))
+	}))
+	defer origin.Close()
+
+	r := newCastRelay(0, "", headerPolicy{})
+	r.streams["live"] = &castSession{
+		receiver: "10.0.0.2:8009",
+		headers:  []playbackHeader{{Name: "Referer", Value: "https://embed.example/"}},
+		hosts:    map[string]bool{strings.TrimPrefix(origin.URL, "http://"): true},
+		lastUsed: time.Now(),
+	}
+	r.streams["stale"] = &castSession{
+		receiver: "10.0.0.3:8009",
+		hosts:    map[string]bool{strings.TrimPrefix(origin.URL, "http://"): true},
+		lastUsed: time.Now().Add(-castSessionIdle - time.Minute),
+	}
+
+	// The steps run in order against the same relay.
+	steps := []struct {
+		name     string
+		token    string
+		upstream string
+		want     int
+	}{
+		{"unknown token", "nope", origin.URL + "/live/index.m3u8", http.StatusNotFound},
+		{"expired token", "stale", origin.URL + "/live/index.m3u8", http.StatusNotFound},
+		{"host not yet learned", "live", segments.URL + "/seg1.ts", http.StatusNotFound},
+		{"not http", "live", "file:///etc/passwd", http.StatusNotFound},
+		{"playlist", "live", origin.URL + "/live/index.m3u8", http.StatusOK},
+		{"relative segment", "live", origin.URL + "/live/seg0.ts", http.StatusOK},
+		{"host learned from playlist", "live", segments.URL + "/seg1.ts", http.StatusOK},
+		{"host outside allowlist", "live", elsewhere.URL + "/seg1.ts", http.StatusNotFound},
+	}
+	for _, s := range steps {
+		t.Run(s.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/cast/"+s.token+"/x?u="+url.QueryEscape(s.upstream), nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != s.want {
+				t.Fatalf("GET %s with token %q: status %d, want %d", s.upstream, s.token, rec.Code, s.want)
+			}
+		})
+	}
+	if _, ok := r.streams["stale"]; ok {
+		t.Error("expired session still registered")
+	}
+}
+
+func TestRewritePlaylist(t *testing.T) {
+	const base = "http://192.168.1.5:8123/cast/tok/"
+	playlistURL, _ := url.Parse("https://cdn.example/live/index.m3u8?sig=1")
+
+	tests := []struct {
+		name      string
+		line      string
+		want      string
+		wantHosts []string
+	}{
+		{
+			name:      "relative segment",
+			line:      "seg0.ts",
+			want:      base + "seg0.ts?u=" + url.QueryEscape("https://cdn.example/live/seg0.ts"),
+			wantHosts: []string{"cdn.example"},
+		},
+		{
+			name:      "root-relative segment",
+			line:      "/other/seg0.ts",
+			want:      base + "seg0.ts?u=" + url.QueryEscape("https://cdn.example/other/seg0.ts"),
+			wantHosts: []string{"cdn.example"},
+		},
+		{
+			name:      "absolute segment on another host",
+			line:      "https://edge.example/a/seg1.ts?t=2",
+			want:      base + "seg1.ts?u=" + url.QueryEscape("https://edge.example/a/seg1.ts?t=2"),
+			wantHosts: []string{"edge.example"},
+		},
+		{
+			name:      "relative key URI",
+			line:      `#EXT-X-KEY:METHOD=AES-128,URI="key.bin"`,
+			want:      `#EXT-X-KEY:METHOD=AES-128,URI="` + base + "key.bin?u=" + url.QueryEscape("https://cdn.example/live/key.bin") + `"`,
+			wantHosts: []string{"cdn.example"},
+		},
+		{
+			name:      "absolute media URI",
+			line:      `#EXT-X-MEDIA:TYPE=AUDIO,URI="https://audio.example/en.m3u8"`,
+			want:      `#EXT-X-MEDIA:TYPE=AUDIO,URI="` + base + "en.m3u8?u=" + url.QueryEscape("https://audio.example/en.m3u8") + `"`,
+			wantHosts: []string{"audio.example"},
+		},
+		{
+			name: "tag without URI",
+			line: "#EXTINF:4,",
+			want: "#EXTINF:4,",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hosts := rewritePlaylist(playlistURL, tt.line+"\n", base)
+			if got != tt.want+"\n" {
+				t.Errorf("rewritePlaylist(%q)\n got %q\nwant %q", tt.line, got, tt.want+"\n")
+			}
+			if strings.Join(hosts, ",") != strings.Join(tt.wantHosts, ",") {
+				t.Errorf("rewritePlaylist(%q) hosts = %v, want %v", tt.line, hosts, tt.wantHosts)
+			}
+		})
+	}
+}
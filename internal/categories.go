@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// CATEGORY PICKER
+// ────────────────────────────────
+
+// noCategory names matches the API sent without a category.
+const noCategory = "Uncategorized"
+
+// categoryOption is a row of the category picker: a category of the shown
+// matches and how many there are, or every category when All is set.
+type categoryOption struct {
+	Name  string
+	Count int
+	All   bool
+}
+
+func (o categoryOption) label() string {
+	if o.All {
+		return fmt.Sprintf("All categories (%d)", o.Count)
+	}
+	return fmt.Sprintf("%s (%d)", categoryLabel(o.Name), o.Count)
+}
+
+func newCategoryList() *ListColumn[categoryOption] {
+	return NewListColumn[categoryOption]("Categories", categoryOption.label)
+}
+
+// categoryLabel names a category, including the empty one. The Matches
+// column filter is kept as a label, so matches without a category can be
+// picked too.
+func categoryLabel(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return noCategory
+	}
+	return name
+}
+
+// sameCategory compares categories ignoring the API's mixed casing.
+func sameCategory(a, b string) bool {
+	return strings.EqualFold(categoryLabel(a), categoryLabel(b))
+}
+
+// matchCategories counts the matches of each category, most common first,
+// ties broken by name.
+func matchCategories(matches []Match) []categoryOption {
+	var out []categoryOption
+	for _, mt := range matches {
+		i := slices.IndexFunc(out, func(o categoryOption) bool { return sameCategory(o.Name, mt.Category) })
+		if i < 0 {
+			out = append(out, categoryOption{Name: strings.TrimSpace(mt.Category)})
+			i = len(out) - 1
+		}
+		out[i].Count++
+	}
+	slices.SortStableFunc(out, func(a, b categoryOption) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(strings.ToLower(categoryLabel(a.Name)), strings.ToLower(categoryLabel(b.Name)))
+	})
+	return out
+}
+
+// inCategory returns the matches of one category.
+func inCategory(matches []Match, category string) []Match {
+	var out []Match
+	for _, mt := range matches {
+		if sameCategory(mt.Category, category) {
+			out = append(out, mt)
+		}
+	}
+	return out
+}
+
+// openCategoryPicker lists the categories of the shown sport's matches, with
+// the cursor on the one in use.
+func (m *Model) openCategoryPicker() {
+	cats := matchCategories(m.allMatches)
+	if len(cats) == 0 {
+		m.status = "No matches to pick a category from"
+		return
+	}
+	items := append([]categoryOption{{All: true, Count: len(m.allMatches)}}, cats...)
+	m.categories.SetItems(items)
+	m.categories.Select(func(o categoryOption) bool {
+		if m.matchCategory == "" {
+			return o.All
+		}
+		return !o.All && sameCategory(o.Name, m.matchCategory)
+	})
+	m.currentView = viewCategories
+	m.status = fmt.Sprintf("%d categories in %s – pick one with Enter", len(cats), m.matchesTitle)
+}
+
+// updateCategoryPicker handles keys while the category picker is open.
+func (m *Model) updateCategoryPicker(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.categories.CursorUp()
+	case key.Matches(msg, m.keys.Down):
+		m.categories.CursorDown()
+	case key.Matches(msg, m.keys.PageUp):
+		m.categories.Jump(jumpPageUp)
+	case key.Matches(msg, m.keys.PageDown):
+		m.categories.Jump(jumpPageDown)
+	case key.Matches(msg, m.keys.Enter):
+		o, ok := m.categories.Selected()
+		if !ok {
+			return nil
+		}
+		m.currentView = viewMain
+		m.setMatchCategory(o)
+	}
+	return nil
+}
+
+// setMatchCategory narrows the Matches column to the picked category.
+func (m *Model) setMatchCategory(o categoryOption) {
+	m.matchCategory = ""
+	if !o.All {
+		m.matchCategory = categoryLabel(o.Name)
+	}
+	m.applyMatches()
+	if o.All {
+		m.status = fmt.Sprintf("Showing matches of every category (%d)", len(m.matches.Items()))
+		return
+	}
+	m.status = fmt.Sprintf("Showing %s matches only (%d of %d)", categoryLabel(o.Name), len(m.matches.Items()), len(m.allMatches))
+}
+
+func (m Model) renderCategoryView() string {
+	header := m.styles.Title.Render(m.styles.Text("Category – " + m.matchesTitle))
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter show its matches · Esc cancel"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.categories.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
@@ -0,0 +1,326 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// ────────────────────────────────
+// CHROMECAST
+// ────────────────────────────────
+
+const (
+	mdnsAddr       = "224.0.0.251:5353"
+	googlecastName = "_googlecast._tcp.local."
+
+	// castDefaultReceiver is Google's Default Media Receiver app, which
+	// plays HLS from a URL.
+	castDefaultReceiver = "CC1AD845"
+
+	castNSConnection = "urn:x-cast:com.google.cast.tp.connection"
+	castNSHeartbeat  = "urn:x-cast:com.google.cast.tp.heartbeat"
+	castNSReceiver   = "urn:x-cast:com.google.cast.receiver"
+	castNSMedia      = "urn:x-cast:com.google.cast.media"
+
+	castSender   = "sender-0"
+	castReceiver = "receiver-0"
+)
+
+// chromecast is a Cast device, driven over the Cast v2 protocol: JSON
+// payloads in length-prefixed protobuf frames on a TLS connection.
+type chromecast struct {
+	name string
+	addr string
+}
+
+func (c chromecast) Name() string { return c.name }
+func (c chromecast) Kind() string { return "Chromecast" }
+func (c chromecast) Addr() string { return c.addr }
+
+// Cast launches the Default Media Receiver and loads the stream into it. The
+// receiver keeps playing after the connection is closed.
+func (c chromecast) Cast(ctx context.Context, media castMedia) error {
+	dialer := tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}} // devices use self-signed certificates
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	s := castConn{rw: conn}
+
+	if err := s.send(castReceiver, castNSConnection, map[string]any{"type": "CONNECT"}); err != nil {
+		return err
+	}
+	if err := s.send(castReceiver, castNSReceiver, map[string]any{"type": "LAUNCH", "appId": castDefaultReceiver, "requestId": 1}); err != nil {
+		return err
+	}
+	var transport string
+	err = s.await(func(ns string, payload castPayload) (bool, error) {
+		switch payload.Type {
+		case "RECEIVER_STATUS":
+			for _, app := range payload.Status.Applications {
+				if app.AppID == castDefaultReceiver && app.TransportID != "" {
+					transport = app.TransportID
+					return true, nil
+				}
+			}
+		case "LAUNCH_ERROR":
+			return false, fmt.Errorf("launch failed: %s", payload.Reason)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.send(transport, castNSConnection, map[string]any{"type": "CONNECT"}); err != nil {
+		return err
+	}
+	load := map[string]any{
+		"type":      "LOAD",
+		"requestId": 2,
+		"autoplay":  true,
+		"media": map[string]any{
+			"contentId":   media.URL,
+			"contentType": castContentType,
+			"streamType":  "LIVE",
+			"metadata":    map[string]any{"metadataType": 0, "title": media.Title},
+		},
+	}
+	if err := s.send(transport, castNSMedia, load); err != nil {
+		return err
+	}
+	err = s.await(func(ns string, payload castPayload) (bool, error) {
+		switch payload.Type {
+		case "MEDIA_STATUS":
+			return payload.RequestID == 2, nil
+		case "LOAD_FAILED", "LOAD_CANCELLED", "INVALID_REQUEST":
+			reason := payload.Reason
+			if reason == "" {
+				reason = strings.ToLower(strings.ReplaceAll(payload.Type, "_", " "))
+			}
+			return false, fmt.Errorf("load: %s", reason)
+		}
+		return false, nil
+	})
+	_ = s.send(transport, castNSConnection, map[string]any{"type": "CLOSE"})
+	return err
+}
+
+// castPayload holds the fields of the JSON payloads the sender looks at.
+type castPayload struct {
+	Type      string `json:"type"`
+	RequestID int    `json:"requestId"`
+	Reason    string `json:"reason"`
+	Status    struct {
+		Applications []struct {
+			AppID       string `json:"appId"`
+			TransportID string `json:"transportId"`
+		} `json:"applications"`
+	} `json:"status"`
+}
+
+// castConn frames Cast messages on a connection.
+type castConn struct {
+	rw io.ReadWriter
+}
+
+// send writes one CastMessage with a JSON payload from the sender to dest.
+func (c castConn) send(dest, namespace string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	str := func(b []byte, field byte, s string) []byte {
+		b = append(b, field<<3|2)
+		b = binary.AppendUvarint(b, uint64(len(s)))
+		return append(b, s...)
+	}
+	msg := []byte{0x08, 0x00} // protocol_version CASTV2_1_0
+	msg = str(msg, 2, castSender)
+	msg = str(msg, 3, dest)
+	msg = str(msg, 4, namespace)
+	msg = append(msg, 0x28, 0x00) // payload_type STRING
+	msg = str(msg, 6, string(body))
+
+	frame := binary.BigEndian.AppendUint32(nil, uint32(len(msg)))
+	_, err = c.rw.Write(append(frame, msg...))
+	return err
+}
+
+// read returns the namespace and payload of the next message.
+func (c castConn) read() (string, []byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(c.rw, size[:]); err != nil {
+		return "", nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > 1<<20 {
+		return "", nil, fmt.Errorf("cast message of %d bytes", n)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(c.rw, msg); err != nil {
+		return "", nil, err
+	}
+	var namespace string
+	var payload []byte
+	for len(msg) > 0 {
+		tag, k := binary.Uvarint(msg)
+		if k <= 0 {
+			return "", nil, errors.New("malformed cast message")
+		}
+		msg = msg[k:]
+		switch tag & 7 {
+		case 0:
+			_, k = binary.Uvarint(msg)
+			if k <= 0 {
+				return "", nil, errors.New("malformed cast message")
+			}
+			msg = msg[k:]
+		case 2:
+			l, k := binary.Uvarint(msg)
+			if k <= 0 || uint64(len(msg)-k) < l {
+				return "", nil, errors.New("malformed cast message")
+			}
+			value := msg[k : k+int(l)]
+			msg = msg[k+int(l):]
+			switch tag >> 3 {
+			case 4:
+				namespace = string(value)
+			case 6:
+				payload = value
+			}
+		default:
+			return "", nil, fmt.Errorf("unexpected wire type %d in cast message", tag&7)
+		}
+	}
+	return namespace, payload, nil
+}
+
+// await reads messages, answering heartbeats, until handle is done with
+// them or fails.
+func (c castConn) await(handle func(ns string, payload castPayload) (bool, error)) error {
+	for {
+		ns, body, err := c.read()
+		if err != nil {
+			return err
+		}
+		var payload castPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			continue
+		}
+		if ns == castNSHeartbeat && payload.Type == "PING" {
+			if err := c.send(castReceiver, castNSHeartbeat, map[string]any{"type": "PONG"}); err != nil {
+				return err
+			}
+			continue
+		}
+		if done, err := handle(ns, payload); done || err != nil {
+			return err
+		}
+	}
+}
+
+// discoverChromecasts asks for Cast devices with an mDNS query until ctx is
+// done. Responders answer queries from other ports than 5353 directly.
+func discoverChromecasts(ctx context.Context) ([]castTarget, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	dst, err := net.ResolveUDPAddr("udp4", mdnsAddr)
+	if err != nil {
+		return nil, err
+	}
+	query := dnsmessage.Message{Questions: []dnsmessage.Question{{
+		Name:  dnsmessage.MustNewName(googlecastName),
+		Type:  dnsmessage.TypePTR,
+		Class: dnsmessage.ClassINET,
+	}}}
+	packet, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteTo(packet, dst); err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetReadDeadline(deadline)
+	}
+
+	var out []castTarget
+	seen := map[string]bool{}
+	buf := make([]byte, 9000)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil {
+			continue
+		}
+		for _, c := range parseGooglecast(msg, from) {
+			if !seen[c.addr] {
+				seen[c.addr] = true
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseGooglecast reads the Cast devices from an mDNS response: the SRV
+// record gives the port, an A record (or else the sender) the address, and
+// the TXT record's fn the name shown in the Google Home app.
+func parseGooglecast(msg dnsmessage.Message, from net.Addr) []chromecast {
+	records := append(msg.Answers, msg.Additionals...)
+	addrs := map[string]net.IP{}
+	names := map[string]string{}
+	for _, r := range records {
+		switch body := r.Body.(type) {
+		case *dnsmessage.AResource:
+			addrs[r.Header.Name.String()] = net.IP(body.A[:])
+		case *dnsmessage.TXTResource:
+			for _, kv := range body.TXT {
+				if v, ok := strings.CutPrefix(kv, "fn="); ok {
+					names[r.Header.Name.String()] = v
+				}
+			}
+		}
+	}
+	var out []chromecast
+	for _, r := range records {
+		srv, ok := r.Body.(*dnsmessage.SRVResource)
+		instance := r.Header.Name.String()
+		if !ok || !strings.HasSuffix(instance, "."+googlecastName) {
+			continue
+		}
+		ip := addrs[srv.Target.String()]
+		if ip == nil {
+			if udp, ok := from.(*net.UDPAddr); ok {
+				ip = udp.IP
+			}
+		}
+		name := names[instance]
+		if name == "" {
+			name = strings.TrimSuffix(instance, "."+googlecastName)
+		}
+		out = append(out, chromecast{name: name, addr: net.JoinHostPort(ip.String(), strconv.Itoa(int(srv.Port)))})
+	}
+	return out
+}
@@ -0,0 +1,318 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ────────────────────────────────
+// COMMAND-LINE OUTPUT
+// ────────────────────────────────
+
+// extractJSON is what -e prints with --json.
+type extractJSON struct {
+	EmbedURL  string            `json:"embedUrl"`
+	URL       string            `json:"url,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Backend   string            `json:"backend,omitempty"`
+	ElapsedMs int64             `json:"elapsedMs"`
+	Attempts  []attemptJSON     `json:"attempts,omitempty"`
+	Probe     *probeJSON        `json:"probe,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// probeJSON is the pre-play check of the playlist.
+type probeJSON struct {
+	Status    string `json:"status,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newProbeJSON(pr probeResult) *probeJSON {
+	out := &probeJSON{Status: pr.Status, LatencyMs: pr.Latency.Milliseconds()}
+	if pr.Err != nil {
+		out.Error = pr.Err.Error()
+	}
+	return out
+}
+
+type attemptJSON struct {
+	Backend   string `json:"backend"`
+	ElapsedMs int64  `json:"elapsedMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newExtractJSON(embedURL string, res extractResult, err error) extractJSON {
+	out := extractJSON{
+		EmbedURL:  embedURL,
+		URL:       res.URL,
+		Headers:   res.Headers,
+		Backend:   res.Backend,
+		ElapsedMs: res.Elapsed.Milliseconds(),
+	}
+	for _, a := range res.Attempts {
+		aj := attemptJSON{Backend: a.Backend, ElapsedMs: a.Elapsed.Milliseconds()}
+		if a.Err != nil {
+			aj.Error = a.Err.Error()
+		}
+		out.Attempts = append(out.Attempts, aj)
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	return out
+}
+
+// writeJSON prints v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// RunListCommand handles the "sports", "matches", and "streams" commands
+// (also accepted after "list"), which print what the API lists as a table or
+// as JSON.
+func RunListCommand(args []string, opts Options) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	asJSON := fs.Bool("json", opts.JSON, "print JSON instead of a table")
+	demo := fs.Bool("demo", opts.Demo, "list the bundled demo fixtures")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: streamed-tui sports [--json]")
+		fmt.Fprintln(fs.Output(), "       streamed-tui matches [--json] [sport|popular]")
+		fmt.Fprintln(fs.Output(), "       streamed-tui streams [--json] <match-id> [sport]")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errors.New("missing what to list")
+	}
+	sub := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	opts = opts.withDefaults()
+	if err := opts.checkProxy(); err != nil {
+		return err
+	}
+
+	opts.Demo = *demo
+	provider, err := cliProvider(opts)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch sub {
+	case "sports":
+		sports, err := provider.ListSports(ctx)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return writeJSON(os.Stdout, sports)
+		}
+		return printTable(func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tNAME")
+			for _, s := range sports {
+				fmt.Fprintf(w, "%s\t%s\n", s.ID, s.Name)
+			}
+		})
+
+	case "matches":
+		matches, err := listMatches(ctx, provider, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return writeJSON(os.Stdout, matches)
+		}
+		tag := multiProvider(provider)
+		return printTable(func(w io.Writer) {
+			header := "ID\tKICKOFF\tTITLE\tCATEGORY\tSOURCES"
+			if tag {
+				header += "\tPROVIDER"
+			}
+			fmt.Fprintln(w, header)
+			for _, mt := range matches {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d", mt.ID,
+					time.UnixMilli(mt.Date).Local().Format("Jan 2 15:04"), matchDisplayTitle(mt), mt.Category, len(mt.Sources))
+				if tag {
+					fmt.Fprintf(w, "\t%s", mt.Provider)
+				}
+				fmt.Fprintln(w)
+			}
+		})
+
+	case "streams":
+		if fs.Arg(0) == "" {
+			fs.Usage()
+			return errors.New("missing match ID")
+		}
+		_, streams, err := matchStreams(ctx, provider, fs.Arg(0), fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return writeJSON(os.Stdout, streams)
+		}
+		return printTable(func(w io.Writer) {
+			fmt.Fprintln(w, "SOURCE\tNO\tLANGUAGE\tQUALITY\tVIEWERS\tEMBED URL")
+			for _, st := range streams {
+				quality := "SD"
+				if st.HD {
+					quality = "HD"
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\t%s\n", st.Source, st.StreamNo, st.Language, quality, st.Viewers, st.EmbedURL)
+			}
+		})
+	}
+	fs.Usage()
+	return fmt.Errorf("cannot list %q", sub)
+}
+
+// cliProvider returns what the command-line modes list matches from: the
+// streamed API, or the demo fixtures, merged with the configured providers.
+// Providers that fail while others answer are reported on stderr.
+func cliProvider(opts Options) (Provider, error) {
+	client := NewClient(ResolveBaseURL(opts.BaseURL), opts.APITimeout)
+	client.SetMirrors(opts.Mirrors)
+	client.SetViewCountURL(opts.ViewCountURL)
+	if opts.Demo {
+		client = newDemoClient(opts.APITimeout)
+	}
+	extras, err := opts.extraProviders()
+	if err != nil {
+		return nil, err
+	}
+	clients := []*Client{client}
+	for _, p := range extras {
+		if c, ok := p.(*Client); ok {
+			clients = append(clients, c)
+		}
+	}
+	for _, c := range clients {
+		if !opts.Demo {
+			c.SetProxy(opts.proxy())
+		}
+		c.SetStrict(opts.Strict)
+		c.SetRetries(opts.APIRetries)
+	}
+	return newProviderSet(client, extras, func(line string) { fmt.Fprintln(os.Stderr, "warning:", line) }), nil
+}
+
+// listMatches fetches the popular matches, or those of a sport.
+func listMatches(ctx context.Context, provider Provider, sport string) ([]Match, error) {
+	if sport == "" {
+		sport = popularSportID
+	}
+	return provider.ListMatches(ctx, sport)
+}
+
+// matchStreams finds a match by ID among the matches of sport, or of all
+// sports when it is empty, and fetches its streams. Streams of the sources
+// that answered are returned with a warning on stderr when others failed.
+func matchStreams(ctx context.Context, provider Provider, id, sport string) (Match, []Stream, error) {
+	if sport == "" {
+		sport = "all"
+	}
+	matches, err := listMatches(ctx, provider, sport)
+	if err != nil {
+		return Match{}, nil, err
+	}
+	i := slices.IndexFunc(matches, func(mt Match) bool { return mt.ID == id })
+	if i < 0 {
+		return Match{}, nil, fmt.Errorf("no match %q in %s", id, sport)
+	}
+	streams, err := provider.ListStreams(ctx, matches[i])
+	if partial, err := partialStreams(err); err != nil {
+		return matches[i], nil, err
+	} else if partial != nil {
+		fmt.Fprintln(os.Stderr, "warning:", partial)
+	}
+	return matches[i], streams, nil
+}
+
+// playCandidates lists the streams worth extracting, best first: the one
+// autoPickStream chooses, then its runners-up, up to limit.
+func playCandidates(streams []Stream, languages []string, limit int) []Stream {
+	var out []Stream
+	rest := slices.Clone(streams)
+	for len(out) < limit {
+		st, ok := autoPickStream(rest, languages)
+		if !ok {
+			break
+		}
+		out = append(out, st)
+		rest = slices.DeleteFunc(rest, func(s Stream) bool { return s.EmbedURL == st.EmbedURL })
+	}
+	return out
+}
+
+// RunPlayCommand handles "streamed-tui play <match-id>": it picks the best
+// stream of the match by the stream preference and languages, extracts it
+// (falling back to the next ones), and launches the player without the TUI.
+func RunPlayCommand(args []string, opts Options) error {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	asJSON := fs.Bool("json", opts.JSON, "print the extracted stream as JSON instead of playing it")
+	fullscreen := fs.Bool("fullscreen", opts.Fullscreen, "open the player fullscreen")
+	demo := fs.Bool("demo", opts.Demo, "play from the bundled demo fixtures")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: streamed-tui play [--json] [--fullscreen] <match-id> [sport]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.Arg(0) == "" {
+		fs.Usage()
+		return errors.New("missing match ID")
+	}
+	opts = opts.withDefaults()
+	opts.JSON, opts.Fullscreen, opts.Demo = *asJSON, *fullscreen, *demo
+	if err := opts.checkProxy(); err != nil {
+		return err
+	}
+
+	extract := extractFunc(extractM3U8)
+	if *demo {
+		extract = demoExtract
+	}
+	provider, err := cliProvider(opts)
+	if err != nil {
+		return err
+	}
+
+	mt, streams, err := matchStreams(context.Background(), provider, fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	ranked := reorderStreams(streams, parseStreamPreference(opts.StreamPreference))
+	candidates := playCandidates(ranked, opts.Languages, 1+max(opts.FallbackStreams, 0))
+	if len(candidates) == 0 {
+		if len(opts.Languages) > 0 {
+			return fmt.Errorf("no playable %s streams for %s", strings.Join(opts.Languages, "/"), matchDisplayTitle(mt))
+		}
+		return fmt.Errorf("no playable streams for %s", matchDisplayTitle(mt))
+	}
+	embedURLs := make([]string, len(candidates))
+	for i, st := range candidates {
+		embedURLs[i] = st.EmbedURL
+	}
+	return extractAndPlay(embedURLs, matchDisplayTitle(mt), extract, opts)
+}
+
+func printTable(rows func(io.Writer)) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	rows(w)
+	return w.Flush()
+}
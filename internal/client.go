@@ -1,14 +1,25 @@
 package internal
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"os"
 	"sort"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // ────────────────────────────────
@@ -16,8 +27,21 @@ import (
 // ────────────────────────────────
 
 type Client struct {
-	base string
-	http *http.Client
+	base    string
+	name    string
+	http    *http.Client
+	trace   func(string)
+	log     func(string)
+	retries int
+	cache   *responseCache
+	schema  *schemaChecker
+	strict  bool
+	mirrors *mirrorSet
+	// viewCountURL overrides where popular view counts are fetched from.
+	viewCountURL string
+	// flights coalesces concurrent requests for the same URL.
+	flightMu sync.Mutex
+	flights  map[string]*flight
 }
 
 func NewClient(base string, timeout time.Duration) *Client {
@@ -27,6 +51,15 @@ func NewClient(base string, timeout time.Duration) *Client {
 	}
 }
 
+// ResolveBaseURL returns the API base URL, preferring an explicit override
+// (e.g. the --base flag) over STREAMED_BASE and the built-in default.
+func ResolveBaseURL(override string) string {
+	if val := strings.TrimSpace(override); val != "" {
+		return strings.TrimRight(val, "/")
+	}
+	return BaseURLFromEnv()
+}
+
 func BaseURLFromEnv() string {
 	val := strings.TrimSpace(os.Getenv("STREAMED_BASE"))
 	if val == "" {
@@ -35,6 +68,52 @@ func BaseURLFromEnv() string {
 	return strings.TrimRight(val, "/")
 }
 
+// SetTracer enables per-request tracing. Each completed request reports its
+// method, URL, status, timing, and response size through fn.
+func (c *Client) SetTracer(fn func(string)) { c.trace = fn }
+
+// SetSchemaWarnings enables schema drift detection. Unknown or missing JSON
+// fields in API responses are reported through fn, once per finding.
+func (c *Client) SetSchemaWarnings(fn func(string)) { c.schema = newSchemaChecker(fn) }
+
+// SetStrict makes the client reject responses that are not JSON, contain
+// fields it does not model, or decode into items missing required values.
+func (c *Client) SetStrict(strict bool) { c.strict = strict }
+
+// SetProxy routes API requests through the proxy; empty keeps the default
+// transport, which honours HTTP_PROXY and friends.
+func (c *Client) SetProxy(proxy string) { c.http.Transport = proxyTransport(proxy) }
+
+// SetRetries sets how often a request that failed transiently (timeout,
+// connection reset, 5xx, 429) is retried; zero disables retries.
+func (c *Client) SetRetries(n int) { c.retries = max(n, 0) }
+
+// SetLogger reports retried requests through fn.
+func (c *Client) SetLogger(fn func(string)) { c.log = fn }
+
+func (c *Client) logf(format string, args ...any) {
+	if c.log != nil {
+		c.log(fmt.Sprintf(format, args...))
+	}
+}
+
+// SetViewCountURL fetches popular view counts from url instead of the API
+// host's popular-viewcount endpoint; empty keeps the default.
+func (c *Client) SetViewCountURL(url string) { c.viewCountURL = strings.TrimSpace(url) }
+
+// SetCache reuses sports, match, and view-count responses from cache until
+// their TTL runs out.
+func (c *Client) SetCache(cache *responseCache) { c.cache = cache }
+
+// Base returns the API base URL the client talks to: the active mirror when
+// it has mirrors.
+func (c *Client) Base() string {
+	if c.mirrors != nil {
+		return c.mirrors.current()
+	}
+	return c.base
+}
+
 type Sport struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -50,20 +129,28 @@ type Teams struct {
 	Away *Team `json:"away"`
 }
 
+type MatchSource struct {
+	Source string `json:"source"`
+	ID     string `json:"id"`
+}
+
 type Match struct {
-	ID       string `json:"id"`
-	Title    string `json:"title"`
-	Category string `json:"category"`
-	Date     int64  `json:"date"`
-	Poster   string `json:"poster"`
-	Popular  bool   `json:"popular"`
-	Teams    *Teams `json:"teams"`
-	Sources  []struct {
-		Source string `json:"source"`
-		ID     string `json:"id"`
-	} `json:"sources"`
+	ID       string        `json:"id"`
+	Title    string        `json:"title"`
+	Category string        `json:"category"`
+	Date     int64         `json:"date"`
+	Poster   string        `json:"poster"`
+	Popular  bool          `json:"popular"`
+	Teams    *Teams        `json:"teams"`
+	Sources  []MatchSource `json:"sources"`
+
+	// Provider names the provider that listed the match when several are
+	// configured.
+	Provider string `json:"provider,omitempty"`
 
-	Viewers int `json:"viewers"`
+	// Viewers is filled in from the popular view-count endpoint rather than
+	// sent with the match itself.
+	Viewers int `json:"viewers" schema:"optional"`
 }
 
 type Stream struct {
@@ -80,8 +167,64 @@ type Stream struct {
 // API CLIENT
 // ────────────────────────────────
 
+// PosterURL returns the absolute URL of a match poster. The API sends a path
+// on its own host, or on older mirrors just the image name.
+func (c *Client) PosterURL(mt Match) string {
+	switch p := mt.Poster; {
+	case p == "", strings.HasPrefix(p, "http://"), strings.HasPrefix(p, "https://"):
+		return p
+	case strings.HasPrefix(p, "/"):
+		return c.Base() + p
+	default:
+		return c.Base() + "/api/images/proxy/" + p + ".webp"
+	}
+}
+
+// BadgeURL returns the absolute URL of a team badge, which the API sends as
+// an image ID.
+func (c *Client) BadgeURL(t Team) string {
+	switch b := t.Badge; {
+	case b == "", strings.HasPrefix(b, "http://"), strings.HasPrefix(b, "https://"):
+		return b
+	case strings.HasPrefix(b, "/"):
+		return c.Base() + b
+	default:
+		return c.Base() + "/api/images/badge/" + b + ".webp"
+	}
+}
+
+// FetchImage downloads and decodes a poster or badge (PNG, JPEG, GIF, or
+// WebP) through the same proxy as API requests.
+func (c *Client) FetchImage(ctx context.Context, url string) (image.Image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "StreamedTUI/1.0 (+https://github.com/Salastil/streamed-tui)")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("GET %s: image larger than %d bytes", url, maxImageBytes)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", url, err)
+	}
+	return img, nil
+}
+
 func (c *Client) GetSports(ctx context.Context) ([]Sport, error) {
-	url := c.base + "/api/sports"
+	url := c.Base() + "/api/sports"
 	var out []Sport
 	if err := c.get(ctx, url, &out); err != nil {
 		return nil, err
@@ -89,39 +232,55 @@ func (c *Client) GetSports(ctx context.Context) ([]Sport, error) {
 	return out, nil
 }
 
+// GetPopularMatches fetches the popular matches and their view counts at
+// once. View counts are best-effort: when only they fail, the matches are
+// returned without viewer numbers and the failure is logged.
 func (c *Client) GetPopularMatches(ctx context.Context) ([]Match, error) {
-	url := c.base + "/api/matches/all/popular"
+	var (
+		viewCounts PopularViewCounts
+		viewErr    error
+		done       = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		viewCounts, viewErr = c.GetPopularViewCounts(ctx)
+	}()
+
+	url := c.Base() + "/api/matches/all/popular"
 	matches, err := c.getMatches(ctx, url)
+	<-done
 	if err != nil {
 		return nil, err
 	}
-
-	viewCounts, err := c.GetPopularViewCounts(ctx)
-	if err != nil {
-		return nil, err
+	if viewErr != nil {
+		c.logf("[api] popular matches shown without viewer numbers: %v", viewErr)
+		return matches, nil
 	}
+	viewCounts.attach(matches)
+	return matches, nil
+}
 
+// attach sets the viewer numbers of matches it has counts for.
+func (vc PopularViewCounts) attach(matches []Match) {
 	for i := range matches {
 		// Prefer a direct match on the match ID.
-		if viewers, ok := viewCounts.ByMatchID[matches[i].ID]; ok {
+		if viewers, ok := vc.ByMatchID[matches[i].ID]; ok {
 			matches[i].Viewers = viewers
 			continue
 		}
 
 		// Fallback: some IDs can differ between endpoints, so also try source IDs.
 		for _, src := range matches[i].Sources {
-			if viewers, ok := viewCounts.BySourceID[src.ID]; ok {
+			if viewers, ok := vc.BySourceID[src.ID]; ok {
 				matches[i].Viewers = viewers
 				break
 			}
 		}
 	}
-
-	return matches, nil
 }
 
 func (c *Client) GetMatchesBySport(ctx context.Context, sportID string) ([]Match, error) {
-	url := fmt.Sprintf("%s/api/matches/%s", c.base, sportID)
+	url := fmt.Sprintf("%s/api/matches/%s", c.Base(), sportID)
 	return c.getMatches(ctx, url)
 }
 
@@ -130,8 +289,13 @@ type PopularViewCounts struct {
 	BySourceID map[string]int
 }
 
+// GetPopularViewCounts fetches the viewer numbers of live popular matches,
+// from the API host unless SetViewCountURL points elsewhere.
 func (c *Client) GetPopularViewCounts(ctx context.Context) (PopularViewCounts, error) {
-	url := "https://streami.su/api/matches/live/popular-viewcount"
+	url := c.viewCountURL
+	if url == "" {
+		url = c.Base() + "/api/matches/live/popular-viewcount"
+	}
 
 	var payload []struct {
 		ID      string `json:"id"`
@@ -160,15 +324,69 @@ func (c *Client) GetPopularViewCounts(ctx context.Context) (PopularViewCounts, e
 	return PopularViewCounts{ByMatchID: matchMap, BySourceID: sourceMap}, nil
 }
 
+// streamFetchConcurrency bounds parallel per-source stream requests.
+const streamFetchConcurrency = 4
+
+// SourceError is a match source whose streams could not be fetched.
+type SourceError struct {
+	Source MatchSource
+	Err    error
+}
+
+// StreamSourcesError is returned by GetStreamsForMatch when some sources
+// failed. The streams of the sources that answered are returned with it, so
+// callers should check for them before treating the error as fatal.
+type StreamSourcesError struct {
+	Failed []SourceError
+	Total  int
+}
+
+func (e *StreamSourcesError) Error() string {
+	parts := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		parts[i] = fmt.Sprintf("%s: %v", f.Source.Source, f.Err)
+	}
+	return fmt.Sprintf("%d of %d stream sources failed (%s)", len(e.Failed), e.Total, strings.Join(parts, "; "))
+}
+
+func (e *StreamSourcesError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// GetStreamsForMatch fetches the streams of every source of mt in parallel.
+// Streams keep the order of the sources. When a source fails the others are
+// still returned, together with a *StreamSourcesError naming the failures.
 func (c *Client) GetStreamsForMatch(ctx context.Context, mt Match) ([]Stream, error) {
-	var all []Stream
-	for _, src := range mt.Sources {
-		url := fmt.Sprintf("%s/api/stream/%s/%s", c.base, src.Source, src.ID)
-		var list []Stream
-		if err := c.get(ctx, url, &list); err != nil {
-			return nil, err
+	lists := make([][]Stream, len(mt.Sources))
+	errs := make([]error, len(mt.Sources))
+	var g errgroup.Group
+	g.SetLimit(streamFetchConcurrency)
+	for i, src := range mt.Sources {
+		g.Go(func() error {
+			url := fmt.Sprintf("%s/api/stream/%s/%s", c.Base(), src.Source, src.ID)
+			errs[i] = c.get(ctx, url, &lists[i])
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var (
+		all    []Stream
+		failed []SourceError
+	)
+	for i, src := range mt.Sources {
+		if errs[i] != nil {
+			failed = append(failed, SourceError{Source: src, Err: errs[i]})
+			continue
 		}
-		all = append(all, list...)
+		all = append(all, lists[i]...)
+	}
+	if len(failed) > 0 {
+		return all, &StreamSourcesError{Failed: failed, Total: len(mt.Sources)}
 	}
 	return all, nil
 }
@@ -182,22 +400,428 @@ func (c *Client) getMatches(ctx context.Context, url string) ([]Match, error) {
 	return out, nil
 }
 
+// get fetches url and decodes the JSON response into v, retrying transient
+// failures with jittered exponential backoff and then trying the mirrors.
+// Cached responses are used while fresh unless ctx bypasses the cache, and
+// concurrent requests for the same URL share one fetch.
 func (c *Client) get(ctx context.Context, url string, v any) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if !cacheBypassed(ctx) {
+		if e, ok := c.cache.lookup(url); ok {
+			if c.trace != nil {
+				c.trace(fmt.Sprintf("[http] GET %s → cached %s ago", url, time.Since(e.Fetched).Round(time.Second)))
+			}
+			return c.decode(url, e.ContentType, e.Body, v)
+		}
+	}
+	body, err := c.share(ctx, url)
+	if err != nil {
+		return err
+	}
+	if err := c.decode(body.url, body.contentType, body.data, v); err != nil {
+		return err
+	}
+	if err := c.cache.store(url, body.contentType, body.data); err != nil && c.log != nil {
+		c.log(fmt.Sprintf("[cache] could not save %s: %v", url, err))
+	}
+	return nil
+}
+
+// response is a fetched response body, shared by coalesced requests and
+// decoded by each of them.
+type response struct {
+	url         string
+	contentType string
+	data        []byte
+}
+
+// flight is a fetch shared by concurrent requests for the same URL. It runs
+// under its own context, canceled once every request waiting for it has
+// given up, so one caller leaving does not cut the others off.
+type flight struct {
+	done    chan struct{}
+	res     response
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// share fetches url, joining the fetch already running for it if there is
+// one.
+func (c *Client) share(ctx context.Context, url string) (response, error) {
+	c.flightMu.Lock()
+	f, ok := c.flights[url]
+	if !ok {
+		fctx, cancel := context.WithCancel(context.Background())
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		if c.flights == nil {
+			c.flights = make(map[string]*flight)
+		}
+		c.flights[url] = f
+		go func() {
+			f.res, f.err = c.fetch(fctx, url)
+			cancel()
+			c.flightMu.Lock()
+			c.forgetFlight(url, f)
+			c.flightMu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	c.flightMu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.res, f.err
+	case <-ctx.Done():
+		c.flightMu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			c.forgetFlight(url, f)
+		}
+		c.flightMu.Unlock()
+		return response{}, ctx.Err()
+	}
+}
+
+// forgetFlight lets the next request for url start a fetch of its own
+// instead of joining f. c.flightMu must be held.
+func (c *Client) forgetFlight(url string, f *flight) {
+	if c.flights[url] == f {
+		delete(c.flights, url)
+	}
+}
+
+// fetch gets url, retrying transient failures and then trying the mirrors.
+func (c *Client) fetch(ctx context.Context, url string) (response, error) {
+	for attempt := 1; ; attempt++ {
+		res, transient, err := c.fetchOnce(ctx, url)
+		if err == nil || !transient {
+			return res, err
+		}
+		if attempt > c.retries {
+			if attempt > 1 {
+				err = fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+			}
+			return c.failover(ctx, url, err)
+		}
+		wait := retryDelay(attempt)
+		if c.log != nil {
+			c.log(fmt.Sprintf("[http] GET %s failed (attempt %d of %d): %v – retrying in %s",
+				url, attempt, c.retries+1, err, wait.Round(time.Millisecond)))
+		}
+		select {
+		case <-ctx.Done():
+			return response{}, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// getOnce performs a single request and decodes it into v, caching the
+// response.
+func (c *Client) getOnce(ctx context.Context, url string, v any) error {
+	res, _, err := c.fetchOnce(ctx, url)
 	if err != nil {
 		return err
 	}
+	if err := c.decode(url, res.contentType, res.data, v); err != nil {
+		return err
+	}
+	if err := c.cache.store(url, res.contentType, res.data); err != nil && c.log != nil {
+		c.log(fmt.Sprintf("[cache] could not save %s: %v", url, err))
+	}
+	return nil
+}
+
+// fetchOnce performs a single request and reports whether a failure is worth
+// retrying.
+func (c *Client) fetchOnce(ctx context.Context, url string) (res response, transient bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return res, false, err
+	}
 	req.Header.Set("User-Agent", "StreamedTUI/1.0 (+https://github.com/Salastil/streamed-tui)")
 	req.Header.Set("Accept", "application/json")
 
+	var tr *requestTrace
+	if c.trace != nil {
+		tr = &requestTrace{start: time.Now()}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), tr.clientTrace()))
+	}
+
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return err
+		if tr != nil {
+			c.trace(tr.failed(req, err))
+		}
+		return res, ctx.Err() == nil && transientError(err), err
 	}
 	defer resp.Body.Close()
 
+	body := io.Reader(resp.Body)
+	if tr != nil {
+		counter := &countingReader{r: resp.Body}
+		body = counter
+		defer func() { c.trace(tr.done(req, resp, counter.n)) }()
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("GET %s: %s", url, resp.Status)
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return res, retry, newStatusError(url, resp, body)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return res, ctx.Err() == nil && transientError(err), err
+	}
+	return response{url: url, contentType: resp.Header.Get("Content-Type"), data: data}, false, nil
+}
+
+// decode unmarshals a response body into v, applying strict mode and the
+// schema check.
+func (c *Client) decode(url, contentType string, data []byte, v any) error {
+	if c.strict {
+		if err := decodeStrict(contentType, data, v); err != nil {
+			return fmt.Errorf("GET %s: %w", url, err)
+		}
+	} else if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("GET %s: %w (%s: %#q)", url, err, contentTypeLabel(contentType), bodySummary(data))
+	}
+	c.schema.check(url, data, v)
+	return nil
+}
+
+// ────────────────────────────────
+// RETRIES
+// ────────────────────────────────
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+)
+
+// retryDelay returns the wait before retry n (1-based): the base delay
+// doubled per attempt up to a cap, with the upper half jittered so clients
+// that failed together do not retry in lockstep.
+func retryDelay(n int) time.Duration {
+	d := retryBaseDelay << (n - 1)
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// transientError reports whether a transport error is likely to go away on
+// its own: timeouts, resets, refused connections, and truncated responses.
+func transientError(err error) bool {
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED), errors.Is(err, syscall.EPIPE):
+		return true
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return true
+	}
+	return false
+}
+
+// ────────────────────────────────
+// STRICT DECODING
+// ────────────────────────────────
+
+// decodeStrict rejects obviously malformed payloads, such as HTML error or
+// challenge pages served with a 200 status, before and after decoding.
+func decodeStrict(contentType string, data []byte, v any) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return errors.New("malformed payload: empty response body")
+	}
+	if trimmed[0] == '<' || strings.Contains(strings.ToLower(contentType), "html") {
+		return fmt.Errorf("malformed payload: expected JSON but got %s (%#q)", contentTypeLabel(contentType), bodySummary(trimmed))
+	}
+	if bytes.Equal(trimmed, []byte("null")) {
+		return errors.New("malformed payload: response body is null")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("malformed payload: %w", err)
+	}
+	if dec.More() {
+		return errors.New("malformed payload: trailing data after JSON value")
+	}
+	return validatePayload(v)
+}
+
+// validatePayload checks decoded API items for the values the UI relies on.
+func validatePayload(v any) error {
+	switch out := v.(type) {
+	case *[]Sport:
+		for i, sp := range *out {
+			if sp.ID == "" || sp.Name == "" {
+				return fmt.Errorf("malformed payload: sport %d is missing id or name", i)
+			}
+		}
+	case *[]Match:
+		for i, mt := range *out {
+			if mt.ID == "" {
+				return fmt.Errorf("malformed payload: match %d is missing id", i)
+			}
+			if mt.Title == "" && mt.Teams == nil {
+				return fmt.Errorf("malformed payload: match %s has neither title nor teams", mt.ID)
+			}
+		}
+	case *[]Stream:
+		for i, st := range *out {
+			if st.EmbedURL == "" {
+				return fmt.Errorf("malformed payload: stream %d is missing embedUrl", i)
+			}
+		}
+	}
+	return nil
+}
+
+func contentTypeLabel(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	if mediaType = strings.TrimSpace(mediaType); mediaType == "" {
+		return "an unlabeled body"
+	}
+	return mediaType
+}
+
+func snippet(data []byte, max int) string {
+	if r := []rune(string(data)); len(r) > max {
+		return string(r[:max]) + "…"
+	}
+	return string(data)
+}
+
+// ────────────────────────────────
+// ERROR RESPONSES
+// ────────────────────────────────
+
+const (
+	// errorBodyBytes is how much of an error response is read to describe it.
+	errorBodyBytes = 4096
+	// errorSnippetLen bounds the part of a response body quoted in errors.
+	errorSnippetLen = 120
+)
+
+// statusError is a non-2xx API response. It quotes the start of the body and
+// names its content type, so Cloudflare challenges and HTML error pages can
+// be told apart from errors of the API itself.
+type statusError struct {
+	URL         string
+	Status      string
+	Code        int
+	ContentType string
+	Body        string
+	// Challenge is set when Cloudflare answered with a bot challenge.
+	Challenge bool
+}
+
+func newStatusError(url string, resp *http.Response, body io.Reader) *statusError {
+	data, _ := io.ReadAll(io.LimitReader(body, errorBodyBytes))
+	contentType := resp.Header.Get("Content-Type")
+	summary := bodySummary(data)
+	return &statusError{
+		URL:         url,
+		Status:      resp.Status,
+		Code:        resp.StatusCode,
+		ContentType: contentType,
+		Body:        summary,
+		Challenge:   resp.Header.Get("Cf-Mitigated") == "challenge" || strings.HasPrefix(summary, "Just a moment"),
+	}
+}
+
+func (e *statusError) Error() string {
+	msg := fmt.Sprintf("GET %s: %s", e.URL, e.Status)
+	if e.Challenge {
+		msg += " (Cloudflare challenge)"
+	}
+	if e.Body != "" {
+		msg += fmt.Sprintf(" – %s: %#q", contentTypeLabel(e.ContentType), e.Body)
+	}
+	return msg
+}
+
+// bodySummary describes a response body in one short line: an HTML page's
+// title when it has one, or the start of the body with whitespace collapsed.
+func bodySummary(data []byte) string {
+	text := string(bytes.TrimSpace(data))
+	lower := strings.ToLower(text)
+	if start := strings.Index(lower, "<title"); start >= 0 {
+		if open := strings.Index(lower[start:], ">"); open >= 0 {
+			rest := text[start+open+1:]
+			if end := strings.Index(strings.ToLower(rest), "</title>"); end >= 0 && strings.TrimSpace(rest[:end]) != "" {
+				text = rest[:end]
+			}
+		}
+	}
+	return snippet([]byte(strings.Join(strings.Fields(text), " ")), errorSnippetLen)
+}
+
+// ────────────────────────────────
+// REQUEST TRACING
+// ────────────────────────────────
+
+type requestTrace struct {
+	start     time.Time
+	firstByte time.Duration
+	reused    bool
+}
+
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.reused = info.Reused
+		},
+		GotFirstResponseByte: func() {
+			t.firstByte = time.Since(t.start)
+		},
+	}
+}
+
+func (t *requestTrace) done(req *http.Request, resp *http.Response, size int64) string {
+	conn := "new conn"
+	if t.reused {
+		conn = "reused conn"
+	}
+	return fmt.Sprintf("[http] %s %s → %s in %s (ttfb %s, %s, %s)",
+		req.Method, req.URL, resp.Status,
+		time.Since(t.start).Round(time.Millisecond),
+		t.firstByte.Round(time.Millisecond),
+		formatByteSize(size), conn)
+}
+
+func (t *requestTrace) failed(req *http.Request, err error) string {
+	return fmt.Sprintf("[http] %s %s failed after %s: %v",
+		req.Method, req.URL, time.Since(t.start).Round(time.Millisecond), err)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
 	}
-	return json.NewDecoder(resp.Body).Decode(v)
 }
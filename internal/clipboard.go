@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// CLIPBOARD
+// ────────────────────────────────
+
+// clipboardCopiedMsg reports the outcome of copyToClipboard.
+type clipboardCopiedMsg struct {
+	What string
+	Via  string
+	Err  error
+}
+
+// clipboardCommand returns the first clipboard tool that suits the session,
+// or nil when there is none.
+func clipboardCommand(getenv func(string) string) []string {
+	var candidates [][]string
+	switch {
+	case runtime.GOOS == "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case runtime.GOOS == "windows":
+		candidates = [][]string{{"clip.exe"}}
+	case getenv("WAYLAND_DISPLAY") != "":
+		candidates = [][]string{{"wl-copy"}}
+	case getenv("DISPLAY") != "":
+		candidates = [][]string{{"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	}
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// copyToClipboard puts text on the system clipboard with wl-copy, xclip,
+// xsel, pbcopy, or clip.exe when one is available, and otherwise asks the
+// terminal to with OSC 52, which also works over SSH. tmux only passes OSC 52
+// on wrapped in its own passthrough sequence. A TUI served by serve-ssh
+// passes its session as remote, which always gets OSC 52 so the text lands
+// on the client's clipboard rather than the server's.
+func copyToClipboard(what, text string, remote io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+		if remote != nil {
+			if _, err := io.WriteString(remote, seq); err != nil {
+				return clipboardCopiedMsg{What: what, Err: err}
+			}
+			return clipboardCopiedMsg{What: what, Via: "the SSH client's terminal"}
+		}
+		if args := clipboardCommand(os.Getenv); args != nil {
+			cmd := exec.Command(args[0], args[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			if err := cmd.Run(); err == nil {
+				return clipboardCopiedMsg{What: what, Via: args[0]}
+			}
+		}
+		if os.Getenv("TMUX") != "" {
+			seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+		}
+		// A single write cannot land in the middle of a frame.
+		if _, err := os.Stdout.WriteString(seq); err != nil {
+			return clipboardCopiedMsg{What: what, Err: err}
+		}
+		return clipboardCopiedMsg{What: what, Via: "the terminal"}
+	}
+}
+
+func (msg clipboardCopiedMsg) status() string {
+	if msg.Err != nil {
+		return fmt.Sprintf("Could not copy the %s: %v", msg.What, msg.Err)
+	}
+	return fmt.Sprintf("Copied the %s to the clipboard (via %s)", msg.What, msg.Via)
+}
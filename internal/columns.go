@@ -18,10 +18,63 @@ type Styles struct {
 	Status lipgloss.Style
 	Error  lipgloss.Style // NEW: for red bold error lines
 	Subtle lipgloss.Style
+	Glyphs Glyphs
+	Border lipgloss.Border
+	ASCII  bool
+}
+
+// Glyphs are the decorative characters used when rendering columns. Some fonts
+// render the defaults double-width, so each one can be overridden.
+type Glyphs struct {
+	Cursor    string // marks the selected row
+	Focus     string // prefixes the focused column's title
+	Separator string // fills separator rows on both sides of the label
+	Ellipsis  string // marks truncated rows
+}
+
+func DefaultGlyphs() Glyphs {
+	return Glyphs{Cursor: "▸", Focus: "▶", Separator: "─", Ellipsis: "…"}
+}
+
+// Merge returns g with every non-empty field of override applied.
+func (g Glyphs) Merge(override Glyphs) Glyphs {
+	if override.Cursor != "" {
+		g.Cursor = override.Cursor
+	}
+	if override.Focus != "" {
+		g.Focus = override.Focus
+	}
+	if override.Separator != "" {
+		g.Separator = override.Separator
+	}
+	if override.Ellipsis != "" {
+		g.Ellipsis = override.Ellipsis
+	}
+	return g
 }
 
 func NewStyles() Styles {
-	border := lipgloss.RoundedBorder()
+	return newStyles(lipgloss.RoundedBorder(), DefaultGlyphs())
+}
+
+// NewASCIIStyles returns styles that only use plain ASCII for borders, glyphs,
+// and text, for dumb terminals and serial consoles.
+func NewASCIIStyles() Styles {
+	s := newStyles(asciiBorder, asciiGlyphs())
+	s.ASCII = true
+	return s
+}
+
+// Text returns s as it should be displayed, stripping decorative non-ASCII
+// characters in ASCII mode.
+func (s Styles) Text(str string) string {
+	if s.ASCII {
+		return toASCII(str)
+	}
+	return str
+}
+
+func newStyles(border lipgloss.Border, glyphs Glyphs) Styles {
 	return Styles{
 		Title: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12")),
 		Box:   lipgloss.NewStyle().Border(border).Padding(0, 1),
@@ -32,6 +85,8 @@ func NewStyles() Styles {
 		Status: lipgloss.NewStyle().Foreground(lipgloss.Color("8")).MarginTop(1),
 		Error:  lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true),
 		Subtle: lipgloss.NewStyle().Foreground(lipgloss.Color("243")),
+		Glyphs: glyphs,
+		Border: border,
 	}
 }
 
@@ -43,7 +98,9 @@ type renderer[T any] func(T) string
 
 type ListColumn[T any] struct {
 	title    string
-	items    []T
+	items    []T // visible items: source narrowed by filter
+	source   []T
+	filter   string
 	selected int
 	scroll   int
 	width    int
@@ -51,6 +108,18 @@ type ListColumn[T any] struct {
 	render   renderer[T]
 
 	separator func(prev, curr T) (string, bool)
+	decorator func(item T, text string) string
+	// onItems is told the visible items whenever they change.
+	onItems func(items []T)
+	// footer is a separator-style row after the last item, e.g. a summary
+	// of items left out.
+	footer string
+
+	// rows caches buildRows; rowOf maps item index to row index. Both are
+	// rebuilt lazily after the items, separator, or width change.
+	rows      []listRow[T]
+	rowOf     []int
+	rowsValid bool
 }
 
 func NewListColumn[T any](title string, r renderer[T]) *ListColumn[T] {
@@ -59,6 +128,39 @@ func NewListColumn[T any](title string, r renderer[T]) *ListColumn[T] {
 
 func (c *ListColumn[T]) SetSeparator(sep func(prev, curr T) (string, bool)) {
 	c.separator = sep
+	c.rowsValid = false
+}
+
+// SetFooter sets the row shown after the last item; empty removes it.
+func (c *ListColumn[T]) SetFooter(text string) {
+	if text != c.footer {
+		c.footer = text
+		c.rowsValid = false
+	}
+}
+
+// SetDecorator installs a hook that post-processes the visible (already
+// truncated) text of unselected rows, e.g. to colorize part of it.
+func (c *ListColumn[T]) SetDecorator(fn func(item T, text string) string) {
+	c.decorator = fn
+}
+
+// OnItems installs a hook told the visible items whenever they change, so
+// what the decorator needs from the whole list is worked out once rather
+// than for every row.
+func (c *ListColumn[T]) OnItems(fn func(items []T)) {
+	c.onItems = fn
+	fn(c.items)
+}
+
+// setVisible narrows items by the filter and makes them the visible ones.
+func (c *ListColumn[T]) setVisible(items []T) {
+	c.source = items
+	c.items = c.filtered(items)
+	c.rowsValid = false
+	if c.onItems != nil {
+		c.onItems(c.items)
+	}
 }
 
 func truncateToWidth(text string, width int) string {
@@ -83,7 +185,7 @@ func truncateToWidth(text string, width int) string {
 	return text
 }
 
-func buildSeparatorLine(label string, width int) string {
+func buildSeparatorLine(label string, width int, fill string) string {
 	if width <= 0 {
 		return label
 	}
@@ -95,22 +197,107 @@ func buildSeparatorLine(label string, width int) string {
 		return truncateToWidth(padded, width)
 	}
 
+	// Repeat the fill by its display width so wide glyphs do not overflow.
+	fillWidth := lipgloss.Width(fill)
+	if fillWidth < 1 {
+		fill, fillWidth = " ", 1
+	}
 	left := remaining / 2
 	right := remaining - left
-	return strings.Repeat("─", left) + padded + strings.Repeat("─", right)
+	leftFill := strings.Repeat(fill, left/fillWidth) + strings.Repeat(" ", left%fillWidth)
+	rightFill := strings.Repeat(" ", right%fillWidth) + strings.Repeat(fill, right/fillWidth)
+	return leftFill + padded + rightFill
 }
 
 func (c *ListColumn[T]) SetItems(items []T) {
-	c.items = items
+	c.setVisible(items)
+	c.selected = 0
+	c.scroll = 0
+}
+
+// ReplaceItems swaps in a refreshed or re-sorted item list while keeping the
+// cursor on the previously selected item (as identified by same) at the same
+// height on screen. When that item is gone the cursor stays at the same
+// position instead of going back to the top.
+func (c *ListColumn[T]) ReplaceItems(items []T, same func(a, b T) bool) {
+	prev, hadPrev := c.Selected()
+	offset := 0
+	if hadPrev {
+		c.buildRows()
+		offset = c.rowOf[c.selected] - c.scroll
+	}
+	c.setVisible(items)
+	if !hadPrev {
+		c.selected, c.scroll = 0, 0
+		return
+	}
+	found := false
+	for i, item := range c.items {
+		if same(prev, item) {
+			c.selected, found = i, true
+			break
+		}
+	}
+	if !found {
+		c.selected = max(min(c.selected, len(c.items)-1), 0)
+	}
+	if len(c.items) > 0 {
+		c.buildRows()
+		c.scroll = c.rowOf[c.selected] - offset
+	}
+	c.ensureSelectedVisible()
+}
+
+// SetFilter narrows the visible items to those whose rendered text contains
+// query (case-insensitively). An empty query shows every item. The cursor
+// stays on the selected item when it is still visible.
+func (c *ListColumn[T]) SetFilter(query string) {
+	prev, hadPrev := c.Selected()
+	c.filter = query
+	c.setVisible(c.source)
 	c.selected = 0
 	c.scroll = 0
+	if hadPrev {
+		rendered := c.render(prev)
+		for i, item := range c.items {
+			if c.render(item) == rendered {
+				c.selected = i
+				break
+			}
+		}
+	}
+	c.ensureSelectedVisible()
+}
+
+// Filter returns the active filter query.
+func (c *ListColumn[T]) Filter() string { return c.filter }
+
+func (c *ListColumn[T]) filtered(items []T) []T {
+	query := strings.ToLower(strings.TrimSpace(c.filter))
+	if query == "" {
+		return items
+	}
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		if strings.Contains(strings.ToLower(c.render(item)), query) {
+			out = append(out, item)
+		}
+	}
+	return out
 }
 
 func (c *ListColumn[T]) SetTitle(title string) { c.title = title }
 
+// Invalidate drops the cached rows after state the renderer reads (other
+// than the items themselves) has changed.
+func (c *ListColumn[T]) Invalidate() { c.rowsValid = false }
+
+func (c *ListColumn[T]) Items() []T { return c.items }
+
 func (c *ListColumn[T]) SetWidth(w int) {
 	// w is the total width the app wants to allocate to the box.
 	// Subtract 4 for border (2) + padding (2) to get interior content width.
+	c.rowsValid = false
 	if w < 4 {
 		c.width = 0
 		return
@@ -138,6 +325,58 @@ func (c *ListColumn[T]) CursorDown() {
 	c.ensureSelectedVisible()
 }
 
+// listJump is a cursor move of more than one row.
+type listJump int
+
+const (
+	jumpPageUp listJump = iota
+	jumpPageDown
+	jumpTop
+	jumpBottom
+)
+
+// Jump moves the cursor a page up or down, scrolling the list with it, or to
+// the first or last item. Pages are counted in rows, separators included.
+func (c *ListColumn[T]) Jump(to listJump) {
+	if len(c.items) == 0 {
+		return
+	}
+	c.buildRows()
+	page := max(c.height-1, 1)
+	switch to {
+	case jumpPageUp:
+		target := c.rowOf[c.selected] - page
+		for c.selected > 0 && c.rowOf[c.selected-1] >= target {
+			c.selected--
+		}
+		c.scroll -= page
+	case jumpPageDown:
+		target := c.rowOf[c.selected] + page
+		for c.selected < len(c.items)-1 && c.rowOf[c.selected+1] <= target {
+			c.selected++
+		}
+		c.scroll += page
+	case jumpTop:
+		c.selected, c.scroll = 0, 0
+	case jumpBottom:
+		c.selected = len(c.items) - 1
+	}
+	c.ensureSelectedVisible()
+}
+
+// Select moves the cursor to the first item matching fn and reports whether
+// one was found.
+func (c *ListColumn[T]) Select(fn func(T) bool) bool {
+	for i, item := range c.items {
+		if fn(item) {
+			c.selected = i
+			c.ensureSelectedVisible()
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ListColumn[T]) Selected() (T, bool) {
 	var zero T
 	if len(c.items) == 0 {
@@ -152,8 +391,15 @@ type listRow[T any] struct {
 	itemIndex   int
 }
 
+// buildRows returns the rendered rows, separators included. They are cached
+// since cursor moves and every View need them and rendering every item is
+// noticeable once a column holds hundreds of entries.
 func (c *ListColumn[T]) buildRows() []listRow[T] {
+	if c.rowsValid {
+		return c.rows
+	}
 	rows := make([]listRow[T], 0, len(c.items))
+	rowOf := make([]int, len(c.items))
 	var prev T
 
 	for i, item := range c.items {
@@ -163,9 +409,14 @@ func (c *ListColumn[T]) buildRows() []listRow[T] {
 			}
 		}
 
+		rowOf[i] = len(rows)
 		rows = append(rows, listRow[T]{text: c.render(item), itemIndex: i})
 		prev = item
 	}
+	if c.footer != "" {
+		rows = append(rows, listRow[T]{text: c.footer, isSeparator: true, itemIndex: -1})
+	}
+	c.rows, c.rowOf, c.rowsValid = rows, rowOf, true
 	return rows
 }
 
@@ -195,14 +446,8 @@ func (c *ListColumn[T]) ensureSelectedVisible() {
 
 	rows := c.buildRows()
 	selRow := 0
-	for idx, row := range rows {
-		if row.isSeparator {
-			continue
-		}
-		if row.itemIndex == c.selected {
-			selRow = idx
-			break
-		}
+	if c.selected < len(c.rowOf) {
+		selRow = c.rowOf[c.selected]
 	}
 
 	if c.height <= 0 {
@@ -227,15 +472,23 @@ func (c *ListColumn[T]) View(styles Styles, focused bool) string {
 	}
 
 	titleText := fmt.Sprintf("%s (%d)", c.title, len(c.items))
+	if c.filter != "" {
+		titleText = fmt.Sprintf("%s (%d/%d) /%s", c.title, len(c.items), len(c.source), c.filter)
+	}
 	if focused {
-		titleText = fmt.Sprintf("▶ %s", titleText)
+		titleText = fmt.Sprintf("%s %s", styles.Glyphs.Focus, titleText)
 	}
-	head := styles.Title.Render(titleText)
-	meta := styles.Subtle.Render("Waiting for data…")
+	head := styles.Title.Render(styles.Text(titleText))
+	meta := styles.Subtle.Render(styles.Text("Waiting for data…"))
 	lines := []string{}
 
-	if len(c.items) == 0 {
+	if len(c.items) == 0 && c.filter != "" && len(c.source) > 0 {
+		lines = append(lines, "(no matches for filter)")
+	} else if len(c.items) == 0 {
 		lines = append(lines, "(no items)")
+		if c.footer != "" {
+			lines = append(lines, styles.Subtle.Render(styles.Text(c.footer)))
+		}
 	} else {
 		rows := c.buildRows()
 		c.clampScroll(len(rows))
@@ -248,19 +501,25 @@ func (c *ListColumn[T]) View(styles Styles, focused bool) string {
 
 		startItem, endItem := -1, -1
 
+		// The blank cursor matches the glyph's display width so selected and
+		// unselected rows stay aligned whatever glyph is configured.
+		activeCursor := styles.Glyphs.Cursor + " "
+		blankCursor := strings.Repeat(" ", lipgloss.Width(activeCursor))
+
 		for i := start; i < end; i++ {
 			row := rows[i]
-			cursor := "  "
-			lineText := row.text
+			cursor := blankCursor
+			lineText := styles.Text(row.text)
 
 			contentWidth := c.width - lipgloss.Width(cursor)
 
 			if row.isSeparator {
-				lineText = buildSeparatorLine(lineText, contentWidth)
+				lineText = buildSeparatorLine(lineText, contentWidth, styles.Glyphs.Separator)
 				lineText = styles.Subtle.Render(lineText)
 			} else {
-				if contentWidth > 1 && lipgloss.Width(lineText) > contentWidth {
-					lineText = fmt.Sprintf("%s…", truncateToWidth(lineText, contentWidth-1))
+				ellipsisWidth := lipgloss.Width(styles.Glyphs.Ellipsis)
+				if contentWidth > ellipsisWidth && lipgloss.Width(lineText) > contentWidth {
+					lineText = truncateToWidth(lineText, contentWidth-ellipsisWidth) + styles.Glyphs.Ellipsis
 				}
 
 				if startItem == -1 {
@@ -268,8 +527,12 @@ func (c *ListColumn[T]) View(styles Styles, focused bool) string {
 				}
 				endItem = row.itemIndex
 
+				if row.itemIndex != c.selected && c.decorator != nil {
+					lineText = c.decorator(c.items[row.itemIndex], lineText)
+				}
+
 				if row.itemIndex == c.selected {
-					cursor = "▸ "
+					cursor = activeCursor
 					lineText = lipgloss.NewStyle().
 						Foreground(lipgloss.Color("#FA8072")). // Not pink, its Salmon obviously
 						Bold(true).
@@ -288,7 +551,7 @@ func (c *ListColumn[T]) View(styles Styles, focused bool) string {
 			endItem = startItem
 		}
 
-		meta = styles.Subtle.Render(fmt.Sprintf("Showing %d–%d of %d", startItem+1, endItem+1, len(c.items)))
+		meta = styles.Subtle.Render(styles.Text(fmt.Sprintf("Showing %d–%d of %d", startItem+1, endItem+1, len(c.items))))
 	}
 
 	// Fill remaining lines if fewer than height
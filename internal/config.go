@@ -0,0 +1,489 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ────────────────────────────────
+// CONFIG FILE
+// ────────────────────────────────
+
+const configFileName = "config.toml"
+
+// fileConfig mirrors config.toml. Pointer fields distinguish "not set" from
+// zero values so only keys present in the file override the defaults.
+type fileConfig struct {
+	BaseURL        *string   `toml:"base_url"`
+	APITimeout     *duration `toml:"api_timeout"`
+	APIRetries     *int      `toml:"api_retries"`
+	ExtractTimeout *duration `toml:"extract_timeout"`
+	CaptureTimeout *duration `toml:"capture_timeout"`
+
+	SportsCacheTTL  *duration `toml:"cache_sports_ttl"`
+	MatchesCacheTTL *duration `toml:"cache_matches_ttl"`
+	ViewersCacheTTL *duration `toml:"cache_viewers_ttl"`
+	DiskCache       *bool     `toml:"cache_disk"`
+
+	Player           *string  `toml:"player"`
+	PlayerBackend    *string  `toml:"player_backend"`
+	StreamlinkPath   *string  `toml:"streamlink_path"`
+	PlayerCommand    *string  `toml:"player_command"`
+	Quality          *string  `toml:"quality"`
+	FallbackStreams  *int     `toml:"fallback_streams"`
+	FFmpegPath       *string  `toml:"ffmpeg_path"`
+	RecordDir        *string  `toml:"record_dir"`
+	DownloadDir      *string  `toml:"download_dir"`
+	Fullscreen       *bool    `toml:"fullscreen"`
+	FullscreenScreen *int     `toml:"fs_screen"`
+	KillPlayers      *bool    `toml:"kill_players_on_quit"`
+	MPVArgs          []string `toml:"mpv_args"`
+	MPVArgsFull      []string `toml:"mpv_args_fullscreen"`
+	MPVArgsWindowed  []string `toml:"mpv_args_windowed"`
+	DefaultSport     *string  `toml:"default_sport"`
+	Languages        []string `toml:"languages"`
+	StreamPreference []string `toml:"stream_preference"`
+
+	UpcomingWindow *duration `toml:"upcoming_window"`
+
+	Watchdog        *duration `toml:"watchdog"`
+	WatchdogRetries *int      `toml:"watchdog_retries"`
+
+	ForwardCookies  *bool               `toml:"forward_cookies"`
+	PlaybackHeaders map[string][]string `toml:"playback_headers"`
+
+	HeaderProfiles map[string]headerProfile `toml:"header_profiles"`
+
+	Providers []providerConfig `toml:"providers"`
+
+	Mirrors      []string `toml:"mirrors"`
+	ViewCountURL *string  `toml:"viewcount_url"`
+
+	Proxy *string `toml:"proxy"`
+
+	Extractors  []string `toml:"extractors"`
+	BrowserPath *string  `toml:"browser_path"`
+	RunnerPath  *string  `toml:"runner_path"`
+	Runtime     *string  `toml:"runtime"`
+	AdBlock     *bool    `toml:"adblock"`
+	LoadAssets  *bool    `toml:"load_assets"`
+	Probe       *bool    `toml:"probe"`
+
+	Images *string `toml:"images"`
+
+	DiscordClientID *string `toml:"discord_client_id"`
+	CastPort        *int    `toml:"cast_port"`
+
+	KickoffAlerts *bool    `toml:"kickoff_alerts"`
+	Webhooks      []string `toml:"webhooks"`
+
+	Hooks []hookConfig `toml:"hooks"`
+
+	LogFile  *string `toml:"log_file"`
+	LogLevel *string `toml:"log_level"`
+
+	ASCII         *bool `toml:"ascii"`
+	RelativeTimes *bool `toml:"relative_times"`
+	UpdateCheck   *bool `toml:"update_check"`
+	Debug         *bool `toml:"debug"`
+	SchemaCheck   *bool `toml:"schema_check"`
+	StrictDecoder *bool `toml:"strict"`
+}
+
+// duration decodes TOML strings such as "15s" or "1m30s".
+type duration struct{ time.Duration }
+
+func (d *duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// ConfigPath returns where config.toml is read from.
+func ConfigPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
+
+// LoadOptions returns DefaultOptions with config.toml applied on top. A
+// missing file is not an error. Command-line flags are meant to be applied to
+// the result, so they take precedence over the file; STREAMED_BASE still
+// overrides base_url.
+func LoadOptions() (Options, error) {
+	opts := DefaultOptions()
+	path, err := ConfigPath()
+	if err != nil {
+		return opts, nil
+	}
+	var cfg fileConfig
+	md, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, fs.ErrNotExist) {
+		return opts, nil
+	}
+	if err != nil {
+		return opts, fmt.Errorf("config %s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		sort.Strings(keys)
+		return opts, fmt.Errorf("config %s: unknown keys: %s", path, strings.Join(keys, ", "))
+	}
+	cfg.apply(&opts)
+	return opts, nil
+}
+
+func (c fileConfig) apply(o *Options) {
+	setString := func(dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+		}
+	}
+	setBool := func(dst *bool, src *bool) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setDuration := func(dst *time.Duration, src *duration) {
+		if src != nil {
+			*dst = src.Duration
+		}
+	}
+
+	if strings.TrimSpace(os.Getenv("STREAMED_BASE")) == "" {
+		setString(&o.BaseURL, c.BaseURL)
+	}
+	setDuration(&o.APITimeout, c.APITimeout)
+	if c.APIRetries != nil {
+		o.APIRetries = *c.APIRetries
+	}
+	setDuration(&o.ExtractTimeout, c.ExtractTimeout)
+	setDuration(&o.CaptureTimeout, c.CaptureTimeout)
+	setDuration(&o.CacheTTL.Sports, c.SportsCacheTTL)
+	setDuration(&o.CacheTTL.Matches, c.MatchesCacheTTL)
+	setDuration(&o.CacheTTL.Viewers, c.ViewersCacheTTL)
+	setBool(&o.DiskCache, c.DiskCache)
+
+	setString(&o.Player, c.Player)
+	setString(&o.PlayerBackend, c.PlayerBackend)
+	if c.FallbackStreams != nil {
+		o.FallbackStreams = *c.FallbackStreams
+	}
+	setString(&o.StreamlinkPath, c.StreamlinkPath)
+	setString(&o.PlayerCommand, c.PlayerCommand)
+	setString(&o.Quality, c.Quality)
+	setString(&o.FFmpegPath, c.FFmpegPath)
+	setString(&o.RecordDir, c.RecordDir)
+	setString(&o.DownloadDir, c.DownloadDir)
+	setBool(&o.Fullscreen, c.Fullscreen)
+	if c.FullscreenScreen != nil {
+		o.FullscreenScreen = *c.FullscreenScreen
+	}
+	setBool(&o.KillPlayersOnQuit, c.KillPlayers)
+	setDuration(&o.Watchdog, c.Watchdog)
+	if c.WatchdogRetries != nil {
+		o.WatchdogRetries = *c.WatchdogRetries
+	}
+	if c.MPVArgs != nil {
+		o.MPVArgs = c.MPVArgs
+	}
+	if c.MPVArgsFull != nil {
+		o.MPVArgsFullscreen = c.MPVArgsFull
+	}
+	if c.MPVArgsWindowed != nil {
+		o.MPVArgsWindowed = c.MPVArgsWindowed
+	}
+	setBool(&o.ForwardCookies, c.ForwardCookies)
+	if c.PlaybackHeaders != nil {
+		o.PlaybackHeaders = c.PlaybackHeaders
+	}
+	if c.HeaderProfiles != nil {
+		o.HeaderProfiles = c.HeaderProfiles
+	}
+	if c.Mirrors != nil {
+		o.Mirrors = c.Mirrors
+	}
+	setString(&o.ViewCountURL, c.ViewCountURL)
+	if c.Providers != nil {
+		o.Providers = c.Providers
+	}
+	setString(&o.DefaultSport, c.DefaultSport)
+	setDuration(&o.UpcomingWindow, c.UpcomingWindow)
+	if c.Languages != nil {
+		o.Languages = c.Languages
+	}
+	if c.StreamPreference != nil {
+		o.StreamPreference = c.StreamPreference
+	}
+
+	if strings.TrimSpace(os.Getenv(proxyEnv)) == "" {
+		setString(&o.Proxy, c.Proxy)
+	}
+
+	if c.Extractors != nil {
+		o.Extractors = c.Extractors
+	}
+	setString(&o.BrowserPath, c.BrowserPath)
+	setString(&o.RunnerPath, c.RunnerPath)
+	setString(&o.Runtime, c.Runtime)
+	if c.AdBlock != nil {
+		o.NoAdBlock = !*c.AdBlock
+	}
+	if c.Probe != nil {
+		o.NoProbe = !*c.Probe
+	}
+	setBool(&o.LoadAssets, c.LoadAssets)
+
+	setString(&o.Images, c.Images)
+	setString(&o.DiscordClientID, c.DiscordClientID)
+	if c.CastPort != nil {
+		o.CastPort = *c.CastPort
+	}
+	if c.KickoffAlerts != nil {
+		o.NoKickoffAlerts = !*c.KickoffAlerts
+	}
+	if c.Webhooks != nil {
+		o.Webhooks = c.Webhooks
+	}
+	if c.Hooks != nil {
+		o.Hooks = c.Hooks
+	}
+	setString(&o.LogFile, c.LogFile)
+	setString(&o.LogLevel, c.LogLevel)
+	setBool(&o.ASCII, c.ASCII)
+	setBool(&o.RelativeTimes, c.RelativeTimes)
+	if c.UpdateCheck != nil {
+		o.NoUpdateCheck = !*c.UpdateCheck
+	}
+	setBool(&o.Debug, c.Debug)
+	setBool(&o.SchemaCheck, c.SchemaCheck)
+	setBool(&o.Strict, c.StrictDecoder)
+}
+
+// sampleConfig is written by `config init`. Every key is commented out so the
+// file documents the defaults without changing them.
+const sampleConfig = `# streamed-tui configuration. Command-line flags override these values.
+
+# API host; STREAMED_BASE overrides it.
+# base_url = "https://streamed.pk"
+
+# Mirrors of the API, checked at startup and failed over to in order when
+# the API host times out or answers with server errors.
+# mirrors = ["https://streamed.su"]
+
+# Where viewer numbers of popular matches come from; by default the API
+# host's /api/matches/live/popular-viewcount. Popular matches still load,
+# without numbers, when it fails.
+# viewcount_url = "https://streami.su/api/matches/live/popular-viewcount"
+
+# Timeouts for API requests, loading the embed page, and waiting for the
+# .m3u8 request.
+# api_timeout = "15s"
+# extract_timeout = "45s"
+# capture_timeout = "20s"
+
+# How often a failed API request (timeout, reset, 5xx) is retried, with
+# exponential backoff.
+# api_retries = 2
+
+# How long sports, match lists, and view counts are reused before being
+# fetched again ("0s" disables caching), and whether the cache is also kept
+# on disk between runs. r (Refresh) always fetches fresh data.
+# cache_sports_ttl = "1h"
+# cache_matches_ttl = "1m"
+# cache_viewers_ttl = "30s"
+# cache_disk = false
+
+# mpv-compatible player binary, and whether to start it fullscreen.
+# player_backend = "streamlink" fetches the stream with streamlink (header
+# injection, segment retries) and pipes it into the player.
+# player = "mpv"
+# player_backend = "mpv"
+# streamlink_path = "streamlink"
+# fullscreen = false
+# fs_screen = 0
+# Stop the players started from the TUI when it quits (Shift+P lists them).
+# kill_players_on_quit = false
+
+# Show the match being watched, and for how long, as your Discord activity
+# while a player launched from the TUI runs. Create an application in the
+# Discord developer portal and put its application ID here; its name is what
+# Discord shows you playing.
+# discord_client_id = "123456789012345678"
+
+# Casting (Shift+K) serves the stream to the Chromecast or DLNA device from
+# this machine, adding the captured headers. Pin the port to open it in a
+# firewall; 0 picks a free one.
+# cast_port = 0
+
+# Watchdog: when a player exits with an error within this long of starting,
+# extract the stream again (then the next streams) and relaunch it, up to
+# watchdog_retries times per match. "0s" turns it off.
+# watchdog = "0s"
+# watchdog_retries = 3
+
+# Extra mpv arguments for every launch, and for fullscreen (Shift+F) or
+# windowed launches only. With streamlink they are passed as --player-args.
+# mpv_args = ["--profile=low-latency", "--cache=no"]
+# mpv_args_fullscreen = ["--ontop"]
+# mpv_args_windowed = ["--autofit=50%"]
+
+# The player gets the captured User-Agent, Origin, and Referer. Also send the
+# embed page's cookies, for hosts that check the session. Per-domain rules
+# are set in [playback_headers] at the end of this file.
+# forward_cookies = false
+
+# Custom player command, used instead of the settings above. Placeholders:
+# {url}, {user_agent}, {referer}, {origin}, {cookie}, {title}, {proxy}.
+# Arguments whose placeholders are all empty are dropped.
+# player_command = "vlc --http-user-agent={user_agent} --http-referrer={referer} {url}"
+
+# Quality when the stream offers several: "ask" (picker), "auto" (the player
+# chooses), "best", or a maximum height such as "720p".
+# quality = "ask"
+
+# When extracting a stream fails, how many of the streams listed after it are
+# tried in turn (0 only tries the chosen stream). Browser-only streams are
+# skipped.
+# fallback_streams = 3
+
+# Recording (Shift+R): the ffmpeg binary and where recordings are saved
+# (default: "recordings" in the data directory).
+# ffmpeg_path = "ffmpeg"
+# record_dir = "~/Videos/streamed-tui"
+
+# Downloads (d) are handed to yt-dlp and saved here (default: "downloads" in
+# the data directory).
+# download_dir = "~/Downloads/streamed-tui"
+
+# Sport whose matches are shown on startup instead of Popular.
+# default_sport = "football"
+
+# How soon matches must start to be shown by the upcoming filter (u).
+# upcoming_window = "6h"
+
+# Preferred stream languages for automatic picks, most preferred first.
+# languages = ["English", "Spanish"]
+
+# Stream ranking in the Streams column and for automatic picks, most
+# preferred first: a language, "HD" or "SD", or both.
+# stream_preference = ["English HD", "English", "HD"]
+
+# HTTP or SOCKS5 proxy for API requests, extraction, and playback;
+# STREAMED_PROXY overrides it. mpv and ffmpeg only support HTTP proxies.
+# proxy = "socks5://127.0.0.1:1080"
+
+# Extractor backends, tried in order until one finds the stream: "regex"
+# (plain HTTP, no browser), "chromedp" (local Chrome/Chromium), "puppeteer"
+# (Node.js runner), "yt-dlp".
+# extractors = ["regex", "chromedp", "puppeteer"]
+# browser_path = "/usr/bin/chromium"
+
+# Puppeteer runner: custom script and JavaScript runtime (node, bun, deno).
+# runner_path = "/path/to/runner.js"
+# runtime = "node"
+
+# Request blocking, for both backends.
+# adblock = true
+# load_assets = false
+
+# Check that the extracted playlist answers before starting the player.
+# probe = true
+
+# Match posters and team badges in the details column: "auto" detects the
+# terminal, or "kitty", "iterm2", "sixel", "off".
+# images = "auto"
+
+# Notify when a starred match or a starred team's match kicks off while the
+# TUI runs: over D-Bus, with notify-send, or else with the terminal bell.
+# kickoff_alerts = true
+
+# URLs "streamed-tui notify-daemon" POSTs each reminder and kickoff to as
+# JSON, with the message in "text" and "content" for chat webhooks.
+# webhooks = ["https://hooks.example.com/streamed"]
+
+# Show kickoff times relative to now ("in 45m", "LIVE", "2h ago") instead of
+# the date and time. Ctrl+T toggles them while running.
+# relative_times = false
+
+# Log file for the debug pane's lines (default: debug.log in the state
+# directory), rotated at 5 MiB with three old files kept. Only lines at
+# log_level or above are written: "debug", "info", "warn", "error", or "off"
+# (default "info", or "debug" with --debug).
+# log_file = "~/streamed-tui.log"
+# log_level = "info"
+
+# ascii = false
+# update_check = true
+# debug = false
+# schema_check = false
+# strict = false
+
+# Captured headers forwarded to the player for playlists on a domain (and its
+# subdomains), instead of User-Agent, Origin, and Referer. "*" forwards every
+# captured header except connection-level ones.
+# [playback_headers]
+# "cdn.example.com" = ["User-Agent", "Referer", "Cookie"]
+# "other.example.net" = ["*"]
+
+# Header profiles replace the Origin, Referer, and User-Agent captured for
+# streams embedded from a domain (and its subdomains). Placeholders: {embed},
+# {embed_origin}, {playlist_origin}, and the captured {user_agent},
+# {referer}, and {origin}. Fields left out keep the captured value.
+# [header_profiles."embed.example.com"]
+# origin = "{embed_origin}"
+# referer = "{embed_origin}/"
+# user_agent = "{user_agent}"
+
+# More providers whose matches are listed next to the streamed API's, tagged
+# with their name. "streamed" (the default kind) is any API compatible with
+# streamed.pk's; a match already listed by an earlier provider is skipped.
+# [[providers]]
+# name = "mirror"
+# kind = "streamed"
+# base_url = "https://mirror.example"
+
+# Hooks run a shell command, POST to a URL, or both when something happens:
+# "stream_extracted", "player_launched", "match_started" (a starred match or
+# team kicks off), or "reminder". Leave out events to run on all of them.
+# The event is sent as JSON (on stdin for commands) and in STREAMED_EVENT,
+# STREAMED_MATCH_ID, STREAMED_TITLE, STREAMED_URL, and so on.
+# [[hooks]]
+# events = ["player_launched"]
+# command = "notify-send \"Watching $STREAMED_TITLE\""
+#
+# [[hooks]]
+# events = ["match_started", "reminder"]
+# url = "https://discord.com/api/webhooks/..."
+`
+
+// initConfig writes sampleConfig unless a config file already exists.
+func initConfig(force bool) (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil && !force {
+		return path, fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return path, err
+	}
+	return path, os.WriteFile(path, []byte(sampleConfig), 0o644)
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// crashTrailSize bounds how many recent debug lines are kept for crash reports.
+const crashTrailSize = 50
+
+// recoveredPanicsKept bounds how many panics recovered by safeCmd are kept
+// for crash reports.
+const recoveredPanicsKept = 5
+
+// crashTrail keeps the most recent debug lines outside of the (copied) Model so
+// a crash report can include them even when the panic unwinds past Update.
+// Panics recovered in commands are kept apart with their stacks, so a long
+// stack does not push the debug lines out.
+type crashTrail struct {
+	mu        sync.Mutex
+	lines     []string
+	recovered []recoveredPanic
+}
+
+// recoveredPanic is a panic safeCmd turned into an error.
+type recoveredPanic struct {
+	at    time.Time
+	name  string
+	value any
+	stack []byte
+}
+
+func newCrashTrail() *crashTrail {
+	return &crashTrail{}
+}
+
+func (t *crashTrail) Add(line string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, line)
+	if len(t.lines) > crashTrailSize {
+		t.lines = t.lines[len(t.lines)-crashTrailSize:]
+	}
+}
+
+func (t *crashTrail) Lines() []string {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.lines...)
+}
+
+// AddRecovered records a panic recovered in a command.
+func (t *crashTrail) AddRecovered(p recoveredPanic) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recovered = append(t.recovered, p)
+	if len(t.recovered) > recoveredPanicsKept {
+		t.recovered = t.recovered[len(t.recovered)-recoveredPanicsKept:]
+	}
+}
+
+// Recovered returns the panics recovered in commands, oldest first.
+func (t *crashTrail) Recovered() []recoveredPanic {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]recoveredPanic(nil), t.recovered...)
+}
+
+// recoverCrash must be deferred directly around tea.Program.Run. It restores the
+// terminal from the alt screen, writes a crash report containing the panic
+// value, stack trace, and recent debug lines, and prints the report path.
+func recoverCrash(p *tea.Program, trail *crashTrail, errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	stack := debug.Stack()
+	if p != nil {
+		_ = p.ReleaseTerminal()
+	}
+
+	path, err := writeCrashReport(r, stack, trail.Lines(), trail.Recovered())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "streamed-tui crashed: %v\n\n%s\n", r, stack)
+		fmt.Fprintf(os.Stderr, "failed to write crash report: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "streamed-tui crashed: %v\n", r)
+		fmt.Fprintf(os.Stderr, "crash report written to %s\n", path)
+	}
+
+	if errp != nil {
+		*errp = fmt.Errorf("panic: %v", r)
+	}
+}
+
+// writeCrashReport stores a crash report under the state directory and
+// returns its path.
+func writeCrashReport(r any, stack []byte, lines []string, recovered []recoveredPanic) (string, error) {
+	dir, err := ensureAppDir(stateDir, "crashes")
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "streamed-tui crash report\n")
+	fmt.Fprintf(&sb, "time: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "panic: %v\n\n", r)
+	sb.WriteString("stack trace:\n")
+	sb.Write(stack)
+	sb.WriteString("\nrecent debug lines:\n")
+	if len(lines) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+	for _, p := range recovered {
+		fmt.Fprintf(&sb, "\nrecovered panic in %s at %s: %v\n", p.name, p.at.Format(time.RFC3339), p.value)
+		sb.Write(p.stack)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s.log", now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// safeCmd wraps a tea.Cmd so a panic inside it is converted into an errorMsg
+// shown in the UI instead of taking down the program. Commands run on their own
+// goroutines, so recoverCrash cannot catch these panics.
+func safeCmd(name string, trail *crashTrail, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				trail.Add(fmt.Sprintf("[panic] %s: %v", name, r))
+				trail.AddRecovered(recoveredPanic{at: time.Now(), name: name, value: r, stack: debug.Stack()})
+				msg = errorMsg(fmt.Errorf("internal error in %s: %v", name, r))
+			}
+		}()
+		return cmd()
+	}
+}
@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ────────────────────────────────
+// NOTIFY DAEMON
+// ────────────────────────────────
+
+// defaultDaemonInterval is how often the daemon checks reminders and
+// favorites.
+const defaultDaemonInterval = time.Minute
+
+// notifyDaemon fires reminders and favorite kickoff alerts without the TUI.
+type notifyDaemon struct {
+	provider Provider
+	notifier *desktopNotifier
+	desktop  bool
+	hooks    *hookRunner
+	since    time.Time
+	notified map[string]bool
+}
+
+// RunNotifyDaemon handles "streamed-tui notify-daemon": it checks the
+// reminders and the favorites on an interval until interrupted, and
+// announces due reminders and favorites that kick off as desktop
+// notifications, webhook POSTs, or both.
+func RunNotifyDaemon(args []string, opts Options) error {
+	fs := flag.NewFlagSet("notify-daemon", flag.ContinueOnError)
+	interval := fs.Duration("interval", defaultDaemonInterval, "how often reminders and favorites are checked")
+	noDesktop := fs.Bool("no-desktop", false, "only run the webhooks and hooks, without desktop notifications")
+	once := fs.Bool("once", false, "check once and exit")
+	demo := fs.Bool("demo", opts.Demo, "watch the bundled demo fixtures")
+	fs.Func("webhook", "URL to POST each event to as JSON (repeatable; adds to webhooks in the config)", func(v string) error {
+		opts.Webhooks = append(opts.Webhooks, v)
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: streamed-tui notify-daemon [--interval 1m] [--webhook URL]... [--no-desktop] [--once]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	if *noDesktop && len(opts.Webhooks) == 0 && len(opts.Hooks) == 0 {
+		return errors.New("--no-desktop needs at least one webhook or hook")
+	}
+	opts = opts.withDefaults()
+	opts.Demo = *demo
+	// Webhooks hear about every event, like hooks without events.
+	for _, url := range opts.Webhooks {
+		opts.Hooks = append(opts.Hooks, hookConfig{URL: url})
+	}
+	hooks, err := opts.hookRunner(func(line string) { log.Print("notify-daemon: ", line) })
+	if err != nil {
+		return err
+	}
+	if err := opts.checkProxy(); err != nil {
+		return err
+	}
+	provider, err := cliProvider(opts)
+	if err != nil {
+		return err
+	}
+
+	d := &notifyDaemon{
+		provider: provider,
+		desktop:  !*noDesktop,
+		hooks:    hooks,
+		since:    time.Now(),
+		notified: map[string]bool{},
+	}
+	if d.desktop {
+		d.notifier = newDesktopNotifier(nil)
+		defer d.notifier.Close()
+	}
+	if *once {
+		// A single check has no earlier one to compare with, so every
+		// favorite that is live now counts.
+		d.since = time.Time{}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	log.Printf("notify-daemon: checking every %s (%d hooks, desktop notifications %s)",
+		*interval, len(opts.Hooks), map[bool]string{true: "on", false: "off"}[d.desktop])
+	for {
+		d.check(ctx)
+		d.hooks.Wait()
+		if *once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			log.Println("notify-daemon: stopped")
+			return nil
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// check fires the reminders that came due and announces favorites that
+// kicked off since the last check. The stores are read again each time so
+// changes made in the TUI are picked up.
+func (d *notifyDaemon) check(ctx context.Context) {
+	now := time.Now()
+	if store, err := openScheduleStore(); err != nil {
+		log.Printf("notify-daemon: reminders: %v", err)
+	} else if due, err := store.TakeDueOf(now, jobReminder); err != nil {
+		log.Printf("notify-daemon: save reminders: %v", err)
+	} else {
+		// Auto-launch jobs and recordings stay armed for the TUI.
+		for _, job := range due {
+			d.announce(hookReminder, job.match(), reminderNotification(job, now))
+		}
+	}
+
+	favs, err := openFavoritesStore()
+	if err != nil {
+		log.Printf("notify-daemon: favorites: %v", err)
+		return
+	}
+	if favs.Empty() {
+		return
+	}
+	matches, err := d.provider.ListMatches(ctx, allSportID)
+	if err != nil {
+		log.Printf("notify-daemon: list matches: %v", err)
+		return
+	}
+	for _, mt := range favoritesKickedOff(matches, favs, d.since, now) {
+		if d.notified[mt.ID] {
+			continue
+		}
+		d.notified[mt.ID] = true
+		d.announce(hookMatchStarted, mt, kickoffNotification(mt, favs))
+	}
+}
+
+// announce logs an event and sends it to the desktop and the hooks.
+func (d *notifyDaemon) announce(event string, mt Match, note notification) {
+	message := note.Summary + " – " + note.Body
+	log.Printf("notify-daemon: %s: %s", event, message)
+	if d.desktop {
+		// Nobody is listening for actions, so the buttons are left off.
+		note.Actions = nil
+		if err := notifyDesktop(d.notifier, note); err != nil {
+			log.Printf("notify-daemon: desktop notification: %v", err)
+		}
+	}
+	d.hooks.Fire(newHookEvent(event, mt, Stream{}, message))
+}
@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// uiLogger delivers log lines produced on background goroutines to the debug
+// pane by sending debugLogMsg values to the running program. Lines logged
+// before a program is attached are dropped. Log must not be called from
+// inside Update, as Send blocks until the event loop receives the message.
+type uiLogger struct {
+	mu sync.Mutex
+	p  *tea.Program
+}
+
+func (l *uiLogger) attach(p *tea.Program) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	l.p = p
+	l.mu.Unlock()
+}
+
+func (l *uiLogger) Log(line string) {
+	l.Send(debugLogMsg(line))
+}
+
+// Send delivers an arbitrary message to the attached program.
+func (l *uiLogger) Send(msg tea.Msg) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	p := l.p
+	l.mu.Unlock()
+	if p != nil {
+		p.Send(msg)
+	}
+}
+
+// ────────────────────────────────
+// LOG FILE
+// ────────────────────────────────
+
+// logLevel ranks debug lines for the log file.
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+	levelOff
+)
+
+var logLevelNames = [...]string{"debug", "info", "warn", "error", "off"}
+
+func (l logLevel) String() string { return logLevelNames[l] }
+
+const (
+	// maxLogFileBytes is the size at which the log file is rotated.
+	maxLogFileBytes = 5 << 20
+	// logFileBackups is how many rotated files (debug.log.1, …) are kept.
+	logFileBackups = 3
+)
+
+// parseLogLevel reads a level name; empty means info.
+func parseLogLevel(name string) (logLevel, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return levelInfo, nil
+	}
+	for i, n := range logLevelNames {
+		if n == name {
+			return logLevel(i), nil
+		}
+	}
+	return levelInfo, fmt.Errorf("unknown log level %q (want debug, info, warn, error, or off)", name)
+}
+
+// checkLogLevel rejects log levels that are not understood.
+func (o Options) checkLogLevel() error {
+	_, err := parseLogLevel(o.LogLevel)
+	return err
+}
+
+// fileLogLevel is the level lines must reach to be written to the log file:
+// the configured one, or debug with --debug when none is configured.
+func (o Options) fileLogLevel() logLevel {
+	if strings.TrimSpace(o.LogLevel) == "" && o.Debug {
+		return levelDebug
+	}
+	level, _ := parseLogLevel(o.LogLevel)
+	return level
+}
+
+// lineLevel guesses the level of a debug line. Lines are plain text from all
+// over the app, so failures are recognised by their wording and request
+// traces and browser chatter by their prefix.
+func lineLevel(line string) logLevel {
+	lower := strings.ToLower(line)
+	switch {
+	case strings.Contains(lower, "retrying"), strings.Contains(lower, "warning"), strings.Contains(line, "⚠"):
+		return levelWarn
+	case strings.Contains(line, "❌"), strings.HasPrefix(line, "[panic]"),
+		strings.Contains(lower, "error"), strings.Contains(lower, "failed"):
+		return levelError
+	case strings.Contains(lower, "unavailable"), strings.Contains(lower, "cannot"), strings.Contains(lower, "not persisted"):
+		return levelWarn
+	}
+	for _, prefix := range []string{"[http]", "[schema]", "[demo]", "[chromedp]", "[puppeteer", "[regex]", "[prefetch]", "[art]", "[dedupe]", "[discord]"} {
+		if strings.HasPrefix(line, prefix) {
+			return levelDebug
+		}
+	}
+	return levelInfo
+}
+
+// debugFile mirrors debug pane lines at or above its level to a file so they
+// survive after the TUI exits. The file is rotated once it grows past
+// maxLogFileBytes.
+type debugFile struct {
+	mu    sync.Mutex
+	f     *os.File
+	size  int64
+	path  string
+	level logLevel
+}
+
+// openDebugFile opens (appending) the log file at path, or debug.log under
+// the state directory when path is empty.
+func openDebugFile(path string, level logLevel) (*debugFile, error) {
+	if path == "" {
+		dir, err := ensureAppDir(stateDir)
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, "debug.log")
+	} else {
+		path = expandHome(path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	d := &debugFile{path: path, level: level}
+	if err := d.open(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *debugFile) open() error {
+	f, err := os.OpenFile(d.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	d.f, d.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts debug.log to debug.log.1, and older backups along, dropping
+// the oldest, then starts a new file.
+func (d *debugFile) rotate() error {
+	d.f.Close()
+	for i := logFileBackups - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", d.path, i), fmt.Sprintf("%s.%d", d.path, i+1))
+	}
+	if err := os.Rename(d.path, d.path+".1"); err != nil {
+		return err
+	}
+	return d.open()
+}
+
+func (d *debugFile) Write(line string) {
+	if d == nil {
+		return
+	}
+	level := lineLevel(line)
+	if level < d.level {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.f == nil {
+		return
+	}
+	entry := fmt.Sprintf("%s %-5s %s\n", time.Now().Format("2006-01-02 15:04:05.000"), strings.ToUpper(level.String()), line)
+	if d.size > 0 && d.size+int64(len(entry)) > maxLogFileBytes {
+		// When rotating fails, keep appending to the old file rather than
+		// losing lines.
+		if err := d.rotate(); err != nil && d.open() != nil {
+			d.f = nil
+			return
+		}
+	}
+	n, _ := d.f.WriteString(entry)
+	d.size += int64(n)
+}
+
+func (d *debugFile) Close() error {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.f == nil {
+		return nil
+	}
+	return d.f.Close()
+}
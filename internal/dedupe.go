@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ────────────────────────────────
+// DUPLICATE MATCHES
+// ────────────────────────────────
+
+// duplicateWindow is how far apart two listings of the same fixture may
+// start; sources disagree by a few minutes.
+const duplicateWindow = 30 * time.Minute
+
+// fixtureKey identifies the fixture a match is: its two teams in either
+// order, or its title when the teams are missing. Case, spacing, and
+// punctuation are ignored, since that is where listings of one fixture
+// differ most.
+func fixtureKey(mt Match) string {
+	if teams := matchTeams(mt); len(teams) == 2 {
+		a, b := normalizeFixtureName(teams[0]), normalizeFixtureName(teams[1])
+		if a > b {
+			a, b = b, a
+		}
+		return "teams:" + a + "|" + b
+	}
+	if title := normalizeFixtureName(mt.Title); title != "" {
+		return "title:" + normalizeFixtureName(mt.Category) + "|" + title
+	}
+	return ""
+}
+
+// normalizeFixtureName keeps only the letters and digits of s, lowercased.
+func normalizeFixtureName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// sameKickoff reports whether two listings start close enough to be the
+// same fixture. Listings without a start time only match each other.
+func sameKickoff(a, b Match) bool {
+	if a.Date <= 0 || b.Date <= 0 {
+		return a.Date <= 0 && b.Date <= 0
+	}
+	d := time.Duration(a.Date-b.Date) * time.Millisecond
+	return d.Abs() <= duplicateWindow
+}
+
+// mergeDuplicateMatches folds listings of the same fixture into the first
+// one, which gains the others' sources and viewers, so one row offers every
+// stream. It returns the merged list and how many listings were folded.
+func mergeDuplicateMatches(matches []Match) ([]Match, int) {
+	out := make([]Match, 0, len(matches))
+	byKey := map[string][]int{}
+	merged := 0
+	for _, mt := range matches {
+		key := fixtureKey(mt)
+		i := -1
+		if key != "" {
+			for _, j := range byKey[key] {
+				if sameKickoff(out[j], mt) {
+					i = j
+					break
+				}
+			}
+		}
+		if i < 0 {
+			mt.Sources = slices.Clone(mt.Sources)
+			out = append(out, mt)
+			if key != "" {
+				byKey[key] = append(byKey[key], len(out)-1)
+			}
+			continue
+		}
+		out[i].absorb(mt)
+		merged++
+	}
+	return out, merged
+}
+
+// absorb adds a duplicate listing's sources, viewers, and missing details to
+// mt.
+func (mt *Match) absorb(dup Match) {
+	for _, src := range dup.Sources {
+		if !slices.Contains(mt.Sources, src) {
+			mt.Sources = append(mt.Sources, src)
+		}
+	}
+	mt.Viewers += dup.Viewers
+	mt.Popular = mt.Popular || dup.Popular
+	if mt.Poster == "" {
+		mt.Poster = dup.Poster
+	}
+	if mt.Category == "" {
+		mt.Category = dup.Category
+	}
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// demoBaseURL is the API base used in --demo mode. Requests never leave the
+// process; demoTransport answers them from fixtures.
+const demoBaseURL = "https://demo.invalid"
+
+// demoStreamURL is a public HLS test stream handed to the player by the demo
+// extractor so screencasts show real playback.
+const demoStreamURL = "https://test-streams.mux.dev/x36xhzz/x36xhzz.m3u8"
+
+// newDemoClient returns a Client backed by bundled fixture data.
+func newDemoClient(timeout time.Duration) *Client {
+	c := NewClient(demoBaseURL, timeout)
+	c.http.Transport = demoTransport{now: time.Now()}
+	return c
+}
+
+// demoTransport serves the streamed API endpoints from fixtures built relative
+// to now, so matches always appear live or upcoming.
+type demoTransport struct {
+	now time.Time
+}
+
+func (t demoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.Trim(req.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	var payload any
+	switch {
+	case path == "api/sports":
+		payload = demoSports
+	case path == "api/matches/live/popular-viewcount":
+		payload = t.viewCounts()
+	case path == "api/matches/all/popular":
+		payload = t.popular()
+	case len(parts) == 3 && parts[0] == "api" && parts[1] == "matches":
+		payload = t.matchesFor(parts[2])
+	case len(parts) == 4 && parts[0] == "api" && parts[1] == "stream":
+		payload = demoStreams(parts[2], parts[3])
+	default:
+		return demoResponse(req, http.StatusNotFound, []byte(`{"error":"not found"}`)), nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return demoResponse(req, http.StatusOK, data), nil
+}
+
+func demoResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+var demoSports = []Sport{
+	{ID: "football", Name: "Football"},
+	{ID: "basketball", Name: "Basketball"},
+	{ID: "tennis", Name: "Tennis"},
+	{ID: "motor-sports", Name: "Motor Sports"},
+}
+
+type demoFixture struct {
+	sport    string
+	category string
+	home     string
+	away     string
+	title    string
+	offset   time.Duration
+	popular  bool
+	viewers  int
+	sources  int
+}
+
+var demoFixtures = []demoFixture{
+	{sport: "football", category: "Premier League", home: "Arsenal", away: "Chelsea", offset: -40 * time.Minute, popular: true, viewers: 18400, sources: 3},
+	{sport: "football", category: "La Liga", home: "Real Madrid", away: "Sevilla", offset: 35 * time.Minute, popular: true, viewers: 9200, sources: 2},
+	{sport: "football", category: "Serie A", home: "Inter", away: "Napoli", offset: 26 * time.Hour, sources: 2},
+	{sport: "football", category: "Bundesliga", home: "Dortmund", away: "Leipzig", offset: 50 * time.Hour},
+	{sport: "basketball", category: "NBA", home: "Lakers", away: "Celtics", offset: -15 * time.Minute, popular: true, viewers: 23100, sources: 3},
+	{sport: "basketball", category: "EuroLeague", home: "Olympiacos", away: "Real Madrid", offset: 5 * time.Hour, sources: 1},
+	{sport: "tennis", category: "ATP", title: "Alcaraz vs Sinner – Final", offset: 2 * time.Hour, popular: true, viewers: 4100, sources: 2},
+	{sport: "motor-sports", category: "Formula 1", title: "Grand Prix – Race", offset: 28 * time.Hour, sources: 2},
+}
+
+func (t demoTransport) match(i int, f demoFixture) Match {
+	id := fmt.Sprintf("demo-%s-%d", f.sport, i)
+	mt := Match{
+		ID:       id,
+		Title:    f.title,
+		Category: f.category,
+		Date:     t.now.Add(f.offset).UnixMilli(),
+		Popular:  f.popular,
+	}
+	if f.home != "" {
+		mt.Title = fmt.Sprintf("%s vs %s", f.home, f.away)
+		mt.Teams = &Teams{Home: &Team{Name: f.home}, Away: &Team{Name: f.away}}
+	}
+	sources := []string{"alpha", "bravo", "admin"}
+	for s := 0; s < f.sources && s < len(sources); s++ {
+		mt.Sources = append(mt.Sources, MatchSource{Source: sources[s], ID: id})
+	}
+	return mt
+}
+
+func (t demoTransport) matchesFor(sport string) []Match {
+	out := []Match{}
+	for i, f := range demoFixtures {
+		if f.sport == sport || sport == "all" {
+			out = append(out, t.match(i, f))
+		}
+	}
+	return out
+}
+
+func (t demoTransport) popular() []Match {
+	out := []Match{}
+	for i, f := range demoFixtures {
+		if f.popular {
+			out = append(out, t.match(i, f))
+		}
+	}
+	return out
+}
+
+func (t demoTransport) viewCounts() []map[string]any {
+	out := []map[string]any{}
+	for i, f := range demoFixtures {
+		if f.viewers > 0 {
+			out = append(out, map[string]any{"id": t.match(i, f).ID, "viewers": f.viewers, "sources": []any{}})
+		}
+	}
+	return out
+}
+
+func demoStreams(source, id string) []Stream {
+	languages := []string{"English", "Spanish", "French"}
+	count := 3
+	if source == "admin" {
+		count = 1
+	}
+	out := make([]Stream, 0, count)
+	for i := 0; i < count; i++ {
+		out = append(out, Stream{
+			ID:       id,
+			StreamNo: i + 1,
+			Language: languages[i%len(languages)],
+			HD:       i%2 == 0,
+			EmbedURL: fmt.Sprintf("https://demo.invalid/embed/%s/%s/%d", source, id, i+1),
+			Source:   source,
+			Viewers:  (len(id)*137 + i*911) % 2500,
+		})
+	}
+	return out
+}
+
+// demoExtract stands in for the Puppeteer runner in --demo mode. It logs the
+// same kind of progress lines and returns a public test stream.
+func demoExtract(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error) {
+	if log == nil {
+		log = func(string) {}
+	}
+	start := time.Now()
+	steps := []struct{ phase, line string }{
+		{"locating node modules", "[demo] launching fake chromium for " + embedURL},
+		{"loading embed page in chromium", "[demo] navigating to embed page"},
+		{"waiting for .m3u8", "[demo] captured .m3u8 (contains #EXTINF segments)"},
+	}
+	for _, step := range steps {
+		opts.phase(step.phase)
+		select {
+		case <-ctx.Done():
+			return extractResult{}, ctx.Err()
+		case <-time.After(400 * time.Millisecond):
+		}
+		log(step.line)
+	}
+	hdrs := map[string]string{
+		"user-agent": "Mozilla/5.0 (X11; Linux x86_64) streamed-tui demo",
+		"referer":    embedURL,
+	}
+	return extractResult{URL: demoStreamURL, Headers: hdrs, Backend: "demo", Elapsed: time.Since(start)}, nil
+}
@@ -20,8 +20,9 @@ var embeddedNodeModules []byte
 
 // ensureEmbeddedNodeModules extracts the bundled Node.js dependencies into a
 // deterministic cache directory derived from the archive hash and returns the
-// path that contains the resulting node_modules directory.
-func ensureEmbeddedNodeModules() (string, error) {
+// path that contains the resulting node_modules directory. phase, when non-nil,
+// is told when the (slow, first-run only) unpacking starts.
+func ensureEmbeddedNodeModules(phase func(string)) (string, error) {
 	if len(embeddedNodeModules) == 0 {
 		return "", errors.New("no embedded node modules archive available")
 	}
@@ -29,17 +30,20 @@ func ensureEmbeddedNodeModules() (string, error) {
 	sum := sha256.Sum256(embeddedNodeModules)
 	hashPrefix := hex.EncodeToString(sum[:8])
 
-	cacheRoot, err := os.UserCacheDir()
+	cacheRoot, err := cacheDir()
 	if err != nil {
-		cacheRoot = os.TempDir()
+		cacheRoot = filepath.Join(os.TempDir(), appName)
 	}
-	baseDir := filepath.Join(cacheRoot, "streamed-tui", "node_modules", hashPrefix)
+	baseDir := filepath.Join(cacheRoot, "node_modules", hashPrefix)
 
 	marker := filepath.Join(baseDir, ".complete")
 	if _, err := os.Stat(marker); err == nil {
 		return baseDir, nil
 	}
 
+	if phase != nil {
+		phase("unpacking bundled node modules (first run)")
+	}
 	if err := os.RemoveAll(baseDir); err != nil {
 		return "", fmt.Errorf("failed to clear embedded node cache: %w", err)
 	}
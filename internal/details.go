@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// MATCH DETAILS
+// ────────────────────────────────
+
+// detailsKickoffLayout formats kickoff times in the details column.
+const detailsKickoffLayout = "Mon Jan 2 2006 15:04 MST"
+
+// toggleDetails shows or hides the details column and lays the columns out
+// again.
+func (m *Model) toggleDetails() {
+	m.showDetails = !m.showDetails
+	m.layoutColumns()
+}
+
+// matchDetailLines describes a match field by field: everything the single
+// row in the matches column truncates or leaves out.
+func (m Model) matchDetailLines(mt Match, now time.Time) []string {
+	field := func(name, value string) string {
+		return m.styles.Subtle.Render(m.styles.Text(name+":")) + " " + m.styles.Text(value)
+	}
+	title := matchDisplayTitle(mt)
+	if m.favorites.Matches(mt) {
+		title = "★ " + title
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(m.styles.Text(title))}
+	if mt.Title != "" && mt.Title != matchDisplayTitle(mt) {
+		lines = append(lines, m.styles.Text(mt.Title))
+	}
+	lines = append(lines, "")
+
+	if mt.Teams != nil {
+		if mt.Teams.Home != nil {
+			lines = append(lines, field("Home", mt.Teams.Home.Name))
+		}
+		if mt.Teams.Away != nil {
+			lines = append(lines, field("Away", mt.Teams.Away.Name))
+		}
+	}
+	if mt.Category != "" {
+		lines = append(lines, field("Category", mt.Category))
+	}
+	if mt.Date > 0 {
+		start := time.UnixMilli(mt.Date)
+		lines = append(lines,
+			field("Kickoff", start.Local().Format(detailsKickoffLayout)),
+			field("UTC", start.UTC().Format(detailsKickoffLayout)),
+		)
+	}
+	if status := matchStatusLabel(mt, now); status != "" {
+		lines = append(lines, field("Status", status))
+	}
+	if mt.Viewers > 0 {
+		lines = append(lines, field("Viewers", formatViewerCount(mt.Viewers)))
+	}
+	if mt.Popular {
+		lines = append(lines, field("Popular", "yes"))
+	}
+	if mt.Poster != "" {
+		lines = append(lines, field("Poster", m.artClient(mt).PosterURL(mt)))
+	}
+	if mt.Provider != "" {
+		lines = append(lines, field("Provider", mt.Provider))
+	}
+	lines = append(lines, field("ID", mt.ID), "")
+
+	lines = append(lines, m.styles.Subtle.Render(m.styles.Text(fmt.Sprintf("Sources (%d)", len(mt.Sources)))))
+	for _, src := range mt.Sources {
+		lines = append(lines, m.styles.Text(fmt.Sprintf("%s %s · %s", m.styles.Glyphs.Cursor, src.Source, src.ID)))
+	}
+	if len(mt.Sources) == 0 {
+		lines = append(lines, m.styles.Text("(none yet)"))
+	}
+	return lines
+}
+
+// detailsArtTop is the row of the main view where the details column's
+// content, and so its picture, starts: below the border, title, and meta line.
+const detailsArtTop = 3
+
+// detailsArt returns the picture of the highlighted match sized for the
+// details column, or nil when there is none to show.
+func (m Model) detailsArt() *artRender {
+	mt, ok := m.matches.Selected()
+	if !m.showDetails || !ok {
+		return nil
+	}
+	rows := min(artMaxRows, m.matches.height/3)
+	return m.art.matchArt(mt.ID, max(m.detailsWidth-4, 10), rows)
+}
+
+// renderDetailsColumn draws the details of the highlighted match in a box the
+// same size as the list columns beside it, with its picture on top when
+// there is one.
+func (m Model) renderDetailsColumn(art *artRender) string {
+	width := max(m.detailsWidth-4, 10)
+	head := m.styles.Title.Render(m.styles.Text("Details"))
+	meta := m.styles.Subtle.Render(m.styles.Text("i to hide"))
+
+	var lines []string
+	if mt, ok := m.matches.Selected(); ok {
+		// Wrap long values (titles, poster URLs) rather than cutting them off,
+		// then drop whatever does not fit.
+		wrapped := lipgloss.NewStyle().Width(width).Render(strings.Join(m.matchDetailLines(mt, time.Now()), "\n"))
+		lines = strings.Split(wrapped, "\n")
+		if art != nil {
+			lines = append(append(append([]string{}, art.lines...), ""), lines...)
+		}
+	} else {
+		lines = []string{"(no match selected)"}
+	}
+	if len(lines) > m.matches.height {
+		lines = lines[:m.matches.height]
+	}
+	for len(lines) < m.matches.height {
+		lines = append(lines, "")
+	}
+	return m.styles.Box.Width(width + 4).Render(head + "\n" + meta + "\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// DISCORD PRESENCE
+// ────────────────────────────────
+
+// discordTimeout bounds connecting to Discord and each exchange with it.
+const discordTimeout = 2 * time.Second
+
+// Opcodes of Discord's local RPC frames.
+const (
+	discordOpHandshake = 0
+	discordOpFrame     = 1
+	discordOpClose     = 2
+)
+
+// discordPresence shows the match being watched as the user's Discord
+// activity over the desktop client's local RPC socket. It connects on first
+// use and again after the connection drops. Players are launched and exit on
+// their own goroutines, so access is serialized.
+type discordPresence struct {
+	clientID string
+	log      func(string)
+
+	mu    sync.Mutex
+	conn  io.ReadWriteCloser
+	nonce int
+	// shown is the player the activity describes; zero when cleared.
+	shown int
+}
+
+// newDiscordPresence returns nil, which does nothing, without a client ID.
+func newDiscordPresence(clientID string, log func(string)) *discordPresence {
+	if clientID == "" {
+		return nil
+	}
+	return &discordPresence{clientID: clientID, log: log}
+}
+
+// Sync shows the newest of the running players as the activity, with the
+// time since it started, or clears the activity when none is left.
+func (d *discordPresence) Sync(players []playerInfo) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var args map[string]any
+	id := 0
+	if len(players) > 0 {
+		p := players[0]
+		state := p.Match.Category
+		if state == "" {
+			state = "Watching a stream"
+		}
+		id = p.ID
+		args = map[string]any{"activity": map[string]any{
+			"details":    p.Title,
+			"state":      state,
+			"timestamps": map[string]int64{"start": p.Started.Unix()},
+		}}
+	} else {
+		if d.shown == 0 {
+			return
+		}
+		args = map[string]any{}
+	}
+	if id != 0 && id == d.shown {
+		return
+	}
+	args["pid"] = os.Getpid()
+	if err := d.setActivity(args); err != nil {
+		d.closeLocked()
+		d.log(fmt.Sprintf("[discord] presence not updated: %v", err))
+		return
+	}
+	d.shown = id
+}
+
+// Close clears the activity by disconnecting.
+func (d *discordPresence) Close() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closeLocked()
+}
+
+func (d *discordPresence) closeLocked() {
+	if d.conn != nil {
+		d.conn.Close()
+		d.conn = nil
+	}
+	d.shown = 0
+}
+
+func (d *discordPresence) setActivity(args map[string]any) error {
+	if d.conn == nil {
+		conn, err := dialDiscord()
+		if err != nil {
+			return err
+		}
+		d.conn = conn
+		if err := d.exchange(discordOpHandshake, map[string]any{"v": 1, "client_id": d.clientID}); err != nil {
+			return fmt.Errorf("handshake: %w", err)
+		}
+	}
+	d.nonce++
+	return d.exchange(discordOpFrame, map[string]any{
+		"cmd":   "SET_ACTIVITY",
+		"args":  args,
+		"nonce": strconv.Itoa(d.nonce),
+	})
+}
+
+// exchange sends one frame and reads Discord's answer to it.
+func (d *discordPresence) exchange(op uint32, payload any) error {
+	if c, ok := d.conn.(interface{ SetDeadline(time.Time) error }); ok {
+		_ = c.SetDeadline(time.Now().Add(discordTimeout))
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var frame bytes.Buffer
+	_ = binary.Write(&frame, binary.LittleEndian, [2]uint32{op, uint32(len(body))})
+	frame.Write(body)
+	if _, err := d.conn.Write(frame.Bytes()); err != nil {
+		return err
+	}
+
+	var header [2]uint32
+	if err := binary.Read(d.conn, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+	data := make([]byte, header[1])
+	if _, err := io.ReadFull(d.conn, data); err != nil {
+		return err
+	}
+	var reply struct {
+		Evt     string `json:"evt"`
+		Message string `json:"message"`
+		Data    struct {
+			Message string `json:"message"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(data, &reply)
+	switch {
+	case header[0] == discordOpClose:
+		return fmt.Errorf("Discord closed the connection: %s", reply.Message)
+	case reply.Evt == "ERROR":
+		return errors.New(reply.Data.Message)
+	}
+	return nil
+}
+
+// dialDiscord connects to the first Discord client socket that answers: a
+// named pipe on Windows, or a Unix socket in the runtime or temp directory,
+// where Flatpak and Snap installs put theirs in a subdirectory.
+func dialDiscord() (io.ReadWriteCloser, error) {
+	var lastErr error
+	for i := range 10 {
+		name := fmt.Sprintf("discord-ipc-%d", i)
+		if runtime.GOOS == "windows" {
+			f, err := os.OpenFile(`\\.\pipe\`+name, os.O_RDWR, 0)
+			if err == nil {
+				return f, nil
+			}
+			lastErr = err
+			continue
+		}
+		for _, dir := range discordSocketDirs() {
+			conn, err := net.DialTimeout("unix", filepath.Join(dir, name), discordTimeout)
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+	}
+	return nil, fmt.Errorf("Discord is not running: %w", lastErr)
+}
+
+func discordSocketDirs() []string {
+	var bases []string
+	for _, env := range []string{"XDG_RUNTIME_DIR", "TMPDIR", "TMP", "TEMP"} {
+		if v := os.Getenv(env); v != "" && !containsFold(bases, v) {
+			bases = append(bases, v)
+		}
+	}
+	if !containsFold(bases, "/tmp") {
+		bases = append(bases, "/tmp")
+	}
+	var dirs []string
+	for _, b := range bases {
+		dirs = append(dirs, b, filepath.Join(b, "app", "com.discordapp.Discord"), filepath.Join(b, "snap.discord"))
+	}
+	return dirs
+}
+
+// syncPresence updates the Discord activity to the running players.
+func (m Model) syncPresence() tea.Cmd {
+	if m.presence == nil {
+		return nil
+	}
+	presence, players := m.presence, m.players
+	return safeCmd("discord presence", m.crash, func() tea.Msg {
+		presence.Sync(players.List())
+		return nil
+	})
+}
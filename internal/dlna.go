@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ────────────────────────────────
+// DLNA RENDERERS
+// ────────────────────────────────
+
+const (
+	ssdpAddr        = "239.255.255.250:1900"
+	avTransportType = "urn:schemas-upnp-org:service:AVTransport:1"
+)
+
+// dlnaRenderer is a UPnP media renderer with an AVTransport service, such
+// as a smart TV, driven with SOAP requests.
+type dlnaRenderer struct {
+	name       string
+	controlURL string
+}
+
+func (d dlnaRenderer) Name() string { return d.name }
+func (d dlnaRenderer) Kind() string { return "DLNA" }
+
+// Addr is the renderer's host and port, to find the interface that reaches
+// it.
+func (d dlnaRenderer) Addr() string {
+	if u, err := url.Parse(d.controlURL); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+// Cast sets the stream as the renderer's current URI and starts playing it.
+func (d dlnaRenderer) Cast(ctx context.Context, media castMedia) error {
+	meta := fmt.Sprintf(`<DIDL-Lite xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/">`+
+		`<item id="0" parentID="-1" restricted="1"><dc:title>%s</dc:title><upnp:class>object.item.videoItem</upnp:class>`+
+		`<res protocolInfo="http-get:*:%s:*">%s</res></item></DIDL-Lite>`,
+		html.EscapeString(media.Title), castContentType, html.EscapeString(media.URL))
+	err := d.soap(ctx, "SetAVTransportURI", fmt.Sprintf(
+		"<InstanceID>0</InstanceID><CurrentURI>%s</CurrentURI><CurrentURIMetaData>%s</CurrentURIMetaData>",
+		html.EscapeString(media.URL), html.EscapeString(meta)))
+	if err != nil {
+		return err
+	}
+	return d.soap(ctx, "Play", "<InstanceID>0</InstanceID><Speed>1</Speed>")
+}
+
+// soap calls an AVTransport action with the given argument elements.
+func (d dlnaRenderer) soap(ctx context.Context, action, args string) error {
+	body := `<?xml version="1.0" encoding="utf-8"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>` +
+		fmt.Sprintf(`<u:%s xmlns:u="%s">%s</u:%s>`, action, avTransportType, args, action) +
+		`</s:Body></s:Envelope>`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.controlURL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, avTransportType, action))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", action, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		var fault struct {
+			Description string `xml:"Body>Fault>detail>UPnPError>errorDescription"`
+			Code        string `xml:"Body>Fault>detail>UPnPError>errorCode"`
+		}
+		if xml.Unmarshal(reply, &fault) == nil && fault.Code != "" {
+			return fmt.Errorf("%s: UPnP error %s %s", action, fault.Code, fault.Description)
+		}
+		return fmt.Errorf("%s: %s", action, resp.Status)
+	}
+	return nil
+}
+
+// discoverDLNA searches the LAN with SSDP for renderers with an AVTransport
+// service until ctx is done.
+func discoverDLNA(ctx context.Context) ([]castTarget, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	dst, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return nil, err
+	}
+	search := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddr + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n" +
+		"ST: " + avTransportType + "\r\n\r\n"
+	if _, err := conn.WriteTo([]byte(search), dst); err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetReadDeadline(deadline)
+	}
+
+	var locations []string
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		if loc := resp.Header.Get("Location"); loc != "" && !containsFold(locations, loc) {
+			locations = append(locations, loc)
+		}
+	}
+
+	var out []castTarget
+	for _, loc := range locations {
+		descCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		r, err := fetchRenderer(descCtx, loc)
+		cancel()
+		if err == nil {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// upnpDevice is a device of a UPnP description, with its embedded devices.
+type upnpDevice struct {
+	FriendlyName string `xml:"friendlyName"`
+	Services     []struct {
+		Type       string `xml:"serviceType"`
+		ControlURL string `xml:"controlURL"`
+	} `xml:"serviceList>service"`
+	Devices []upnpDevice `xml:"deviceList>device"`
+}
+
+// avTransport returns the name and control URL of the first device in the
+// tree with an AVTransport service.
+func (d upnpDevice) avTransport() (string, string, bool) {
+	for _, s := range d.Services {
+		if strings.HasPrefix(s.Type, "urn:schemas-upnp-org:service:AVTransport:") {
+			return d.FriendlyName, s.ControlURL, true
+		}
+	}
+	for _, sub := range d.Devices {
+		if name, control, ok := sub.avTransport(); ok {
+			return name, control, true
+		}
+	}
+	return "", "", false
+}
+
+// fetchRenderer reads a device description and finds its AVTransport
+// control URL.
+func fetchRenderer(ctx context.Context, location string) (dlnaRenderer, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return dlnaRenderer{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return dlnaRenderer{}, err
+	}
+	defer resp.Body.Close()
+	var desc struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&desc); err != nil {
+		return dlnaRenderer{}, fmt.Errorf("%s: %w", location, err)
+	}
+	name, control, ok := desc.Device.avTransport()
+	if !ok {
+		return dlnaRenderer{}, fmt.Errorf("%s: no AVTransport service", location)
+	}
+	base := location
+	if desc.URLBase != "" {
+		base = desc.URLBase
+	}
+	if b, err := url.Parse(base); err == nil {
+		if u, err := b.Parse(control); err == nil {
+			control = u.String()
+		}
+	}
+	if name == "" {
+		name = resp.Request.URL.Host
+	}
+	return dlnaRenderer{name: name, controlURL: control}, nil
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// DOWNLOADS
+// ────────────────────────────────
+
+// downloadsDir returns where yt-dlp downloads are written, creating it.
+func (r *recorder) downloadsDir() (string, error) {
+	if r.downloadDir != "" {
+		dir := expandHome(r.downloadDir)
+		return dir, os.MkdirAll(dir, 0o755)
+	}
+	return ensureAppDir(dataDir, "downloads")
+}
+
+// ytDlpArgs downloads the playlist into an MPEG-TS file, sending the same
+// playback headers as the player. Unlike ffmpeg, yt-dlp takes SOCKS proxies.
+func ytDlpArgs(m3u8, path, proxy string, hdrs map[string]string) []string {
+	args := []string{"--quiet", "--no-warnings", "--no-part", "--hls-use-mpegts"}
+	if proxy != "" {
+		args = append(args, "--proxy", proxy)
+	}
+	for _, hk := range playbackHeaders {
+		if v := lookupHeaderValue(hdrs, hk.lookup); v != "" {
+			args = append(args, "--add-header", hk.display+":"+v)
+		}
+	}
+	return append(args, "-o", path, "--", m3u8)
+}
+
+// Download hands the playlist to yt-dlp, listing it with the recordings.
+func (r *recorder) Download(title, m3u8 string, hdrs map[string]string) (recordingInfo, error) {
+	path, err := exec.LookPath("yt-dlp")
+	if err != nil {
+		return recordingInfo{}, errors.New("yt-dlp is not installed")
+	}
+	dir, err := r.downloadsDir()
+	if err != nil {
+		return recordingInfo{}, fmt.Errorf("downloads directory: %w", err)
+	}
+	started := time.Now()
+	file := recordingPath(dir, title, started)
+	cmd := exec.Command(path, ytDlpArgs(m3u8, file, r.proxy, hdrs)...)
+	return r.start(recordingInfo{Title: title, Path: file, Started: started, Download: true}, cmd)
+}
+
+// downloadStream extracts a stream of mt and downloads it with yt-dlp.
+func (m *Model) downloadStream(st Stream, mt Match) tea.Cmd {
+	return m.trackExtraction("download:"+st.EmbedURL, fmt.Sprintf("Extracting stream #%d for download", st.StreamNo), func(ctx context.Context) tea.Cmd {
+		return m.runDownloader(ctx, st, m.fallbackStreams(st, mt), mt)
+	})
+}
+
+func (m Model) runDownloader(ctx context.Context, st Stream, fallbacks []Stream, mt Match) tea.Cmd {
+	return safeCmd("downloader", m.crash, func() tea.Msg {
+		if st.EmbedURL == "" {
+			return debugLogMsg("Download aborted: empty embed URL")
+		}
+		logcb := m.ui.Log
+		st, res, err := m.extractWithFallback(ctx, append([]Stream{st}, fallbacks...), logcb)
+		if ctx.Err() != nil {
+			return debugLogMsg(fmt.Sprintf("Extraction of stream #%d canceled", st.StreamNo))
+		}
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("Extractor failed: %v", err))
+		}
+		title := matchDisplayTitle(mt)
+		if title == "" {
+			title = fmt.Sprintf("%s stream %d", st.Source, st.StreamNo)
+		}
+		info, err := m.recorder.Download(title, res.URL, res.Headers)
+		if err != nil {
+			return errorMsg(fmt.Errorf("download: %w", err))
+		}
+		logcb(fmt.Sprintf("[download] ⬇ downloading %s to %s with yt-dlp", res.URL, info.Path))
+		return recordingStartedMsg(info)
+	})
+}
@@ -2,16 +2,26 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
-	"syscall"
+	"sync"
 	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
 )
 
 type puppeteerResult struct {
@@ -31,7 +41,7 @@ type logBuffer struct {
 // executable's directory, walking up parent paths until a node_modules match is
 // found. This allows the binary to resolve Node packages even when launched via
 // a .desktop file or from another directory.
-func findNodeModuleBase() (string, error) {
+func findNodeModuleBase(phase func(string)) (string, error) {
 	starts := []string{}
 
 	if wd, err := os.Getwd(); err == nil {
@@ -71,7 +81,7 @@ func findNodeModuleBase() (string, error) {
 		}
 	}
 
-	if extracted, err := ensureEmbeddedNodeModules(); err == nil {
+	if extracted, err := ensureEmbeddedNodeModules(phase); err == nil {
 		return extracted, nil
 	}
 
@@ -117,29 +127,85 @@ func (l *logBuffer) WriteTo(w io.Writer) (int64, error) {
 	return l.buf.WriteTo(w)
 }
 
-func ensurePuppeteerAvailable(baseDir string) error {
-	if _, err := exec.LookPath("node"); err != nil {
-		return fmt.Errorf("node executable not found: %w", err)
+// ────────────────────────────────
+// JAVASCRIPT RUNTIMES
+// ────────────────────────────────
+
+// jsRuntime is a JavaScript runtime able to run the Puppeteer runner. Node is
+// preferred; bun and deno (through its npm compatibility layer) are used when
+// node is not installed.
+type jsRuntime struct {
+	name string
+	path string
+}
+
+// supportedRuntimes lists the runtimes in order of preference.
+var supportedRuntimes = []string{"node", "bun", "deno"}
+
+// findJSRuntime returns the runtime named by want, or the first installed
+// one when want is empty.
+func findJSRuntime(want string) (jsRuntime, error) {
+	if want != "" {
+		if !slices.Contains(supportedRuntimes, want) {
+			return jsRuntime{}, fmt.Errorf("unsupported runtime %q (want one of %s)", want, strings.Join(supportedRuntimes, ", "))
+		}
+		path, err := exec.LookPath(want)
+		if err != nil {
+			return jsRuntime{}, fmt.Errorf("%s executable not found: %w", want, err)
+		}
+		return jsRuntime{name: want, path: path}, nil
+	}
+	for _, name := range supportedRuntimes {
+		if path, err := exec.LookPath(name); err == nil {
+			return jsRuntime{name: name, path: path}, nil
+		}
+	}
+	return jsRuntime{}, errors.New("no JavaScript runtime found; install node (or bun or deno)")
+}
+
+// runArgs returns the arguments that run script with args.
+func (r jsRuntime) runArgs(script string, args ...string) []string {
+	switch r.name {
+	case "bun":
+		return append([]string{"run", script}, args...)
+	case "deno":
+		// -A grants the network, env, file, and subprocess access Puppeteer
+		// needs; the node_modules tree is used as is.
+		return append([]string{"run", "-A", "--node-modules-dir=manual", script}, args...)
+	default:
+		return append([]string{script}, args...)
 	}
+}
+
+// evalArgs returns the arguments that evaluate a CommonJS snippet.
+func (r jsRuntime) evalArgs(body string) []string {
+	switch r.name {
+	case "deno":
+		// deno eval only takes ES modules.
+		return []string{"eval", "import { createRequire } from 'node:module'; import process from 'node:process';" + body}
+	default:
+		return []string{"-e", "const { createRequire } = require('module');" + body}
+	}
+}
 
+func ensurePuppeteerAvailable(rt jsRuntime, baseDir string, phase func(string)) error {
 	// Verify both puppeteer-extra and the stealth plugin are available from the
 	// discovered base directory so the temporary runner can load them reliably
 	// even when the binary is launched outside the repo (e.g., .desktop file).
 	requireScript := strings.Join([]string{
-		"const { createRequire } = require('module');",
 		"const base = process.env.STREAMED_TUI_NODE_BASE || process.cwd();",
 		"const req = createRequire(base.endsWith('/') ? base : base + '/');",
 		"req.resolve('puppeteer-extra/package.json');",
 		"req.resolve('puppeteer-extra-plugin-stealth/package.json');",
 	}, "")
 
-	check := exec.Command("node", "-e", requireScript)
+	check := exec.Command(rt.path, rt.evalArgs(requireScript)...)
 	check.Dir = baseDir
 	check.Env = append(os.Environ(), fmt.Sprintf("STREAMED_TUI_NODE_BASE=%s", baseDir))
 
 	if err := check.Run(); err != nil {
-		if embedded, embErr := ensureEmbeddedNodeModules(); embErr == nil && embedded != baseDir {
-			return ensurePuppeteerAvailable(embedded)
+		if embedded, embErr := ensureEmbeddedNodeModules(phase); embErr == nil && embedded != baseDir {
+			return ensurePuppeteerAvailable(rt, embedded, phase)
 		}
 
 		return fmt.Errorf("puppeteer-extra or stealth plugin missing in %s. Run `npm install puppeteer-extra puppeteer-extra-plugin-stealth puppeteer` there or rebuild the embedded archive with scripts/build_node_modules.sh: %w", baseDir, err)
@@ -148,10 +214,14 @@ func ensurePuppeteerAvailable(baseDir string) error {
 	return nil
 }
 
+// extractFunc resolves an embed URL to a playable .m3u8 URL and the request
+// headers needed to fetch it.
+type extractFunc func(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error)
+
 // extractM3U8Lite invokes a small Puppeteer runner that loads the embed page,
 // watches for .m3u8 requests, and returns the first match plus its request
-// headers.
-func extractM3U8Lite(embedURL string, log func(string)) (string, map[string]string, error) {
+// headers. The runner is killed when ctx is done.
+func extractM3U8Lite(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (string, map[string]string, error) {
 	if log == nil {
 		log = func(string) {}
 	}
@@ -160,32 +230,61 @@ func extractM3U8Lite(embedURL string, log func(string)) (string, map[string]stri
 		return "", nil, errors.New("empty embed URL")
 	}
 
-	baseDir, err := findNodeModuleBase()
+	opts.phase("locating node modules")
+	baseDir, err := findNodeModuleBase(opts.phase)
 	if err != nil {
 		return "", nil, err
 	}
 
-	if err := ensurePuppeteerAvailable(baseDir); err != nil {
+	rt, err := findJSRuntime(opts.Runtime)
+	if err != nil {
 		return "", nil, err
 	}
 
-	runnerPath, err := writePuppeteerRunner(baseDir)
-	if err != nil {
+	opts.phase("checking puppeteer")
+	if err := ensurePuppeteerAvailable(rt, baseDir, opts.phase); err != nil {
 		return "", nil, err
 	}
-	defer os.Remove(runnerPath)
 
-	log(fmt.Sprintf("[puppeteer] launching chromium stealth runner for %s", embedURL))
+	runnerPath := opts.RunnerPath
+	if runnerPath != "" {
+		if _, err := os.Stat(runnerPath); err != nil {
+			return "", nil, fmt.Errorf("custom runner: %w", err)
+		}
+		log(fmt.Sprintf("[puppeteer] using custom runner %s", runnerPath))
+	} else {
+		if runnerPath, err = writePuppeteerRunner(); err != nil {
+			return "", nil, err
+		}
+		defer os.Remove(runnerPath)
+	}
+
+	opts.phase("loading embed page in chromium")
+	log(fmt.Sprintf("[puppeteer] launching chromium stealth runner under %s for %s", rt.name, embedURL))
 
-	cmd := exec.Command("node", runnerPath, embedURL)
+	cmd := exec.CommandContext(ctx, rt.path, rt.runArgs(runnerPath, embedURL)...)
 	cmd.Dir = baseDir
-	cmd.Env = append(os.Environ(), fmt.Sprintf("STREAMED_TUI_NODE_BASE=%s", baseDir))
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("STREAMED_TUI_NODE_BASE=%s", baseDir),
+		fmt.Sprintf("STREAMED_TUI_NAV_TIMEOUT_MS=%d", opts.NavTimeout.Milliseconds()),
+		fmt.Sprintf("STREAMED_TUI_CAPTURE_TIMEOUT_MS=%d", opts.CaptureTimeout.Milliseconds()),
+		fmt.Sprintf("STREAMED_TUI_BLOCK_ADS=%t", opts.BlockAds),
+		fmt.Sprintf("STREAMED_TUI_BLOCKED_DOMAINS=%s", strings.Join(blockedAdDomains, ",")),
+		fmt.Sprintf("STREAMED_TUI_BLOCK_ASSETS=%t", opts.BlockAssets),
+		fmt.Sprintf("STREAMED_TUI_PROXY=%s", browserProxy(opts.Proxy)),
+	)
+	// Run the runner in its own process group so cancellation also takes
+	// down the Chromium children it spawned.
+	killGroupOnCancel(cmd)
 	stdout := &logBuffer{buf: &bytes.Buffer{}, log: func(line string) { log(line) }, prefix: "[puppeteer stdout] "}
 	stderr := &logBuffer{buf: &bytes.Buffer{}, log: func(line string) { log(line) }, prefix: "[puppeteer stderr] "}
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
 	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); errors.Is(ctxErr, context.DeadlineExceeded) {
+			return "", nil, fmt.Errorf("puppeteer runner timed out after %s", opts.deadline())
+		}
 		log(fmt.Sprintf("[puppeteer] runner error: %s", strings.TrimSpace(stderr.String())))
 		return "", nil, fmt.Errorf("puppeteer runner failed: %w", err)
 	}
@@ -207,11 +306,435 @@ func extractM3U8Lite(embedURL string, log func(string)) (string, map[string]stri
 	return res.URL, res.Headers, nil
 }
 
-// writePuppeteerRunner materializes a temporary Node.js script that performs
-// the actual page load and .m3u8 discovery with puppeteer-extra stealth
-// protections.
-func writePuppeteerRunner(baseDir string) (string, error) {
-	script := `const { createRequire } = require('module');
+// ────────────────────────────────
+// CHROMEDP BACKEND
+// ────────────────────────────────
+
+// errNoBrowser means chromedp found no Chrome or Chromium to drive.
+var errNoBrowser = errors.New("no Chrome or Chromium browser found")
+
+// chromeCandidates are looked up on PATH, in order, when no browser path is
+// configured.
+var chromeCandidates = []string{
+	"chromium", "chromium-browser", "google-chrome", "google-chrome-stable",
+	"headless_shell", "chrome-headless-shell", "chrome", "microsoft-edge",
+}
+
+// chromeInstallGlobs are well-known install locations off PATH: macOS
+// application bundles and the browsers Puppeteer downloads into its cache.
+var chromeInstallGlobs = []string{
+	"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+	"/Applications/Chromium.app/Contents/MacOS/Chromium",
+	"~/.cache/puppeteer/chrome-headless-shell/*/*/chrome-headless-shell",
+	"~/.cache/puppeteer/chrome/*/*/chrome",
+}
+
+// chromeUserAgent matches the user agent the Puppeteer runner sends.
+const chromeUserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+
+// blockedAdDomains are the ad, analytics, and popunder networks aborted when
+// ad blocking is on. Embed pages are stuffed with them; none are needed to
+// reach the player and their popups can steal the page. The runner script
+// gets the list in STREAMED_TUI_BLOCKED_DOMAINS.
+var blockedAdDomains = []string{
+	"doubleclick.net", "googlesyndication.com", "googleadservices.com", "adservice.google.com",
+	"google-analytics.com", "googletagmanager.com", "googletagservices.com",
+	"popads.net", "popcash.net", "propellerads.com", "propellerclick.com", "onclickads.net",
+	"adsterra.com", "exoclick.com", "juicyads.com", "hilltopads.net", "clickadu.com",
+	"adcash.com", "a-ads.com", "mgid.com", "taboola.com", "outbrain.com", "revcontent.com",
+	"histats.com", "mc.yandex.ru", "scorecardresearch.com", "quantserve.com", "hotjar.com",
+	"connect.facebook.net", "amazon-adsystem.com", "adnxs.com", "rubiconproject.com",
+	"pubmatic.com", "criteo.com", "outbrainimg.com", "zedo.com", "bidvertiser.com",
+}
+
+// blockedAssetTypes are never needed to observe the .m3u8 request.
+var blockedAssetTypes = map[network.ResourceType]bool{
+	network.ResourceTypeImage:      true,
+	network.ResourceTypeFont:       true,
+	network.ResourceTypeStylesheet: true,
+}
+
+// chromedpStealthScript runs before any page script, hiding the automation
+// markers Puppeteer's stealth plugin would otherwise take care of.
+const chromedpStealthScript = `(() => {
+  const { width, height } = window.screen || { width: 1920, height: 1080 };
+  Object.defineProperty(navigator, 'webdriver', { get: () => undefined });
+  Object.defineProperty(navigator, 'maxTouchPoints', { get: () => 1 });
+  Object.defineProperty(navigator, 'platform', { get: () => 'Linux x86_64' });
+  Object.defineProperty(navigator, 'hardwareConcurrency', { get: () => 8 });
+  Object.defineProperty(navigator, 'languages', { get: () => ['en-US', 'en'] });
+  Object.defineProperty(window, 'outerWidth', { get: () => width });
+  Object.defineProperty(window, 'outerHeight', { get: () => height });
+  window.chrome = window.chrome || { runtime: {} };
+})();`
+
+// chromedpDOMFallbackScript looks for a playlist URL in the page when no
+// .m3u8 request was observed.
+const chromedpDOMFallbackScript = `(() => {
+  try {
+    const video = document.querySelector('video');
+    if (video) {
+      if (video.currentSrc) return video.currentSrc;
+      if (video.src) return video.src;
+      const source = video.querySelector('source');
+      if (source && source.src) return source.src;
+    }
+    const match = document.documentElement.innerHTML.match(/https?:\/\/[^'"\s]+\.m3u8[^'"\s]*/i);
+    if (match) return match[0];
+  } catch (e) {}
+  return '';
+})()`
+
+// findChrome returns the browser binary chromedp should launch: want when
+// set, otherwise the first candidate on PATH or in a known install location.
+func findChrome(want string) (string, error) {
+	if want != "" {
+		path, err := exec.LookPath(want)
+		if err != nil {
+			return "", fmt.Errorf("browser %q: %w", want, errNoBrowser)
+		}
+		return path, nil
+	}
+	for _, name := range chromeCandidates {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	home, _ := os.UserHomeDir()
+	for _, pattern := range chromeInstallGlobs {
+		if rest, ok := strings.CutPrefix(pattern, "~/"); ok {
+			if home == "" {
+				continue
+			}
+			pattern = filepath.Join(home, rest)
+		}
+		matches, _ := filepath.Glob(pattern)
+		// Newer versions sort last.
+		for i := len(matches) - 1; i >= 0; i-- {
+			if path, err := exec.LookPath(matches[i]); err == nil {
+				return path, nil
+			}
+		}
+	}
+	return "", errNoBrowser
+}
+
+// blockedAdHost reports whether rawURL points at a blocked ad domain or one
+// of its subdomains.
+func blockedAdHost(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	for _, d := range blockedAdDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockReason returns why a request should be aborted, or "" to let it
+// through.
+func (e extractOptions) blockReason(rawURL string, typ network.ResourceType) string {
+	if e.BlockAds && blockedAdHost(rawURL) {
+		return "ad/tracker"
+	}
+	if e.BlockAssets && blockedAssetTypes[typ] {
+		return strings.ToLower(string(typ))
+	}
+	return ""
+}
+
+// resolvePlaylist picks the playlist to play from a captured .m3u8 response,
+// like the runner does: a media playlist with segments is final, and a master
+// playlist is followed to the first nested playlist it lists.
+func resolvePlaylist(playlistURL, body string) (final string, hasExtinf bool, reason string) {
+	if strings.Contains(body, "#EXTINF") {
+		return playlistURL, true, "contains #EXTINF segments"
+	}
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || !strings.Contains(strings.ToLower(line), ".m3u8") {
+			continue
+		}
+		base, err := url.Parse(playlistURL)
+		if err != nil {
+			return line, false, "nested m3u8 discovered in response body"
+		}
+		ref, err := base.Parse(line)
+		if err != nil {
+			return line, false, "nested m3u8 discovered in response body"
+		}
+		return ref.String(), false, "nested m3u8 discovered in response body"
+	}
+	return playlistURL, false, "first seen"
+}
+
+// m3u8Capture collects .m3u8 candidates from chromedp's event listeners,
+// which run on chromedp's goroutines.
+type m3u8Capture struct {
+	mu       sync.Mutex
+	requests map[network.RequestID]capturedRequest
+	url      string
+	headers  map[string]string
+	blocked  map[string]int
+	found    chan struct{} // closed on the first capture
+	once     sync.Once
+}
+
+type capturedRequest struct {
+	url     string
+	headers map[string]string
+}
+
+func newM3U8Capture() *m3u8Capture {
+	return &m3u8Capture{
+		requests: map[network.RequestID]capturedRequest{},
+		blocked:  map[string]int{},
+		found:    make(chan struct{}),
+	}
+}
+
+func (c *m3u8Capture) track(id network.RequestID, rawURL string, hdrs network.Headers) {
+	headers := make(map[string]string, len(hdrs))
+	for k, v := range hdrs {
+		headers[strings.ToLower(k)] = fmt.Sprint(v)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests[id] = capturedRequest{url: rawURL, headers: headers}
+}
+
+func (c *m3u8Capture) tracked(id network.RequestID) (capturedRequest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req, ok := c.requests[id]
+	return req, ok
+}
+
+// offer records a playlist. The first one wins until one with segments
+// arrives.
+func (c *m3u8Capture) offer(req capturedRequest, body string, log func(string)) {
+	final, hasExtinf, reason := resolvePlaylist(req.url, body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.url != "" && !hasExtinf {
+		return
+	}
+	c.url = final
+	c.headers = req.headers
+	log(fmt.Sprintf("[chromedp] captured .m3u8 (%s): %s", reason, final))
+	c.once.Do(func() { close(c.found) })
+}
+
+func (c *m3u8Capture) countBlocked(reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocked[reason]++
+}
+
+func (c *m3u8Capture) result() (string, map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hdrs := make(map[string]string, len(c.headers))
+	for k, v := range c.headers {
+		hdrs[k] = v
+	}
+	return c.url, hdrs
+}
+
+// extractM3U8Chromedp drives a local Chrome over the DevTools protocol: it
+// loads the embed page, watches network responses for .m3u8 playlists, and
+// returns the first match plus its request headers, all without Node.
+func extractM3U8Chromedp(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (string, map[string]string, error) {
+	if log == nil {
+		log = func(string) {}
+	}
+	if strings.TrimSpace(embedURL) == "" {
+		return "", nil, errors.New("empty embed URL")
+	}
+
+	opts.phase("locating browser")
+	browser, err := findChrome(opts.BrowserPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	allocOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.ExecPath(browser),
+		chromedp.UserAgent(chromeUserAgent),
+		chromedp.WindowSize(1280, 720),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.NoSandbox,
+	)
+	if opts.Proxy != "" {
+		allocOpts = append(allocOpts, chromedp.ProxyServer(browserProxy(opts.Proxy)))
+	}
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, allocOpts...)
+	defer cancelAlloc()
+	tabCtx, cancelTab := chromedp.NewContext(allocCtx)
+	defer cancelTab()
+
+	capture := newM3U8Capture()
+	targetExec := func() context.Context {
+		return cdp.WithExecutor(tabCtx, chromedp.FromContext(tabCtx).Target)
+	}
+	chromedp.ListenTarget(tabCtx, func(ev any) {
+		switch ev := ev.(type) {
+		case *fetch.EventRequestPaused:
+			// Commands cannot be issued from the listener itself.
+			go func() {
+				if reason := opts.blockReason(ev.Request.URL, ev.ResourceType); reason != "" {
+					capture.countBlocked(reason)
+					_ = fetch.FailRequest(ev.RequestID, network.ErrorReasonBlockedByClient).Do(targetExec())
+					return
+				}
+				_ = fetch.ContinueRequest(ev.RequestID).Do(targetExec())
+			}()
+		case *network.EventRequestWillBeSent:
+			if strings.Contains(ev.Request.URL, ".m3u8") {
+				capture.track(ev.RequestID, ev.Request.URL, ev.Request.Headers)
+			}
+		case *network.EventLoadingFinished:
+			req, ok := capture.tracked(ev.RequestID)
+			if !ok {
+				return
+			}
+			go func() {
+				body, err := network.GetResponseBody(ev.RequestID).Do(targetExec())
+				if err != nil {
+					log(fmt.Sprintf("[chromedp] failed to read m3u8 body for %s: %v", req.url, err))
+				}
+				capture.offer(req, string(body), log)
+			}()
+		}
+	})
+	if opts.BlockAds {
+		// Popunders open new tabs; close them so they never take focus.
+		chromedp.ListenBrowser(tabCtx, func(ev any) {
+			created, ok := ev.(*target.EventTargetCreated)
+			if !ok || created.TargetInfo.Type != "page" || created.TargetInfo.OpenerID == "" {
+				return
+			}
+			go func() {
+				browserExec := cdp.WithExecutor(tabCtx, chromedp.FromContext(tabCtx).Browser)
+				if err := target.CloseTarget(created.TargetInfo.TargetID).Do(browserExec); err == nil {
+					log("[chromedp] closed popup " + created.TargetInfo.URL)
+				}
+			}()
+		})
+	}
+
+	opts.phase("loading embed page in chromium")
+	log(fmt.Sprintf("[chromedp] launching %s for %s", browser, embedURL))
+
+	// The first Run starts the browser, so it uses tabCtx itself rather than
+	// a context that expires with the navigation timeout.
+	setup := []chromedp.Action{
+		network.Enable(),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			_, err := page.AddScriptToEvaluateOnNewDocument(chromedpStealthScript).Do(ctx)
+			return err
+		}),
+		network.SetExtraHTTPHeaders(network.Headers{
+			"accept-language":    "en-US,en;q=0.9",
+			"sec-ch-ua":          `"Chromium";v="124", "Not=A?Brand";v="99", "Google Chrome";v="124"`,
+			"sec-ch-ua-platform": "Linux",
+			"sec-ch-ua-mobile":   "?0",
+		}),
+	}
+	if opts.BlockAds || opts.BlockAssets {
+		setup = append(setup, fetch.Enable())
+	}
+	if err := chromedp.Run(tabCtx, setup...); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", nil, fmt.Errorf("chromedp extractor timed out after %s", opts.deadline())
+		}
+		return "", nil, fmt.Errorf("start browser: %w", err)
+	}
+
+	log("[chromedp] navigating to " + embedURL)
+	navCtx, cancelNav := context.WithTimeout(tabCtx, opts.NavTimeout)
+	err = chromedp.Run(navCtx,
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			_, _, errorText, _, err := page.Navigate(embedURL).Do(ctx)
+			if err == nil && errorText != "" {
+				err = errors.New(errorText)
+			}
+			return err
+		}),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+	)
+	cancelNav()
+	if err != nil {
+		// Like the runner, keep listening: the player often loads anyway.
+		log(fmt.Sprintf("[chromedp] navigation warning: %v", err))
+	} else {
+		log("[chromedp] page reached DOM ready")
+	}
+
+	opts.phase("waiting for .m3u8 request")
+	select {
+	case <-capture.found:
+	case <-time.After(opts.CaptureTimeout):
+	case <-ctx.Done():
+		return "", nil, fmt.Errorf("chromedp extractor timed out after %s", opts.deadline())
+	}
+
+	m3u8, hdrs := capture.result()
+	if m3u8 == "" {
+		log("[chromedp] no .m3u8 request observed, scanning DOM for fallback")
+		var candidate string
+		if err := chromedp.Run(tabCtx, chromedp.Evaluate(chromedpDOMFallbackScript, &candidate)); err == nil && strings.Contains(candidate, ".m3u8") {
+			m3u8 = candidate
+		}
+	}
+	if m3u8 == "" {
+		return "", nil, errors.New("m3u8 not found")
+	}
+
+	// Enrich headers with cookies and referer if missing.
+	var cookies []*network.Cookie
+	_ = chromedp.Run(tabCtx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = network.GetCookies().Do(ctx)
+		return err
+	}))
+	log(fmt.Sprintf("[chromedp] collected %d cookies during session", len(cookies)))
+	if len(cookies) > 0 && hdrs["cookie"] == "" {
+		pairs := make([]string, len(cookies))
+		for i, c := range cookies {
+			pairs[i] = c.Name + "=" + c.Value
+		}
+		hdrs["cookie"] = strings.Join(pairs, "; ")
+	}
+	hdrs["user-agent"] = chromeUserAgent
+	if hdrs["referer"] == "" {
+		hdrs["referer"] = embedURL
+	}
+	if u, err := url.Parse(embedURL); err == nil && hdrs["origin"] == "" {
+		hdrs["origin"] = u.Scheme + "://" + u.Host
+	}
+
+	capture.mu.Lock()
+	for reason, count := range capture.blocked {
+		log(fmt.Sprintf("[chromedp] blocked %d %s requests", count, reason))
+	}
+	capture.mu.Unlock()
+
+	log(fmt.Sprintf("[chromedp] ✅ found .m3u8: %s", m3u8))
+	return m3u8, hdrs, nil
+}
+
+// puppeteerRunnerScript is the built-in Node.js runner that performs the
+// actual page load and .m3u8 discovery with puppeteer-extra stealth
+// protections. A custom runner (Options.RunnerPath) must follow the same
+// contract: the embed URL is argv[2], settings arrive in STREAMED_TUI_*
+// environment variables, logs go to stderr, and a single JSON object
+// {"url", "headers", "browser"} is printed to stdout.
+const puppeteerRunnerScript = `const { createRequire } = require('module');
 const base = process.env.STREAMED_TUI_NODE_BASE || process.cwd();
 const requireFromCwd = createRequire(base.endsWith('/') ? base : base + '/');
 
@@ -227,9 +750,27 @@ try {
 }
 
 const embedURL = process.argv[2];
-const timeoutMs = 45000;
+const timeoutMs = parseInt(process.env.STREAMED_TUI_NAV_TIMEOUT_MS, 10) || 45000;
+const captureTimeoutMs = parseInt(process.env.STREAMED_TUI_CAPTURE_TIMEOUT_MS, 10) || 20000;
+const blockAds = process.env.STREAMED_TUI_BLOCK_ADS !== 'false';
+const blockAssets = process.env.STREAMED_TUI_BLOCK_ASSETS !== 'false';
+// Resource types that are never needed to observe the .m3u8 request.
+const blockedResourceTypes = new Set(['image', 'font', 'stylesheet']);
 const log = (...args) => console.error(...args);
 
+// Ad, analytics, and popunder networks, listed by streamed-tui.
+const blockedDomains = (process.env.STREAMED_TUI_BLOCKED_DOMAINS || '').split(',').filter(Boolean);
+
+function blockedHost(url) {
+  let host = '';
+  try {
+    host = new URL(url).hostname;
+  } catch (_) {
+    return false;
+  }
+  return blockedDomains.some(d => host === d || host.endsWith('.' + d));
+}
+
 if (!embedURL) {
   console.error('missing embed URL');
   process.exit(1);
@@ -237,6 +778,9 @@ if (!embedURL) {
 
 const viewport = { width: 1280, height: 720 };
 const launchArgs = ['--disable-blink-features=AutomationControlled', '--no-sandbox', '--disable-web-security', '--window-size=1920,1080'];
+if (process.env.STREAMED_TUI_PROXY) {
+  launchArgs.push('--proxy-server=' + process.env.STREAMED_TUI_PROXY);
+}
 const userAgent = 'Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36';
 
 async function launchBrowser() {
@@ -279,6 +823,39 @@ function installTouchAndWindowSpoofing(page) {
     'sec-ch-ua-mobile': '?0',
   });
 
+  // blockReason returns why a request should be aborted, or '' to let it
+  // through.
+  const blocked = {};
+  function blockReason(req) {
+    if (blockAds && blockedHost(req.url())) return 'ad/tracker';
+    if (blockAssets && blockedResourceTypes.has(req.resourceType())) return req.resourceType();
+    return '';
+  }
+
+  await page.setRequestInterception(true);
+  page.on('request', req => {
+    if (req.isInterceptResolutionHandled && req.isInterceptResolutionHandled()) return;
+    const reason = blockReason(req);
+    if (reason) {
+      blocked[reason] = (blocked[reason] || 0) + 1;
+      req.abort('blockedbyclient').catch(() => {});
+      return;
+    }
+    req.continue().catch(() => {});
+  });
+
+  if (blockAds) {
+    // Popunders open new tabs; close them so they never take focus.
+    browser.on('targetcreated', async target => {
+      if (target.type() !== 'page') return;
+      const popup = await target.page().catch(() => null);
+      if (popup && popup !== page) {
+        log('[puppeteer] closed popup ' + target.url());
+        popup.close().catch(() => {});
+      }
+    });
+  }
+
   let captured = null;
   let resolveCapture;
   const capturePromise = new Promise(resolve => {
@@ -345,7 +922,7 @@ function installTouchAndWindowSpoofing(page) {
 
   await Promise.race([
     capturePromise,
-    new Promise(resolve => setTimeout(resolve, 20000)),
+    new Promise(resolve => setTimeout(resolve, captureTimeoutMs)),
   ]);
 
   if (!captured) {
@@ -388,6 +965,10 @@ function installTouchAndWindowSpoofing(page) {
     } catch (e) {}
   }
 
+  for (const [reason, count] of Object.entries(blocked)) {
+    log('[puppeteer] blocked ' + count + ' ' + reason + ' requests');
+  }
+
   await browser.close();
 
   const output = captured || { url: '', headers: {} };
@@ -399,9 +980,19 @@ function installTouchAndWindowSpoofing(page) {
 });
 `
 
+// DefaultRunnerScript returns the built-in runner, as a starting point for a
+// custom runner script.
+func DefaultRunnerScript() string {
+	return puppeteerRunnerScript
+}
+
+// writePuppeteerRunner materializes the built-in runner as a temporary file.
+func writePuppeteerRunner() (string, error) {
 	dir := os.TempDir()
-	path := filepath.Join(dir, fmt.Sprintf("puppeteer-runner-%d.js", time.Now().UnixNano()))
-	if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+	// .cjs keeps the CommonJS runner loadable under deno, which treats .js
+	// files as ES modules.
+	path := filepath.Join(dir, fmt.Sprintf("puppeteer-runner-%d.cjs", time.Now().UnixNano()))
+	if err := os.WriteFile(path, []byte(puppeteerRunnerScript), 0o600); err != nil {
 		return "", err
 	}
 	return path, nil
@@ -418,116 +1009,122 @@ func lookupHeaderValue(hdrs map[string]string, name string) string {
 	return ""
 }
 
-// LaunchMPVWithHeaders spawns mpv to play the given M3U8 URL using the minimal
-// header set required for successful playback (User-Agent, Origin, Referer).
-// When attachOutput is true, mpv stays attached to the current terminal and the
-// call blocks until the player exits; otherwise mpv is started quietly and
-// detached so closing the terminal will not terminate playback. Logs are
-// streamed via the provided callback.
-func LaunchMPVWithHeaders(m3u8 string, hdrs map[string]string, log func(string), attachOutput bool) error {
-	if log == nil {
-		log = func(string) {}
+// RunExtractorCLI provides a non-TUI entry point to run the extractor directly
+// from the command line ("-e <embedURL>"). When opts.Debug is true, verbose
+// output from the extractor and mpv launch is printed to stdout. With
+// opts.JSON the result is printed as JSON instead of being played, and the
+// human-readable lines go to stderr.
+func RunExtractorCLI(embedURL string, opts Options) error {
+	if strings.TrimSpace(embedURL) == "" {
+		return errors.New("missing embed URL")
 	}
-	if m3u8 == "" {
-		return fmt.Errorf("empty m3u8 URL")
-	}
-
-	args := []string{}
-	if !attachOutput {
-		args = append(args, "--no-terminal", "--really-quiet")
-	}
-
-	// Only forward the minimal headers mpv requires to mirror the working
-	// curl→mpv handoff: User-Agent, Origin, and Referer. Extra headers
-	// captured in the browser session can cause mpv to reject the request
-	// or send malformed values when duplicated, so we constrain the set
-	// explicitly and tolerate case-insensitive keys from Puppeteer.
-	headerKeys := []struct {
-		lookup  string
-		display string
-	}{
-		{lookup: "user-agent", display: "User-Agent"},
-		{lookup: "origin", display: "Origin"},
-		{lookup: "referer", display: "Referer"},
-	}
-	headerCount := 0
-	for _, hk := range headerKeys {
-		if v := lookupHeaderValue(hdrs, hk.lookup); v != "" {
-			args = append(args, fmt.Sprintf("--http-header-fields=%s: %s", hk.display, v))
-			headerCount++
-		}
+	return extractAndPlay([]string{embedURL}, "", extractM3U8, opts.withDefaults())
+}
+
+// extractAndPlay extracts the first of embedURLs that works and hands it to
+// the player, or prints it with opts.JSON.
+func extractAndPlay(embedURLs []string, title string, extract extractFunc, opts Options) error {
+	debug := opts.Debug
+	out := io.Writer(os.Stdout)
+	if opts.JSON {
+		out = os.Stderr
 	}
 
-	args = append(args, m3u8)
-	log(fmt.Sprintf("[mpv] launching with %d headers: %s", headerCount, m3u8))
+	logger := func(string) {}
+	if debug {
+		logger = func(line string) { fmt.Fprintln(out, line) }
+	}
 
-	cmd := exec.Command("mpv", args...)
+	extractOpts := opts.extractOptions()
+	if debug {
+		extractOpts.OnPhase = func(phase string) { fmt.Fprintf(out, "[extractor] %s…\n", phase) }
+	}
 
-	if attachOutput {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-	} else {
-		// Detach from the current terminal so closing it will not send
-		// SIGHUP to mpv. Discard stdio to avoid keeping the tty open.
-		devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
-		if err != nil {
-			return fmt.Errorf("open devnull: %w", err)
+	var player Player
+	if !opts.JSON {
+		var err error
+		if player, err = opts.newPlayer(); err != nil {
+			return err
+		}
+		if err := opts.checkPlayer(); err != nil {
+			return err
 		}
-		cmd.Stdin = devNull
-		cmd.Stdout = devNull
-		cmd.Stderr = devNull
-		cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
 	}
-
-	if err := cmd.Start(); err != nil {
-		log(fmt.Sprintf("[mpv] launch error: %v", err))
+	if err := opts.checkQuality(); err != nil {
+		return err
+	}
+	if err := opts.checkProxy(); err != nil {
 		return err
 	}
 
-	if attachOutput {
-		log("[mpv] started (attached)")
-		if err := cmd.Wait(); err != nil {
-			log(fmt.Sprintf("[mpv] exited with error: %v", err))
-			return err
+	var (
+		embedURL string
+		res      extractResult
+		err      error
+	)
+	for i, u := range embedURLs {
+		if i > 0 {
+			fmt.Fprintf(out, "[fallback] trying the next stream, attempt %d/%d\n", i+1, len(embedURLs))
 		}
-		log("[mpv] exited")
-		return nil
+		embedURL = u
+		fmt.Fprintf(out, "[extractor] starting for %s\n", embedURL)
+		if res, err = extract(context.Background(), embedURL, extractOpts, logger); err == nil {
+			break
+		}
+		fmt.Fprintf(out, "[extractor] ❌ %v\n", err)
+	}
+	if err != nil {
+		if opts.JSON {
+			_ = writeJSON(os.Stdout, newExtractJSON(embedURL, res, err))
+		}
+		return err
 	}
 
-	log(fmt.Sprintf("[mpv] started (pid %d)", cmd.Process.Pid))
-	return nil
-}
-
-// RunExtractorCLI provides a non-TUI entry point to run the extractor directly
-// from the command line ("-e <embedURL>"). When debug is true, verbose output
-// from the Puppeteer runner and mpv launch is printed to stdout.
-func RunExtractorCLI(embedURL string, debug bool) error {
-	if strings.TrimSpace(embedURL) == "" {
-		return errors.New("missing embed URL")
+	fmt.Fprintf(out, "[extractor] ✅ found M3U8 via %s in %s: %s\n", res.Backend, formatElapsed(res.Elapsed), res.URL)
+	if len(res.Headers) > 0 && debug {
+		fmt.Fprintf(out, "[extractor] captured %d headers\n", len(res.Headers))
 	}
 
-	logger := func(string) {}
-	if debug {
-		logger = func(line string) { fmt.Println(line) }
+	var probe *probeJSON
+	if opts.probeEnabled() {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.APITimeout)
+		pr := probePlaylist(ctx, extractOpts.httpClient(), res.URL, res.Headers)
+		cancel()
+		probe = newProbeJSON(pr)
+		if !pr.ok() {
+			fmt.Fprintf(out, "[probe] ❌ %s\n", pr)
+			err := fmt.Errorf("the playlist looks dead: %s", pr)
+			if opts.JSON {
+				out := newExtractJSON(embedURL, res, err)
+				out.Probe = probe
+				_ = writeJSON(os.Stdout, out)
+			}
+			return err
+		}
+		fmt.Fprintf(out, "[probe] playlist answered %s\n", pr)
 	}
 
-	fmt.Printf("[extractor] starting for %s\n", embedURL)
-	m3u8, hdrs, err := extractM3U8Lite(embedURL, logger)
-	if err != nil {
-		fmt.Printf("[extractor] ❌ %v\n", err)
-		return err
+	// There is no picker outside the TUI, so "ask" leaves it to the player.
+	if q := strings.ToLower(unattendedQuality(opts.Quality)); q != qualityAuto {
+		p := pendingLaunch{Result: res}
+		ctx, cancel := context.WithTimeout(context.Background(), opts.APITimeout)
+		selectVariant(ctx, extractOpts.httpClient(), q, &p, func(line string) { fmt.Fprintln(out, line) })
+		cancel()
+		res = p.Result
 	}
 
-	fmt.Printf("[extractor] ✅ found M3U8: %s\n", m3u8)
-	if len(hdrs) > 0 && debug {
-		fmt.Printf("[extractor] captured %d headers\n", len(hdrs))
+	if opts.JSON {
+		out := newExtractJSON(embedURL, res, nil)
+		out.Probe = probe
+		return writeJSON(os.Stdout, out)
 	}
 
-	if err := LaunchMPVWithHeaders(m3u8, hdrs, logger, false); err != nil {
-		fmt.Printf("[mpv] ❌ %v\n", err)
+	req := playRequest{URL: res.URL, Headers: res.Headers, Fullscreen: opts.Fullscreen, Title: title, Proxy: extractOpts.Proxy}
+	if err := player.Launch(req, logger); err != nil {
+		fmt.Printf("[%s] ❌ %v\n", player.Name(), err)
 		return err
 	}
 
-	fmt.Println("[mpv] ▶ streaming started (detached)")
+	fmt.Printf("[%s] ▶ streaming started (detached)\n", player.Name())
 	return nil
 }
@@ -0,0 +1,390 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ────────────────────────────────
+// EXTRACTOR BACKENDS
+// ────────────────────────────────
+
+// Extractor is one way of resolving an embed URL to a playable stream.
+// Backends are tried in the order configured by Options.Extractors until one
+// succeeds.
+type Extractor interface {
+	Name() string
+	Extract(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error)
+}
+
+// extractResult is a resolved stream plus how it was found. Backend,
+// Elapsed, and Attempts are filled in by extractM3U8.
+type extractResult struct {
+	URL     string
+	Headers map[string]string
+
+	Backend  string
+	Elapsed  time.Duration
+	Attempts []extractAttempt
+}
+
+// extractAttempt is one backend's try; Err is nil for the one that worked.
+type extractAttempt struct {
+	Backend string
+	Elapsed time.Duration
+	Err     error
+}
+
+// Backend names accepted in Options.Extractors.
+const (
+	backendRegex     = "regex"
+	backendChromedp  = "chromedp"
+	backendPuppeteer = "puppeteer"
+	backendYtDlp     = "yt-dlp"
+)
+
+// extractorBackends lists every registered backend.
+var extractorBackends = []Extractor{
+	regexExtractor{},
+	chromedpExtractor{},
+	puppeteerExtractor{},
+	ytDlpExtractor{},
+}
+
+// defaultExtractors is the order used when none is configured: the cheap
+// HTTP attempt first, since launching Chromium adds several seconds.
+var defaultExtractors = []string{backendRegex, backendChromedp, backendPuppeteer}
+
+// budgeted is implemented by backends that need less than the full
+// per-attempt deadline.
+type budgeted interface {
+	budget(opts extractOptions) time.Duration
+}
+
+func lookupExtractor(name string) (Extractor, bool) {
+	for _, e := range extractorBackends {
+		if strings.EqualFold(e.Name(), name) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+func extractorNames() []string {
+	names := make([]string, len(extractorBackends))
+	for i, e := range extractorBackends {
+		names[i] = e.Name()
+	}
+	return names
+}
+
+// extractM3U8 tries the configured backends in order, each with its own
+// deadline, and returns the first stream found. A custom runner script only
+// makes sense under Puppeteer, so setting one skips the other backends.
+func extractM3U8(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error) {
+	if log == nil {
+		log = func(string) {}
+	}
+	if strings.TrimSpace(embedURL) == "" {
+		return extractResult{}, errors.New("empty embed URL")
+	}
+
+	order := opts.Backends
+	if len(order) == 0 {
+		order = defaultExtractors
+	}
+	if opts.RunnerPath != "" {
+		order = []string{backendPuppeteer}
+	}
+	backends := make([]Extractor, 0, len(order))
+	for _, name := range order {
+		e, ok := lookupExtractor(name)
+		if !ok {
+			return extractResult{}, fmt.Errorf("unknown extractor %q (want one of %s)", name, strings.Join(extractorNames(), ", "))
+		}
+		backends = append(backends, e)
+	}
+
+	var attempts []extractAttempt
+	start := time.Now()
+	for i, e := range backends {
+		if err := ctx.Err(); err != nil {
+			return extractResult{Attempts: attempts}, err
+		}
+		timeout := opts.deadline()
+		if b, ok := e.(budgeted); ok {
+			timeout = b.budget(opts)
+		}
+		attemptOpts := opts
+		if opts.OnPhase != nil && len(backends) > 1 {
+			// Say which backend is at work, e.g. "chromedp (2/3): …".
+			attemptOpts.OnPhase = func(phase string) {
+				opts.OnPhase(fmt.Sprintf("%s (%d/%d): %s", e.Name(), i+1, len(backends), phase))
+			}
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		began := time.Now()
+		res, err := e.Extract(attemptCtx, embedURL, attemptOpts, log)
+		cancel()
+		if err == nil && res.URL == "" {
+			err = errors.New("m3u8 not found")
+		}
+		attempt := extractAttempt{Backend: e.Name(), Elapsed: time.Since(began), Err: err}
+		attempts = append(attempts, attempt)
+		if err != nil {
+			log(fmt.Sprintf("[extractor] %s failed after %s: %v", e.Name(), formatElapsed(attempt.Elapsed), err))
+			continue
+		}
+		log(fmt.Sprintf("[extractor] %s found the stream in %s", e.Name(), formatElapsed(attempt.Elapsed)))
+		if domain, ok := opts.HeaderProfiles.apply(embedURL, &res); ok {
+			log(fmt.Sprintf("[extractor] using the %s header profile", domain))
+		}
+		res.Backend = e.Name()
+		res.Elapsed = time.Since(start)
+		res.Attempts = attempts
+		return res, nil
+	}
+	if len(attempts) == 1 {
+		return extractResult{Attempts: attempts}, attempts[0].Err
+	}
+	return extractResult{Attempts: attempts}, fmt.Errorf("no extractor found a stream (tried %s)", summarizeAttempts(attempts))
+}
+
+// summarizeAttempts renders attempts as "chromedp 1.2s, puppeteer 9.8s".
+func summarizeAttempts(attempts []extractAttempt) string {
+	parts := make([]string, len(attempts))
+	for i, a := range attempts {
+		parts[i] = fmt.Sprintf("%s %s", a.Backend, formatElapsed(a.Elapsed))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ────────────────────────────────
+// HEADLESS BROWSERS
+// ────────────────────────────────
+
+type chromedpExtractor struct{}
+
+func (chromedpExtractor) Name() string { return backendChromedp }
+
+func (chromedpExtractor) Extract(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error) {
+	m3u8, hdrs, err := extractM3U8Chromedp(ctx, embedURL, opts, log)
+	return extractResult{URL: m3u8, Headers: hdrs}, err
+}
+
+type puppeteerExtractor struct{}
+
+func (puppeteerExtractor) Name() string { return backendPuppeteer }
+
+func (puppeteerExtractor) Extract(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error) {
+	m3u8, hdrs, err := extractM3U8Lite(ctx, embedURL, opts, log)
+	return extractResult{URL: m3u8, Headers: hdrs}, err
+}
+
+// ────────────────────────────────
+// REGEX OVER HTML
+// ────────────────────────────────
+
+// regexExtractor fetches the embed page over plain HTTP and looks for a
+// playlist URL in the HTML, following one level of iframes. It only works
+// for pages that do not build the URL in JavaScript, but costs a couple of
+// requests instead of a browser launch.
+type regexExtractor struct{}
+
+const (
+	// maxEmbedPageSize bounds how much of an embed page is read.
+	maxEmbedPageSize = 4 << 20
+	// regexBudget caps the HTTP attempt so a slow host falls through to the
+	// browser backends quickly.
+	regexBudget = 8 * time.Second
+)
+
+var (
+	m3u8URLPattern = regexp.MustCompile(`https?:(?:\\?/){2}[^'"\s<>]+?\.m3u8[^'"\s<>]*`)
+	iframePattern  = regexp.MustCompile(`(?i)<iframe[^>]+src=["']([^"']+)["']`)
+)
+
+func (regexExtractor) Name() string { return backendRegex }
+
+func (regexExtractor) budget(opts extractOptions) time.Duration {
+	return min(regexBudget, opts.deadline())
+}
+
+func (regexExtractor) Extract(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error) {
+	opts.phase("fetching embed page")
+	client := opts.httpClient()
+	page, err := fetchEmbedPage(ctx, client, embedURL, "")
+	if err != nil {
+		return extractResult{}, err
+	}
+	pageURL := embedURL
+	m3u8 := findM3U8URL(page)
+	if m3u8 == "" {
+		if src := iframePattern.FindStringSubmatch(page); src != nil {
+			frameURL, err := resolveRef(embedURL, html.UnescapeString(src[1]))
+			if err != nil {
+				return extractResult{}, err
+			}
+			log("[regex] following iframe " + frameURL)
+			if page, err = fetchEmbedPage(ctx, client, frameURL, embedURL); err != nil {
+				return extractResult{}, err
+			}
+			pageURL = frameURL
+			m3u8 = findM3U8URL(page)
+		}
+	}
+	if m3u8 == "" {
+		return extractResult{}, errors.New("no .m3u8 URL in page HTML")
+	}
+	log("[regex] found .m3u8 in page HTML: " + m3u8)
+
+	hdrs := map[string]string{
+		"user-agent": chromeUserAgent,
+		"referer":    pageURL,
+	}
+	if u, err := url.Parse(pageURL); err == nil {
+		hdrs["origin"] = u.Scheme + "://" + u.Host
+	}
+
+	// URLs left in the HTML are often stale or token-less; only skip the
+	// browser when the playlist actually loads.
+	opts.phase("checking playlist")
+	if err := checkPlaylist(ctx, client, m3u8, hdrs); err != nil {
+		return extractResult{}, err
+	}
+	return extractResult{URL: m3u8, Headers: hdrs}, nil
+}
+
+// checkPlaylist fetches a playlist with the headers mpv will send and
+// verifies it is an HLS playlist.
+func checkPlaylist(ctx context.Context, client *http.Client, playlistURL string, hdrs map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, playlistURL, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range hdrs {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("check playlist: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("check playlist: %s", resp.Status)
+	}
+	head := make([]byte, 64)
+	n, _ := io.ReadFull(resp.Body, head)
+	if !strings.HasPrefix(strings.TrimSpace(string(head[:n])), "#EXTM3U") {
+		return errors.New("check playlist: response is not an HLS playlist")
+	}
+	return nil
+}
+
+// fetchEmbedPage GETs a page the way a browser would.
+func fetchEmbedPage(ctx context.Context, client *http.Client, pageURL, referer string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", chromeUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	if referer != "" {
+		req.Header.Set("Referer", referer)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch %s: %w", pageURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch %s: %s", pageURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxEmbedPageSize))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", pageURL, err)
+	}
+	return string(body), nil
+}
+
+// findM3U8URL returns the first playlist URL in a page, undoing JSON and
+// HTML escaping.
+func findM3U8URL(page string) string {
+	match := m3u8URLPattern.FindString(page)
+	if match == "" {
+		return ""
+	}
+	match = strings.ReplaceAll(match, `\/`, "/")
+	return html.UnescapeString(match)
+}
+
+func resolveRef(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := b.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// ────────────────────────────────
+// YT-DLP
+// ────────────────────────────────
+
+// ytDlpExtractor asks an installed yt-dlp for the stream URL and the HTTP
+// headers it would use, which covers hosts yt-dlp has extractors for.
+type ytDlpExtractor struct{}
+
+func (ytDlpExtractor) Name() string { return backendYtDlp }
+
+func (ytDlpExtractor) Extract(ctx context.Context, embedURL string, opts extractOptions, log func(string)) (extractResult, error) {
+	path, err := exec.LookPath("yt-dlp")
+	if err != nil {
+		return extractResult{}, errors.New("yt-dlp is not installed")
+	}
+	opts.phase("asking yt-dlp")
+	log(fmt.Sprintf("[yt-dlp] resolving %s", embedURL))
+	args := []string{
+		"--dump-json", "--no-playlist", "--no-warnings",
+		"--format", "best[protocol^=m3u8]/best",
+	}
+	if opts.Proxy != "" {
+		args = append(args, "--proxy", opts.Proxy)
+	}
+	cmd := exec.CommandContext(ctx, path, append(args, embedURL)...)
+	stderr := &strings.Builder{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return extractResult{}, fmt.Errorf("yt-dlp timed out after %s", opts.deadline())
+		}
+		return extractResult{}, fmt.Errorf("yt-dlp: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var info struct {
+		URL         string            `json:"url"`
+		HTTPHeaders map[string]string `json:"http_headers"`
+	}
+	if err := json.Unmarshal(out, &info); err != nil {
+		return extractResult{}, fmt.Errorf("decode yt-dlp output: %w", err)
+	}
+	hdrs := make(map[string]string, len(info.HTTPHeaders))
+	for k, v := range info.HTTPHeaders {
+		hdrs[strings.ToLower(k)] = v
+	}
+	return extractResult{URL: info.URL, Headers: hdrs}, nil
+}
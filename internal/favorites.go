@@ -0,0 +1,298 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// FAVORITES
+// ────────────────────────────────
+
+// favoritesSportID is the pseudo-sport that lists starred matches and every
+// match involving a starred team.
+const favoritesSportID = "favorites"
+
+// favoriteMatchRetention is how long a starred match is kept after it
+// started.
+const favoriteMatchRetention = 24 * time.Hour
+
+// favoriteMatch is a starred match. Title and Date are kept so the file is
+// readable and old entries can be pruned.
+type favoriteMatch struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Date  int64  `json:"date"`
+}
+
+type favoritesFile struct {
+	Matches []favoriteMatch `json:"matches"`
+	Teams   []string        `json:"teams"`
+}
+
+// favoritesStore persists starred matches and teams as JSON in the data
+// directory. The match renderer reads it while drawing, and the favorites
+// fetch filters with it in the background, so access is serialized.
+type favoritesStore struct {
+	mu   sync.Mutex
+	path string
+	data favoritesFile
+}
+
+// openFavoritesStore loads favorites.json. A missing file yields an empty
+// store; the path is empty (memory only) when the directory cannot be
+// created.
+func openFavoritesStore() (*favoritesStore, error) {
+	s := &favoritesStore{}
+	dir, err := ensureAppDir(dataDir)
+	if err != nil {
+		return s, err
+	}
+	s.path = filepath.Join(dir, "favorites.json")
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.data); err != nil {
+		return s, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// teamKey normalizes team names so "Arsenal" and " arsenal " match.
+func teamKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// matchTeams returns the names of a match's teams that are present.
+func matchTeams(mt Match) []string {
+	if mt.Teams == nil {
+		return nil
+	}
+	var names []string
+	for _, t := range []*Team{mt.Teams.Home, mt.Teams.Away} {
+		if t != nil && strings.TrimSpace(t.Name) != "" {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// Starred reports whether the match itself is starred.
+func (s *favoritesStore) Starred(matchID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.starredLocked(matchID)
+}
+
+func (s *favoritesStore) starredLocked(matchID string) bool {
+	for _, f := range s.data.Matches {
+		if f.ID == matchID {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamStarred reports whether a team is starred.
+func (s *favoritesStore) TeamStarred(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.teamStarredLocked(name)
+}
+
+func (s *favoritesStore) teamStarredLocked(name string) bool {
+	key := teamKey(name)
+	for _, t := range s.data.Teams {
+		if teamKey(t) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether nothing is starred.
+func (s *favoritesStore) Empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.data.Matches) == 0 && len(s.data.Teams) == 0
+}
+
+// Matches reports whether a match belongs in Favorites: it is starred itself
+// or one of its teams is.
+func (s *favoritesStore) Matches(mt Match) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.starredLocked(mt.ID) {
+		return true
+	}
+	for _, name := range matchTeams(mt) {
+		if s.teamStarredLocked(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleMatch stars or unstars a match and reports whether it is now starred.
+func (s *favoritesStore) ToggleMatch(mt Match) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.starredLocked(mt.ID) {
+		kept := s.data.Matches[:0]
+		for _, f := range s.data.Matches {
+			if f.ID != mt.ID {
+				kept = append(kept, f)
+			}
+		}
+		s.data.Matches = kept
+		return false, s.saveLocked()
+	}
+	s.data.Matches = append(s.data.Matches, favoriteMatch{ID: mt.ID, Title: matchDisplayTitle(mt), Date: mt.Date})
+	return true, s.saveLocked()
+}
+
+// ToggleTeam stars or unstars a team and reports whether it is now starred.
+func (s *favoritesStore) ToggleTeam(name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.teamStarredLocked(name) {
+		key := teamKey(name)
+		kept := s.data.Teams[:0]
+		for _, t := range s.data.Teams {
+			if teamKey(t) != key {
+				kept = append(kept, t)
+			}
+		}
+		s.data.Teams = kept
+		return false, s.saveLocked()
+	}
+	s.data.Teams = append(s.data.Teams, strings.TrimSpace(name))
+	sort.Strings(s.data.Teams)
+	return true, s.saveLocked()
+}
+
+// Prune drops starred matches that started before cutoff. Starred teams are
+// kept until unstarred.
+func (s *favoritesStore) Prune(cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.data.Matches[:0]
+	for _, f := range s.data.Matches {
+		if f.Date == 0 || !time.UnixMilli(f.Date).Before(cutoff) {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == len(s.data.Matches) {
+		return nil
+	}
+	s.data.Matches = kept
+	return s.saveLocked()
+}
+
+func (s *favoritesStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// prependFavoritesSport puts the Favorites pseudo-sport at the top of the
+// sports list.
+func prependFavoritesSport(sports []Sport) []Sport {
+	for _, s := range sports {
+		if strings.EqualFold(s.ID, favoritesSportID) {
+			return sports
+		}
+	}
+	return append([]Sport{{ID: favoritesSportID, Name: "★ Favorites"}}, sports...)
+}
+
+// fetchFavoriteMatches loads every sport's matches and keeps the favorites.
+func (m Model) fetchFavoriteMatches(fresh bool) tea.Cmd {
+	provider := m.provider
+	favs := m.favorites
+	return safeCmd("fetch favorites", m.crash, func() tea.Msg {
+		if err := favs.Prune(time.Now().Add(-favoriteMatchRetention)); err != nil {
+			return errorMsg(fmt.Errorf("prune favorites: %w", err))
+		}
+		entries, _, err := loadAllMatches(apiContext(fresh), provider)
+		if err != nil {
+			return errorMsg(err)
+		}
+		var matches []Match
+		for _, e := range entries {
+			if favs.Matches(e.Match) {
+				matches = append(matches, e.Match)
+			}
+		}
+		return matchesLoadedMsg{SportID: favoritesSportID, Matches: matches, Title: "Favorites"}
+	})
+}
+
+// toggleFavoriteMatch stars or unstars the selected match.
+func (m *Model) toggleFavoriteMatch() tea.Cmd {
+	mt, ok := m.matches.Selected()
+	if !ok {
+		return nil
+	}
+	starred, err := m.favorites.ToggleMatch(mt)
+	if err != nil {
+		m.lastError = fmt.Errorf("save favorites: %w", err)
+	}
+	m.matches.Invalidate()
+	if starred {
+		m.status = fmt.Sprintf("★ Starred %s", matchDisplayTitle(mt))
+	} else {
+		m.status = fmt.Sprintf("Unstarred %s", matchDisplayTitle(mt))
+	}
+	return nil
+}
+
+// toggleFavoriteTeam stars or unstars the selected match's home (away=false)
+// or away team.
+func (m *Model) toggleFavoriteTeam(away bool) tea.Cmd {
+	mt, ok := m.matches.Selected()
+	if !ok {
+		return nil
+	}
+	var team *Team
+	if mt.Teams != nil {
+		team = mt.Teams.Home
+		if away {
+			team = mt.Teams.Away
+		}
+	}
+	if team == nil || strings.TrimSpace(team.Name) == "" {
+		m.status = "This match has no team to star"
+		return nil
+	}
+	starred, err := m.favorites.ToggleTeam(team.Name)
+	if err != nil {
+		m.lastError = fmt.Errorf("save favorites: %w", err)
+	}
+	m.matches.Invalidate()
+	if starred {
+		m.status = fmt.Sprintf("★ Starred team %s", team.Name)
+	} else {
+		m.status = fmt.Sprintf("Unstarred team %s", team.Name)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// COLUMN FILTER
+// ────────────────────────────────
+
+func newFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "filter"
+	ti.CharLimit = 64
+	return ti
+}
+
+// startFilter opens the filter input for the focused column, prefilled with
+// its current query.
+func (m *Model) startFilter() tea.Cmd {
+	m.filtering = true
+	m.filterInput.SetValue(m.focusedFilter())
+	m.filterInput.CursorEnd()
+	return m.filterInput.Focus()
+}
+
+// updateFilter handles keys while the filter input is open: typing narrows
+// the focused column live, Enter keeps the filter, and Esc clears it.
+func (m *Model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+		m.filterInput.Blur()
+		return nil
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filterInput.Blur()
+		m.filterInput.SetValue("")
+		m.setFocusedFilter("")
+		return nil
+	case tea.KeyUp, tea.KeyDown:
+		// Arrow keys move the cursor without closing the filter.
+		return m.moveFocusedCursor(msg.Type == tea.KeyUp)
+	}
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	m.setFocusedFilter(m.filterInput.Value())
+	return cmd
+}
+
+func (m *Model) moveFocusedCursor(up bool) tea.Cmd {
+	switch m.focus {
+	case focusSports:
+		if up {
+			m.sports.CursorUp()
+		} else {
+			m.sports.CursorDown()
+		}
+		return m.scheduleMatchPrefetch()
+	case focusMatches:
+		if up {
+			m.matches.CursorUp()
+		} else {
+			m.matches.CursorDown()
+		}
+		return m.scheduleStreamPrefetch()
+	case focusStreams:
+		if up {
+			m.streams.CursorUp()
+		} else {
+			m.streams.CursorDown()
+		}
+	}
+	return nil
+}
+
+func (m Model) focusedFilter() string {
+	switch m.focus {
+	case focusSports:
+		return m.sports.Filter()
+	case focusMatches:
+		return m.matches.Filter()
+	case focusStreams:
+		return m.streams.Filter()
+	}
+	return ""
+}
+
+func (m *Model) setFocusedFilter(query string) {
+	switch m.focus {
+	case focusSports:
+		m.sports.SetFilter(query)
+	case focusMatches:
+		m.matches.SetFilter(query)
+	case focusStreams:
+		m.streams.SetFilter(query)
+	}
+}
@@ -0,0 +1,493 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/color/palette"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ────────────────────────────────
+// TERMINAL GRAPHICS
+// ────────────────────────────────
+
+// graphicsProtocol is how images reach the terminal.
+type graphicsProtocol int
+
+const (
+	graphicsNone graphicsProtocol = iota
+	graphicsKitty
+	graphicsITerm2
+	graphicsSixel
+)
+
+const (
+	imagesAuto   = "auto"
+	imagesOff    = "off"
+	imagesKitty  = "kitty"
+	imagesITerm2 = "iterm2"
+	imagesSixel  = "sixel"
+)
+
+// checkImages rejects image settings that are not understood.
+func (o Options) checkImages() error {
+	switch strings.ToLower(strings.TrimSpace(o.Images)) {
+	case "", imagesAuto, imagesOff, imagesKitty, imagesITerm2, imagesSixel:
+		return nil
+	}
+	return fmt.Errorf("unknown images setting %q (want auto, off, kitty, iterm2, or sixel)", o.Images)
+}
+
+// graphicsFor resolves the images setting. "auto" only picks a protocol for
+// terminals known to support it, and none inside tmux or screen, which do
+// not pass images through.
+func graphicsFor(setting string, getenv func(string) string) graphicsProtocol {
+	switch strings.ToLower(strings.TrimSpace(setting)) {
+	case imagesOff:
+		return graphicsNone
+	case imagesKitty:
+		return graphicsKitty
+	case imagesITerm2:
+		return graphicsITerm2
+	case imagesSixel:
+		return graphicsSixel
+	}
+	if getenv("TMUX") != "" || getenv("STY") != "" {
+		return graphicsNone
+	}
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+	switch {
+	case term == "xterm-kitty", getenv("KITTY_WINDOW_ID") != "", term == "xterm-ghostty", program == "ghostty":
+		return graphicsKitty
+	case program == "iTerm.app", program == "WezTerm", program == "mintty", getenv("LC_TERMINAL") == "iTerm2":
+		return graphicsITerm2
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.HasPrefix(term, "contour"):
+		return graphicsSixel
+	}
+	return graphicsNone
+}
+
+// cellSize is the size of a terminal cell in pixels.
+type cellSize struct{ W, H int }
+
+// fallbackCellSize is assumed when the terminal does not report its pixel
+// size.
+var fallbackCellSize = cellSize{W: 10, H: 20}
+
+func currentCellSize() cellSize {
+	if c, ok := terminalCellSize(); ok {
+		return c
+	}
+	return fallbackCellSize
+}
+
+const (
+	// artMaxRows caps the height of the picture in the details column.
+	artMaxRows = 10
+	// badgeSize is the pixel size badges are composited at.
+	badgeSize = 128
+	// maxImageBytes bounds a single poster or badge download.
+	maxImageBytes = 4 << 20
+)
+
+// artSource is the decoded picture for a match, or why there is none.
+type artSource struct {
+	img image.Image
+	err error
+}
+
+type artKey struct {
+	id         string
+	cols, rows int
+}
+
+// artRender is a picture encoded for one protocol at one size.
+type artRender struct {
+	cols, rows int
+	// lines are the text rows the picture occupies: kitty placeholders, or
+	// blank rows the other protocols draw over.
+	lines []string
+	// seq transmits or draws the picture.
+	seq string
+}
+
+// artCache holds match pictures by match ID and their encodings by size. View
+// fills in encodings as it needs them, so it is shared between model copies
+// and safe for concurrent use.
+type artCache struct {
+	proto graphicsProtocol
+
+	mu      sync.Mutex
+	cell    cellSize
+	sources map[string]artSource
+	loading map[string]bool
+	renders map[artKey]*artRender
+}
+
+func newArtCache(proto graphicsProtocol) *artCache {
+	return &artCache{
+		proto:   proto,
+		cell:    currentCellSize(),
+		sources: map[string]artSource{},
+		loading: map[string]bool{},
+		renders: map[artKey]*artRender{},
+	}
+}
+
+func (a *artCache) enabled() bool { return a != nil && a.proto != graphicsNone }
+
+// resize re-reads the cell size, which changes with the font, and drops
+// encodings made for the old one.
+func (a *artCache) resize() {
+	if !a.enabled() {
+		return
+	}
+	cell := currentCellSize()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if cell != a.cell {
+		a.cell = cell
+		a.renders = map[artKey]*artRender{}
+	}
+}
+
+type artLoadedMsg struct {
+	MatchID string
+	Img     image.Image
+	Err     error
+}
+
+// loadMatchArt fetches the picture for the highlighted match when the details
+// column is shown and it has not been fetched yet.
+func (m *Model) loadMatchArt() tea.Cmd {
+	if !m.art.enabled() || !m.showDetails {
+		return nil
+	}
+	mt, ok := m.matches.Selected()
+	if !ok {
+		return nil
+	}
+	a := m.art
+	a.mu.Lock()
+	_, done := a.sources[mt.ID]
+	if done || a.loading[mt.ID] {
+		a.mu.Unlock()
+		return nil
+	}
+	a.loading[mt.ID] = true
+	a.mu.Unlock()
+
+	client := m.artClient(mt)
+	return safeCmd("load match art", m.crash, func() tea.Msg {
+		img, err := fetchMatchArt(context.Background(), client, mt)
+		return artLoadedMsg{MatchID: mt.ID, Img: img, Err: err}
+	})
+}
+
+// storeMatchArt records a fetched picture. Matches without one are
+// remembered too, so they are not asked for again.
+func (m *Model) storeMatchArt(msg artLoadedMsg) {
+	a := m.art
+	a.mu.Lock()
+	delete(a.loading, msg.MatchID)
+	a.sources[msg.MatchID] = artSource{img: msg.Img, err: msg.Err}
+	a.mu.Unlock()
+	if msg.Err != nil {
+		m.logToUI(fmt.Sprintf("[art] no picture for match %s: %v", msg.MatchID, msg.Err))
+	}
+}
+
+// artClient returns the client whose API serves mt's poster and badges:
+// that of the provider that listed it when it speaks the streamed API, and
+// the primary one otherwise.
+func (m Model) artClient(mt Match) *Client {
+	if c, ok := providerFor(m.provider, mt).(*Client); ok {
+		return c
+	}
+	return m.apiClient
+}
+
+// fetchMatchArt returns the poster of a match, or its two team badges side
+// by side when it has no poster.
+func fetchMatchArt(ctx context.Context, client *Client, mt Match) (image.Image, error) {
+	if mt.Poster != "" {
+		return client.FetchImage(ctx, client.PosterURL(mt))
+	}
+	if mt.Teams == nil || mt.Teams.Home == nil || mt.Teams.Away == nil ||
+		mt.Teams.Home.Badge == "" || mt.Teams.Away.Badge == "" {
+		return nil, nil
+	}
+	home, err := client.FetchImage(ctx, client.BadgeURL(*mt.Teams.Home))
+	if err != nil {
+		return nil, err
+	}
+	away, err := client.FetchImage(ctx, client.BadgeURL(*mt.Teams.Away))
+	if err != nil {
+		return nil, err
+	}
+	return joinBadges(home, away), nil
+}
+
+// joinBadges puts two badges side by side, each scaled to the same square.
+func joinBadges(home, away image.Image) image.Image {
+	gap := badgeSize / 4
+	out := image.NewRGBA(image.Rect(0, 0, 2*badgeSize+gap, badgeSize))
+	for i, badge := range []image.Image{home, away} {
+		box := fitRect(badge.Bounds().Dx(), badge.Bounds().Dy(), badgeSize, badgeSize)
+		x := i*(badgeSize+gap) + (badgeSize-box.Dx())/2
+		y := (badgeSize - box.Dy()) / 2
+		draw.CatmullRom.Scale(out, box.Add(image.Pt(x, y)), badge, badge.Bounds(), draw.Over, nil)
+	}
+	return out
+}
+
+// fitRect scales w×h to fit inside maxW×maxH, keeping the aspect ratio.
+func fitRect(w, h, maxW, maxH int) image.Rectangle {
+	if w <= 0 || h <= 0 {
+		return image.Rectangle{}
+	}
+	if w*maxH > h*maxW {
+		return image.Rect(0, 0, maxW, max(h*maxW/w, 1))
+	}
+	return image.Rect(0, 0, max(w*maxH/h, 1), maxH)
+}
+
+// matchArt returns the picture for a match encoded to fit maxCols×maxRows
+// cells, or nil while it is loading or when there is none.
+func (a *artCache) matchArt(matchID string, maxCols, maxRows int) *artRender {
+	if !a.enabled() || maxCols <= 0 || maxRows <= 0 {
+		return nil
+	}
+	key := artKey{id: matchID, cols: maxCols, rows: maxRows}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if r, ok := a.renders[key]; ok {
+		return r
+	}
+	src, ok := a.sources[matchID]
+	if !ok || src.img == nil {
+		return nil
+	}
+	b := src.img.Bounds()
+	box := fitRect(b.Dx(), b.Dy(), maxCols*a.cell.W, maxRows*a.cell.H)
+	if box.Empty() {
+		return nil
+	}
+	scaled := image.NewRGBA(box)
+	draw.CatmullRom.Scale(scaled, box, src.img, b, draw.Src, nil)
+
+	r := &artRender{
+		cols: (box.Dx() + a.cell.W - 1) / a.cell.W,
+		rows: (box.Dy() + a.cell.H - 1) / a.cell.H,
+	}
+	switch a.proto {
+	case graphicsKitty:
+		id := kittyImageID(key)
+		r.seq = kittyTransmit(scaled, id, r.cols, r.rows)
+		r.lines = kittyPlaceholders(id, r.cols, r.rows)
+	case graphicsITerm2:
+		r.seq = iterm2Image(scaled, r.cols, r.rows)
+	case graphicsSixel:
+		r.seq = sixelImage(scaled)
+	}
+	if r.lines == nil {
+		r.lines = make([]string, r.rows)
+	}
+	a.renders[key] = r
+	return r
+}
+
+// place adds the escape sequence that shows art to the rendered main view.
+// Kitty only needs the image transmitted; its placeholders are already in
+// the text. The other protocols draw at an absolute position (top and left
+// are 0-based cells) from the last row of the picture. That line's sequence
+// also carries a checksum of the rows the picture covers, so whenever the
+// renderer repaints any of them it repaints the last one too, drawing the
+// picture again over the cleared rows.
+func (a *artCache) place(view string, art *artRender, top, left int) string {
+	if !a.enabled() || art == nil {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	last := top + art.rows - 1
+	if top < 0 || last >= len(lines) {
+		return view
+	}
+	switch a.proto {
+	case graphicsKitty:
+		lines[top] += art.seq
+	case graphicsITerm2, graphicsSixel:
+		h := fnv.New32a()
+		for _, l := range lines[top : last+1] {
+			h.Write([]byte(l))
+		}
+		lines[last] += fmt.Sprintf("\x1b7\x1b[%d;%dH%s\x1b[%dG\x1b8", top+1, left+1, art.seq, h.Sum32()%200+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func encodePNG(img image.Image) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+// kittyImageID derives a 24-bit image ID, which placeholders carry in their
+// foreground colour.
+func kittyImageID(key artKey) uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%d/%d", key.id, key.cols, key.rows)
+	return h.Sum32()&0xFFFFFF | 1
+}
+
+// kittyTransmit sends a PNG to kitty in 4096-byte chunks and creates a
+// virtual placement of cols×rows cells for the placeholders to show.
+func kittyTransmit(img image.Image, id uint32, cols, rows int) string {
+	data := base64.StdEncoding.EncodeToString(encodePNG(img))
+	var sb strings.Builder
+	for first := true; first || data != ""; first = false {
+		chunk := data[:min(len(data), 4096)]
+		data = data[len(chunk):]
+		more := 0
+		if data != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,U=1,f=100,t=d,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return sb.String()
+}
+
+// kittyPlaceholder is the character kitty replaces with image cells.
+const kittyPlaceholder = "\U0010EEEE"
+
+// kittyDiacritics encode row and column numbers on placeholders, from
+// kitty's rowcolumn-diacritics.txt.
+var kittyDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F,
+	0x0346, 0x034A, 0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357,
+	0x035B, 0x0363, 0x0364, 0x0365, 0x0366, 0x0367, 0x0368, 0x0369,
+}
+
+// kittyPlaceholders returns one line of placeholders per image row. Only the
+// first cell of a row names its row and column; kitty continues the rest.
+func kittyPlaceholders(id uint32, cols, rows int) []string {
+	rows = min(rows, len(kittyDiacritics))
+	colour := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", id>>16&0xFF, id>>8&0xFF, id&0xFF)
+	lines := make([]string, rows)
+	for r := range lines {
+		lines[r] = colour + kittyPlaceholder + string(kittyDiacritics[r]) + string(kittyDiacritics[0]) +
+			strings.Repeat(kittyPlaceholder, cols-1) + "\x1b[39m"
+	}
+	return lines
+}
+
+// iterm2Image draws a PNG with iTerm2's inline image protocol, scaled into
+// cols×rows cells.
+func iterm2Image(img image.Image, cols, rows int) string {
+	data := encodePNG(img)
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+		len(data), cols, rows, base64.StdEncoding.EncodeToString(data))
+}
+
+// sixelImage encodes an image as sixels using the 216-colour web-safe
+// palette. Transparent pixels are left unpainted.
+func sixelImage(img image.Image) string {
+	b := img.Bounds()
+	pal := color.Palette(palette.WebSafe)
+	indexed := image.NewPaletted(b, pal)
+	draw.FloydSteinberg.Draw(indexed, b, img, b.Min)
+	w, h := b.Dx(), b.Dy()
+	pixels := make([]int, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := int(indexed.ColorIndexAt(b.Min.X+x, b.Min.Y+y))
+			if _, _, _, a := img.At(b.Min.X+x, b.Min.Y+y).RGBA(); a < 0x8000 {
+				i = -1
+			}
+			pixels[y*w+x] = i
+		}
+	}
+	index := func(x, y int) int { return pixels[y*w+x] }
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+	for i, c := range pal {
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, r*100/0xFFFF, g*100/0xFFFF, bl*100/0xFFFF)
+	}
+	bits := make([]byte, w)
+	for y := 0; y < h; y += 6 {
+		used := map[int]bool{}
+		for x := 0; x < w; x++ {
+			for dy := 0; dy < 6 && y+dy < h; dy++ {
+				if i := index(x, y+dy); i >= 0 {
+					used[i] = true
+				}
+			}
+		}
+		first := true
+		for c := range pal {
+			if !used[c] {
+				continue
+			}
+			for x := range bits {
+				bits[x] = 0
+				for dy := 0; dy < 6 && y+dy < h; dy++ {
+					if index(x, y+dy) == c {
+						bits[x] |= 1 << dy
+					}
+				}
+			}
+			if !first {
+				sb.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&sb, "#%d", c)
+			writeSixelRuns(&sb, bits)
+		}
+		sb.WriteByte('-')
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
+
+// writeSixelRuns writes one colour of a six-pixel band, run-length encoded.
+func writeSixelRuns(sb *strings.Builder, bits []byte) {
+	for x := 0; x < len(bits); {
+		n := 1
+		for x+n < len(bits) && bits[x+n] == bits[x] {
+			n++
+		}
+		ch := 63 + bits[x]
+		if n > 3 {
+			fmt.Fprintf(sb, "!%d%c", n, ch)
+		} else {
+			sb.WriteString(strings.Repeat(string(rune(ch)), n))
+		}
+		x += n
+	}
+}
+
+// graphicsFromEnv resolves the images setting against this process's
+// environment.
+func graphicsFromEnv(setting string) graphicsProtocol {
+	return graphicsFor(setting, os.Getenv)
+}
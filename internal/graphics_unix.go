@@ -0,0 +1,19 @@
+//go:build !windows
+
+package internal
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalCellSize asks the terminal for its size in pixels and cells.
+// Terminals that do not report pixels leave them zero.
+func terminalCellSize() (cellSize, bool) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 || ws.Xpixel == 0 || ws.Ypixel == 0 {
+		return cellSize{}, false
+	}
+	return cellSize{W: int(ws.Xpixel / ws.Col), H: int(ws.Ypixel / ws.Row)}, true
+}
@@ -0,0 +1,7 @@
+//go:build windows
+
+package internal
+
+// terminalCellSize is not available on Windows consoles; the fallback size is
+// used instead.
+func terminalCellSize() (cellSize, bool) { return cellSize{}, false }
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ────────────────────────────────
+// PLAYBACK HEADERS
+// ────────────────────────────────
+
+// playbackHeader is a captured header forwarded to the player.
+type playbackHeader struct {
+	Name, Value string
+}
+
+// neverForwarded are captured headers that describe the browser's own
+// connection and would break the player's requests if copied.
+var neverForwarded = []string{"host", "content-length", "connection", "accept-encoding", "range", "if-range"}
+
+// headerPolicy picks the captured headers forwarded to the player for a
+// playlist. By default only User-Agent, Origin, and Referer are, plus the
+// cookies when cookies is set. A domain rule replaces that list for
+// playlists on the domain or its subdomains; the most specific domain wins,
+// and "*" in a rule forwards every captured header.
+type headerPolicy struct {
+	cookies bool
+	domains map[string][]string
+}
+
+func (o Options) headerPolicy() headerPolicy {
+	return headerPolicy{cookies: o.ForwardCookies, domains: o.PlaybackHeaders}
+}
+
+// matchDomain returns the key of domains that names rawURL's host or a
+// parent domain of it, preferring the most specific one.
+func matchDomain[V any](domains map[string]V, rawURL string) (string, bool) {
+	host := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		host = strings.ToLower(u.Hostname())
+	}
+	best, found := "", false
+	for domain := range domains {
+		d := strings.ToLower(strings.TrimPrefix(domain, "."))
+		if (host == d || strings.HasSuffix(host, "."+d)) && (!found || len(d) > len(best)) {
+			best, found = domain, true
+		}
+	}
+	return best, found
+}
+
+// rule returns the header names forwarded for playlistURL.
+func (p headerPolicy) rule(playlistURL string) []string {
+	if domain, ok := matchDomain(p.domains, playlistURL); ok {
+		return p.domains[domain]
+	}
+	names := make([]string, 0, len(playbackHeaders)+1)
+	for _, hk := range playbackHeaders {
+		names = append(names, hk.display)
+	}
+	if p.cookies {
+		names = append(names, "Cookie")
+	}
+	return names
+}
+
+// forward returns the headers to send with the playlist at playlistURL,
+// looking captured keys up case-insensitively.
+func (p headerPolicy) forward(playlistURL string, hdrs map[string]string) []playbackHeader {
+	names := p.rule(playlistURL)
+	if slices.Contains(names, "*") {
+		names = names[:0:0]
+		for k := range hdrs {
+			k = strings.ToLower(k)
+			if !strings.HasPrefix(k, ":") && !slices.Contains(neverForwarded, k) && !slices.Contains(names, k) {
+				names = append(names, k)
+			}
+		}
+		slices.Sort(names)
+	}
+	var out []playbackHeader
+	for _, name := range names {
+		if v := lookupHeaderValue(hdrs, name); v != "" {
+			out = append(out, playbackHeader{Name: http.CanonicalHeaderKey(name), Value: v})
+		}
+	}
+	return out
+}
+
+// ────────────────────────────────
+// HEADER PROFILES
+// ────────────────────────────────
+
+// headerProfile sets the playback headers for streams embedded from a
+// domain, for hosts that want other values than the extractors capture.
+// Fields are templates: {embed} is the embed URL, {embed_origin} and
+// {playlist_origin} the scheme and host of the embed and playlist URLs, and
+// {user_agent}, {referer}, and {origin} the captured values. Empty fields
+// keep the captured value.
+type headerProfile struct {
+	Origin    string `toml:"origin"`
+	Referer   string `toml:"referer"`
+	UserAgent string `toml:"user_agent"`
+}
+
+// headerProfiles maps embed domains, subdomains included, to their profile.
+type headerProfiles map[string]headerProfile
+
+// apply rewrites res.Headers with the profile for embedURL's domain, if one
+// is configured, and returns the domain it used.
+func (p headerProfiles) apply(embedURL string, res *extractResult) (string, bool) {
+	domain, ok := matchDomain(p, embedURL)
+	if !ok {
+		return "", false
+	}
+	prof := p[domain]
+	captured := res.Headers
+	expand := func(tmpl string) string {
+		return strings.NewReplacer(
+			"{embed}", embedURL,
+			"{embed_origin}", urlOrigin(embedURL),
+			"{playlist_origin}", urlOrigin(res.URL),
+			"{user_agent}", lookupHeaderValue(captured, "user-agent"),
+			"{referer}", lookupHeaderValue(captured, "referer"),
+			"{origin}", lookupHeaderValue(captured, "origin"),
+		).Replace(tmpl)
+	}
+	hdrs := make(map[string]string, len(captured)+3)
+	for k, v := range captured {
+		hdrs[strings.ToLower(k)] = v
+	}
+	for name, tmpl := range map[string]string{"origin": prof.Origin, "referer": prof.Referer, "user-agent": prof.UserAgent} {
+		if tmpl != "" {
+			hdrs[name] = expand(tmpl)
+		}
+	}
+	res.Headers = hdrs
+	return domain, true
+}
+
+// urlOrigin returns the scheme and host of rawURL.
+func urlOrigin(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// headerNames lists forwarded headers for the log, without their values
+// since cookies are secrets.
+func headerNames(hdrs []playbackHeader) string {
+	names := make([]string, len(hdrs))
+	for i, h := range hdrs {
+		names[i] = h.Name
+	}
+	return strings.Join(names, ", ")
+}
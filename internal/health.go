@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+// ────────────────────────────────
+// STREAM HEALTH
+// ────────────────────────────────
+
+const (
+	// healthCheckConcurrency bounds how many streams are extracted at once
+	// while a match's streams are checked, since each may start a browser.
+	healthCheckConcurrency = 4
+	// healthSlowLatency is the playlist response time above which a working
+	// stream is marked slow.
+	healthSlowLatency = 1500 * time.Millisecond
+)
+
+type healthState int
+
+const (
+	healthUnknown healthState = iota
+	healthChecking
+	healthOK
+	healthDead
+)
+
+// streamHealth is how a stream fared when its match's streams were checked.
+type streamHealth struct {
+	State healthState
+	// Latency is the playlist's response time, or the extraction time when
+	// playlists are not probed.
+	Latency time.Duration
+	// Bandwidth is the highest variant bandwidth a master playlist
+	// advertises.
+	Bandwidth int
+	Err       error
+}
+
+// icon marks a stream's health in the Streams column.
+func (h streamHealth) icon() string {
+	switch h.State {
+	case healthChecking:
+		return "…"
+	case healthDead:
+		return "✗"
+	case healthOK:
+		if h.Latency > healthSlowLatency {
+			return "◑"
+		}
+		return "✓"
+	}
+	return ""
+}
+
+// label describes a checked stream after its row.
+func (h streamHealth) label() string {
+	switch h.State {
+	case healthOK:
+		text := h.Latency.Round(time.Millisecond).String()
+		if h.Bandwidth > 0 {
+			text += fmt.Sprintf(" %.1f Mbps", float64(h.Bandwidth)/1e6)
+		}
+		return text
+	case healthDead:
+		return "dead"
+	}
+	return ""
+}
+
+// compareHealth orders working streams by latency, then advertised
+// bandwidth, ahead of unchecked streams, with dead ones last.
+func compareHealth(a, b streamHealth) int {
+	rank := func(h streamHealth) int {
+		switch h.State {
+		case healthOK:
+			return 0
+		case healthDead:
+			return 2
+		}
+		return 1
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+	if a.State != healthOK {
+		return 0
+	}
+	if a.Latency != b.Latency {
+		return compareInt64(int64(a.Latency), int64(b.Latency))
+	}
+	return b.Bandwidth - a.Bandwidth
+}
+
+type (
+	// streamHealthMsg reports one stream's check as it finishes.
+	streamHealthMsg struct {
+		EmbedURL string
+		Health   streamHealth
+	}
+	// streamsCheckedMsg ends a check of a match's streams.
+	streamsCheckedMsg struct {
+		Title            string
+		Streams          []Stream
+		Checked, Healthy int
+		Best             Stream
+		BestHealth       streamHealth
+		Canceled         bool
+	}
+)
+
+// checkStreams extracts every stream of the shown match, a few at a time,
+// and ranks the Streams column by how they answered, so a working stream
+// can be picked without trying them one by one.
+func (m *Model) checkStreams() tea.Cmd {
+	mt := m.currentMatch()
+	var streams []Stream
+	for _, st := range m.allStreams {
+		if !isAdminStream(st) && st.EmbedURL != "" {
+			streams = append(streams, st)
+		}
+	}
+	if len(streams) == 0 {
+		m.status = "No streams to check"
+		return nil
+	}
+	cmd := m.trackExtraction("check:"+mt.ID, fmt.Sprintf("Checking %d streams", len(streams)), func(ctx context.Context) tea.Cmd {
+		return m.runHealthCheck(ctx, mt, streams)
+	})
+	if cmd == nil {
+		return nil
+	}
+	for _, st := range streams {
+		m.health[st.EmbedURL] = streamHealth{State: healthChecking}
+	}
+	m.streamSort = sortStreamsHealth
+	m.applyStreams()
+	return tea.Batch(m.logToUI(fmt.Sprintf("[check] checking %d streams of %s", len(streams), matchDisplayTitle(mt))), cmd)
+}
+
+func (m Model) runHealthCheck(ctx context.Context, mt Match, streams []Stream) tea.Cmd {
+	return safeCmd("stream check", m.crash, func() tea.Msg {
+		done := streamsCheckedMsg{Title: matchDisplayTitle(mt), Streams: streams}
+		var mu sync.Mutex
+		g := new(errgroup.Group)
+		g.SetLimit(healthCheckConcurrency)
+		for _, st := range streams {
+			g.Go(func() error {
+				if ctx.Err() != nil {
+					return nil
+				}
+				h := m.checkStream(ctx, st)
+				if ctx.Err() != nil {
+					return nil
+				}
+				m.ui.Send(streamHealthMsg{EmbedURL: st.EmbedURL, Health: h})
+				mu.Lock()
+				defer mu.Unlock()
+				done.Checked++
+				if h.State == healthOK {
+					done.Healthy++
+					if done.Healthy == 1 || compareHealth(h, done.BestHealth) < 0 {
+						done.Best, done.BestHealth = st, h
+					}
+				}
+				m.ui.Send(progressPhaseMsg{key: opExtract, phase: fmt.Sprintf("%d of %d done", done.Checked, len(streams))})
+				return nil
+			})
+		}
+		_ = g.Wait()
+		done.Canceled = ctx.Err() != nil
+		return done
+	})
+}
+
+// checkStream extracts st and probes its playlist, logging under the
+// stream's number since several run at once.
+func (m Model) checkStream(ctx context.Context, st Stream) streamHealth {
+	logcb := func(line string) { m.ui.Log(fmt.Sprintf("[check #%d] %s", st.StreamNo, line)) }
+	res, err := m.extract(ctx, st.EmbedURL, m.opts.extractOptions(), logcb)
+	if err != nil {
+		logcb(fmt.Sprintf("❌ %v", err))
+		return streamHealth{State: healthDead, Err: err}
+	}
+	m.ui.Send(streamExtractedMsg{EmbedURL: st.EmbedURL, URL: res.URL})
+	if !m.opts.probeEnabled() {
+		logcb(fmt.Sprintf("✅ extracted via %s in %s", res.Backend, formatElapsed(res.Elapsed)))
+		return streamHealth{State: healthOK, Latency: res.Elapsed}
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, m.opts.APITimeout)
+	defer cancel()
+	pr := probePlaylist(probeCtx, proxyHTTPClient(m.opts.proxy()), res.URL, res.Headers)
+	if !pr.ok() {
+		logcb(fmt.Sprintf("❌ %s: %s", res.URL, pr))
+		return streamHealth{State: healthDead, Latency: pr.Latency, Err: pr.Err}
+	}
+	logcb(fmt.Sprintf("✅ playlist answered %s", pr))
+	return streamHealth{State: healthOK, Latency: pr.Latency, Bandwidth: pr.Bandwidth}
+}
+
+// streamsChecked reports a finished check. Streams it did not get to, when
+// it was canceled, are left unchecked.
+func (m *Model) streamsChecked(msg streamsCheckedMsg) {
+	for _, st := range msg.Streams {
+		if m.health[st.EmbedURL].State == healthChecking {
+			delete(m.health, st.EmbedURL)
+		}
+	}
+	m.applyStreams()
+	switch {
+	case msg.Canceled:
+		m.status = fmt.Sprintf("Stream check canceled after %d of %d streams", msg.Checked, len(msg.Streams))
+	case msg.Healthy == 0:
+		m.status = fmt.Sprintf("None of the %d streams of %s work", len(msg.Streams), msg.Title)
+	default:
+		m.status = fmt.Sprintf("%d of %d streams of %s work – best is #%d %s (%s)",
+			msg.Healthy, len(msg.Streams), msg.Title, msg.Best.StreamNo, msg.Best.Source, msg.BestHealth.label())
+	}
+}
@@ -0,0 +1,204 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// WATCH HISTORY
+// ────────────────────────────────
+
+// maxHistoryEntries caps history.json; the oldest launches are dropped first.
+const maxHistoryEntries = 500
+
+// historyEntry is one stream launched in mpv.
+type historyEntry struct {
+	MatchID    string    `json:"match_id,omitempty"`
+	MatchTitle string    `json:"match_title"`
+	Source     string    `json:"source"`
+	StreamNo   int       `json:"stream_no"`
+	Language   string    `json:"language,omitempty"`
+	HD         bool      `json:"hd,omitempty"`
+	EmbedURL   string    `json:"embed_url"`
+	M3U8Host   string    `json:"m3u8_host,omitempty"`
+	LaunchedAt time.Time `json:"launched_at"`
+}
+
+// stream rebuilds the stream the entry was launched from, so it can be
+// extracted again (the captured .m3u8 URL itself expires quickly).
+func (e historyEntry) stream() Stream {
+	return Stream{StreamNo: e.StreamNo, Language: e.Language, HD: e.HD, EmbedURL: e.EmbedURL, Source: e.Source}
+}
+
+// historyStore persists launches as JSON in the data directory, newest
+// first. Launches are recorded from the extractor goroutine, so access is
+// serialized.
+type historyStore struct {
+	mu      sync.Mutex
+	path    string
+	entries []historyEntry
+}
+
+// openHistoryStore loads history.json. A missing file yields an empty store;
+// the path is empty (memory only) when the directory cannot be created.
+func openHistoryStore() (*historyStore, error) {
+	s := &historyStore{}
+	dir, err := ensureAppDir(dataDir)
+	if err != nil {
+		return s, err
+	}
+	s.path = filepath.Join(dir, "history.json")
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return s, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// List returns the entries, newest first.
+func (s *historyStore) List() []historyEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]historyEntry(nil), s.entries...)
+}
+
+// Record adds a launch at the top of the history.
+func (s *historyStore) Record(e historyEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append([]historyEntry{e}, s.entries...)
+	if len(s.entries) > maxHistoryEntries {
+		s.entries = s.entries[:maxHistoryEntries]
+	}
+	return s.saveLocked()
+}
+
+// Remove deletes the entry launched at the given time for the given embed.
+func (s *historyStore) Remove(e historyEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.entries[:0]
+	for _, h := range s.entries {
+		if !h.LaunchedAt.Equal(e.LaunchedAt) || h.EmbedURL != e.EmbedURL {
+			kept = append(kept, h)
+		}
+	}
+	s.entries = kept
+	return s.saveLocked()
+}
+
+func (s *historyStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// m3u8Host returns the host serving a playlist, or "" if it cannot be
+// parsed.
+func m3u8Host(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+type historyKeys struct {
+	Relaunch, Delete key.Binding
+}
+
+func defaultHistoryKeys() historyKeys {
+	return historyKeys{
+		Relaunch: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "launch again")),
+		Delete:   key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "remove")),
+	}
+}
+
+func newHistoryList() *ListColumn[historyEntry] {
+	return NewListColumn[historyEntry]("History", func(e historyEntry) string {
+		quality := "SD"
+		if e.HD {
+			quality = "HD"
+		}
+		text := fmt.Sprintf("%s  %s – #%d %s (%s) – %s",
+			e.LaunchedAt.Local().Format("Jan 2 15:04"), e.MatchTitle, e.StreamNo, e.Language, quality, e.Source)
+		if e.M3U8Host != "" {
+			text += " @ " + e.M3U8Host
+		}
+		return text
+	})
+}
+
+// openHistory switches to the history view.
+func (m *Model) openHistory() {
+	m.history.SetItems(m.watched.List())
+	m.currentView = viewHistory
+}
+
+// updateHistory handles keys while the history view is open.
+func (m *Model) updateHistory(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.history.CursorUp()
+		return nil
+	case key.Matches(msg, m.keys.Down):
+		m.history.CursorDown()
+		return nil
+	}
+
+	e, ok := m.history.Selected()
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(msg, m.historyKeys.Relaunch):
+		m.currentView = viewMain
+		return tea.Batch(
+			m.logToUI(fmt.Sprintf("Relaunching stream #%d for %s", e.StreamNo, e.MatchTitle)),
+			m.launchStream(e.stream(), Match{ID: e.MatchID, Title: e.MatchTitle}, m.opts.Fullscreen, false),
+		)
+	case key.Matches(msg, m.historyKeys.Delete):
+		if err := m.watched.Remove(e); err != nil {
+			m.lastError = err
+		}
+		m.history.ReplaceItems(m.watched.List(), func(a, b historyEntry) bool {
+			return a.LaunchedAt.Equal(b.LaunchedAt) && a.EmbedURL == b.EmbedURL
+		})
+		m.status = fmt.Sprintf("Removed %s from history", e.MatchTitle)
+	}
+	return nil
+}
+
+func (m Model) renderHistoryView() string {
+	header := m.styles.Title.Render("Watch history")
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter launch again · x remove · Esc back"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.history.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
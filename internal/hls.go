@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// HLS MASTER PLAYLISTS
+// ────────────────────────────────
+
+// maxPlaylistBytes caps how much of a playlist is read when looking for
+// variants.
+const maxPlaylistBytes = 1 << 20
+
+// Quality settings accepted in Options.Quality besides a maximum height such
+// as "720p".
+const (
+	qualityAsk  = "ask"
+	qualityAuto = "auto"
+	qualityBest = "best"
+)
+
+// unattendedQuality is the quality used where no one is there to answer the
+// picker: "ask", which is also the default, leaves the choice to the player.
+func unattendedQuality(quality string) string {
+	if q := strings.TrimSpace(quality); q == "" || strings.EqualFold(q, qualityAsk) {
+		return qualityAuto
+	}
+	return quality
+}
+
+// hlsVariant is one #EXT-X-STREAM-INF entry of a master playlist. Auto marks
+// the picker entry that keeps the master playlist and lets the player choose.
+type hlsVariant struct {
+	URL       string
+	Bandwidth int
+	Width     int
+	Height    int
+	FrameRate float64
+	Codecs    string
+	Auto      bool
+}
+
+// label describes a variant for the quality picker.
+func (v hlsVariant) label() string {
+	if v.Auto {
+		return "Auto (let the player choose)"
+	}
+	var parts []string
+	if v.Height > 0 {
+		res := fmt.Sprintf("%dp", v.Height)
+		if v.FrameRate > 0 {
+			res += strconv.Itoa(int(v.FrameRate + 0.5))
+		}
+		parts = append(parts, fmt.Sprintf("%-8s %dx%d", res, v.Width, v.Height))
+	}
+	if v.Bandwidth > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f Mbps", float64(v.Bandwidth)/1e6))
+	}
+	if v.Codecs != "" {
+		parts = append(parts, v.Codecs)
+	}
+	if len(parts) == 0 {
+		return v.URL
+	}
+	return strings.Join(parts, " · ")
+}
+
+// parseAttributeList splits an HLS attribute list (KEY=value,KEY="a,b")
+// into a map.
+func parseAttributeList(s string) map[string]string {
+	attrs := map[string]string{}
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else if comma := strings.IndexByte(s, ','); comma >= 0 {
+			value, s = s[:comma], s[comma:]
+		} else {
+			value, s = s, ""
+		}
+		attrs[name] = value
+		s = strings.TrimPrefix(s, ",")
+	}
+	return attrs
+}
+
+// parseMasterPlaylist returns the variants of a master playlist, highest
+// bandwidth first, with URLs resolved against the playlist's own URL. A
+// media playlist has no variants.
+func parseMasterPlaylist(playlistURL, body string) []hlsVariant {
+	base, _ := url.Parse(playlistURL)
+	var (
+		variants []hlsVariant
+		pending  *hlsVariant
+	)
+	sc := bufio.NewScanner(strings.NewReader(body))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"):
+			attrs := parseAttributeList(strings.TrimPrefix(line, "#EXT-X-STREAM-INF:"))
+			v := hlsVariant{Codecs: attrs["CODECS"]}
+			v.Bandwidth, _ = strconv.Atoi(attrs["BANDWIDTH"])
+			if w, h, ok := strings.Cut(attrs["RESOLUTION"], "x"); ok {
+				v.Width, _ = strconv.Atoi(w)
+				v.Height, _ = strconv.Atoi(h)
+			}
+			v.FrameRate, _ = strconv.ParseFloat(attrs["FRAME-RATE"], 64)
+			pending = &v
+		case strings.HasPrefix(line, "#"):
+		case pending != nil:
+			pending.URL = line
+			if base != nil {
+				if ref, err := base.Parse(line); err == nil {
+					pending.URL = ref.String()
+				}
+			}
+			variants = append(variants, *pending)
+			pending = nil
+		}
+	}
+	sort.SliceStable(variants, func(i, j int) bool { return variants[i].Bandwidth > variants[j].Bandwidth })
+	return variants
+}
+
+// fetchVariants downloads a playlist with the captured headers and returns
+// its variants; none when it is a media playlist.
+func fetchVariants(ctx context.Context, client *http.Client, playlistURL string, hdrs map[string]string) ([]hlsVariant, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, playlistURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range hdrs {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch playlist: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch playlist: %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPlaylistBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read playlist: %w", err)
+	}
+	return parseMasterPlaylist(resp.Request.URL.String(), string(body)), nil
+}
+
+// pickVariant applies a non-interactive quality setting: "best" is the
+// highest bandwidth, and a height such as "720p" is the best variant no
+// taller than that (or the smallest one if all are taller). Other settings
+// keep the master playlist.
+func pickVariant(variants []hlsVariant, quality string) (hlsVariant, bool) {
+	if len(variants) == 0 {
+		return hlsVariant{}, false
+	}
+	q := strings.ToLower(strings.TrimSpace(quality))
+	if q == qualityBest {
+		return variants[0], true
+	}
+	limit, err := strconv.Atoi(strings.TrimSuffix(q, "p"))
+	if err != nil || limit <= 0 {
+		return hlsVariant{}, false
+	}
+	for _, v := range variants {
+		if v.Height > 0 && v.Height <= limit {
+			return v, true
+		}
+	}
+	return variants[len(variants)-1], true
+}
+
+// checkQuality rejects quality settings that are not understood.
+func (o Options) checkQuality() error {
+	q := strings.ToLower(strings.TrimSpace(o.Quality))
+	switch q {
+	case "", qualityAsk, qualityAuto, qualityBest:
+		return nil
+	}
+	if n, err := strconv.Atoi(strings.TrimSuffix(q, "p")); err == nil && n > 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown quality %q (want ask, auto, best, or a height such as 720p)", o.Quality)
+}
+
+// selectVariant applies the quality setting to an extracted stream whose
+// playlist has several variants: "best" or a height picks one, and "ask"
+// returns a picker message for the user. Failures to read the playlist keep
+// the master playlist.
+func selectVariant(ctx context.Context, client *http.Client, quality string, p *pendingLaunch, log func(string)) (qualityPickMsg, bool) {
+	q := strings.ToLower(strings.TrimSpace(quality))
+	if q == qualityAuto {
+		return qualityPickMsg{}, false
+	}
+	variants, err := fetchVariants(ctx, client, p.Result.URL, p.Result.Headers)
+	if err != nil {
+		log(fmt.Sprintf("[hls] variants unavailable, letting the player choose: %v", err))
+		return qualityPickMsg{}, false
+	}
+	if len(variants) < 2 {
+		return qualityPickMsg{}, false
+	}
+	log(fmt.Sprintf("[hls] master playlist has %d variants", len(variants)))
+	if q == "" || q == qualityAsk {
+		return qualityPickMsg{Launch: *p, Variants: variants}, true
+	}
+	if v, ok := pickVariant(variants, q); ok {
+		log(fmt.Sprintf("[hls] quality %s: %s", q, v.label()))
+		p.Result.URL = v.URL
+	}
+	return qualityPickMsg{}, false
+}
+
+// ────────────────────────────────
+// QUALITY PICKER
+// ────────────────────────────────
+
+// pendingLaunch is an extracted stream waiting for the player.
+type pendingLaunch struct {
+	Stream     Stream
+	Match      Match
+	Fullscreen bool
+	Result     extractResult
+	// JobID is the extraction job the stream came from, if any.
+	JobID int
+}
+
+// qualityPickMsg asks the user to choose a variant before launching.
+type qualityPickMsg struct {
+	Launch   pendingLaunch
+	Variants []hlsVariant
+}
+
+func newQualityList() *ListColumn[hlsVariant] {
+	return NewListColumn[hlsVariant]("Quality", hlsVariant.label)
+}
+
+// openQualityPicker lists the variants, with Auto first, and keeps the
+// extracted stream until one is chosen.
+func (m *Model) openQualityPicker(msg qualityPickMsg) {
+	items := append([]hlsVariant{{URL: msg.Launch.Result.URL, Auto: true}}, msg.Variants...)
+	m.quality.SetItems(items)
+	m.quality.Select(func(v hlsVariant) bool { return !v.Auto })
+	m.pendingLaunch = msg.Launch
+	m.currentView = viewQuality
+	m.status = fmt.Sprintf("%d qualities available – pick one with Enter", len(msg.Variants))
+}
+
+// updateQualityPicker handles keys while the quality picker is open.
+func (m *Model) updateQualityPicker(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.quality.CursorUp()
+	case key.Matches(msg, m.keys.Down):
+		m.quality.CursorDown()
+	case key.Matches(msg, m.keys.Enter):
+		v, ok := m.quality.Selected()
+		if !ok {
+			return nil
+		}
+		p := m.pendingLaunch
+		p.Result.URL = v.URL
+		m.pendingLaunch = pendingLaunch{}
+		m.currentView = viewMain
+		return tea.Batch(
+			m.logToUI(fmt.Sprintf("[hls] playing %s", v.label())),
+			m.playPending(p),
+		)
+	}
+	return nil
+}
+
+func (m Model) renderQualityView() string {
+	title := matchDisplayTitle(m.pendingLaunch.Match)
+	if title == "" {
+		title = fmt.Sprintf("stream #%d", m.pendingLaunch.Stream.StreamNo)
+	}
+	header := m.styles.Title.Render(m.styles.Text("Choose quality – " + title))
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter play · Esc cancel"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.quality.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
@@ -0,0 +1,218 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ────────────────────────────────
+// HOOKS
+// ────────────────────────────────
+
+// Events hooks can run on.
+const (
+	hookStreamExtracted = "stream_extracted"
+	hookPlayerLaunched  = "player_launched"
+	hookMatchStarted    = "match_started"
+	hookReminder        = "reminder"
+)
+
+var hookEvents = []string{hookStreamExtracted, hookPlayerLaunched, hookMatchStarted, hookReminder}
+
+// hookTimeout bounds how long a hook's command or POST may take.
+const hookTimeout = 30 * time.Second
+
+// hookConfig is one [[hooks]] entry of the config file: a shell command, a
+// URL to POST to, or both.
+type hookConfig struct {
+	// Events are the events the hook runs on; empty means every event.
+	Events  []string `toml:"events"`
+	Command string   `toml:"command"`
+	URL     string   `toml:"url"`
+}
+
+// hookEvent is what a hook is told about an event. URLs get it as the JSON
+// body and commands on stdin, with the main fields also in STREAMED_*
+// environment variables. Text and Content repeat Message so Slack- and
+// Discord-style webhooks show it as is.
+type hookEvent struct {
+	Event    string    `json:"event"`
+	Time     time.Time `json:"time"`
+	MatchID  string    `json:"match_id,omitempty"`
+	Title    string    `json:"title,omitempty"`
+	Category string    `json:"category,omitempty"`
+	Start    time.Time `json:"start"`
+	EmbedURL string    `json:"embed_url,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	StreamNo int       `json:"stream_no,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Player   string    `json:"player,omitempty"`
+	Message  string    `json:"message"`
+	Text     string    `json:"text"`
+	Content  string    `json:"content"`
+}
+
+// newHookEvent describes event for mt, and for st when it has an embed URL.
+func newHookEvent(event string, mt Match, st Stream, message string) hookEvent {
+	ev := hookEvent{
+		Event:    event,
+		Time:     time.Now(),
+		MatchID:  mt.ID,
+		Title:    matchDisplayTitle(mt),
+		Category: mt.Category,
+		EmbedURL: st.EmbedURL,
+		Source:   st.Source,
+		StreamNo: st.StreamNo,
+		Message:  message,
+		Text:     message,
+		Content:  message,
+	}
+	if mt.Date > 0 {
+		ev.Start = time.UnixMilli(mt.Date)
+	}
+	return ev
+}
+
+// hookRunner runs the configured hooks in the background.
+type hookRunner struct {
+	hooks []hookConfig
+	http  *http.Client
+	// log reports hooks that failed.
+	log func(string)
+	wg  sync.WaitGroup
+}
+
+// hookRunner checks the configured hooks and returns a runner for them, or
+// nil when there are none.
+func (o Options) hookRunner(log func(string)) (*hookRunner, error) {
+	for i, h := range o.Hooks {
+		if strings.TrimSpace(h.Command) == "" && strings.TrimSpace(h.URL) == "" {
+			return nil, fmt.Errorf("hook %d: command or url is required", i+1)
+		}
+		for _, ev := range h.Events {
+			if ev != "*" && !slices.Contains(hookEvents, ev) {
+				return nil, fmt.Errorf("hook %d: unknown event %q (want %s)", i+1, ev, strings.Join(hookEvents, ", "))
+			}
+		}
+	}
+	if len(o.Hooks) == 0 {
+		return nil, nil
+	}
+	if log == nil {
+		log = func(string) {}
+	}
+	// Hooks often point at the local network, so they skip the proxy.
+	return &hookRunner{hooks: o.Hooks, http: &http.Client{}, log: log}, nil
+}
+
+func (h hookConfig) runsOn(event string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, "*") || slices.Contains(h.Events, event)
+}
+
+// Fire starts the hooks for ev without waiting for them. A nil runner does
+// nothing.
+func (r *hookRunner) Fire(ev hookEvent) {
+	if r == nil {
+		return
+	}
+	for _, h := range r.hooks {
+		if !h.runsOn(ev.Event) {
+			continue
+		}
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			if err := r.run(h, ev); err != nil {
+				r.log(fmt.Sprintf("[hooks] %s hook failed: %v", ev.Event, err))
+			}
+		}()
+	}
+}
+
+// Wait blocks until the hooks started so far have finished.
+func (r *hookRunner) Wait() {
+	if r != nil {
+		r.wg.Wait()
+	}
+}
+
+func (r *hookRunner) run(h hookConfig, ev hookEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	var errs []error
+	if cmd := strings.TrimSpace(h.Command); cmd != "" {
+		if err := runHookCommand(ctx, cmd, ev, body); err != nil {
+			errs = append(errs, fmt.Errorf("command: %w", err))
+		}
+	}
+	if u := strings.TrimSpace(h.URL); u != "" {
+		if err := postJSON(ctx, r.http, u, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", apiHost(u), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHookCommand runs a hook's command through the shell with the event as
+// JSON on stdin and in the environment.
+func runHookCommand(ctx context.Context, command string, ev hookEvent, body []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	killGroupOnCancel(cmd)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"STREAMED_EVENT="+ev.Event,
+		"STREAMED_MATCH_ID="+ev.MatchID,
+		"STREAMED_TITLE="+ev.Title,
+		"STREAMED_EMBED_URL="+ev.EmbedURL,
+		"STREAMED_URL="+ev.URL,
+		"STREAMED_PLAYER="+ev.Player,
+		"STREAMED_MESSAGE="+ev.Message,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			lines := strings.Split(msg, "\n")
+			return fmt.Errorf("%w: %s", err, lines[len(lines)-1])
+		}
+		return err
+	}
+	return nil
+}
+
+// postJSON POSTs body to url and fails unless the answer is a 2xx.
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("server answered %s", resp.Status)
+	}
+	return nil
+}
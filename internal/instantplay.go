@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// INSTANT PLAY
+// ────────────────────────────────
+
+// instantPlayMsg carries the streams fetched to instantly play a match.
+type instantPlayMsg struct {
+	Match   Match
+	Streams []Stream
+}
+
+// instantPlay plays the best stream of the highlighted match without
+// opening it: its streams are taken from the prefetch cache or fetched, and
+// the top candidate is extracted, falling back to the next ones.
+func (m *Model) instantPlay(mt Match) tea.Cmd {
+	m.lastError = nil
+	if streams, fresh, ok := m.prefetch.lookup(mt.ID); ok && fresh {
+		return m.playBestStream(mt, streams)
+	}
+	provider, pref := m.provider, m.streamPref
+	fetch := safeCmd("fetch streams", m.crash, func() tea.Msg {
+		streams, err := provider.ListStreams(context.Background(), mt)
+		// Play from the sources that answered if some did not.
+		if _, err := partialStreams(err); err != nil {
+			return errorMsg(err)
+		}
+		return instantPlayMsg{Match: mt, Streams: reorderStreams(streams, pref)}
+	})
+	return m.track(opStreams, "streams:"+mt.ID, fmt.Sprintf("Finding the best stream for %s", matchDisplayTitle(mt)), fetch)
+}
+
+func (m *Model) handleInstantPlay(msg instantPlayMsg) tea.Cmd {
+	m.prefetch.store(msg.Match.ID, msg.Streams)
+	return m.playBestStream(msg.Match, msg.Streams)
+}
+
+// playBestStream extracts and plays the first of a match's candidates,
+// with the next ones as fallbacks.
+func (m *Model) playBestStream(mt Match, streams []Stream) tea.Cmd {
+	title := matchDisplayTitle(mt)
+	candidates := m.instantCandidates(streams)
+	if len(candidates) == 0 {
+		m.status = fmt.Sprintf("No playable streams for %s yet", title)
+		if filters := m.instantFiltersLabel(); filters != "" {
+			m.status = fmt.Sprintf("No playable %s streams for %s yet", filters, title)
+		}
+		return nil
+	}
+	st, fallbacks := candidates[0], candidates[1:]
+	m.status = fmt.Sprintf("▶ Instant play: stream #%d %s (%s) of %s", st.StreamNo, st.Language, st.Source, title)
+	return tea.Batch(
+		m.logToUI(fmt.Sprintf("Instant play picked stream #%d (%s, %s) for %s, %d fallback(s)", st.StreamNo, st.Language, st.Source, title, len(fallbacks))),
+		m.trackExtraction("extract:"+st.EmbedURL, fmt.Sprintf("Extracting stream #%d", st.StreamNo), func(ctx context.Context) tea.Cmd {
+			return m.runExtractor(ctx, st, fallbacks, mt, m.opts.Fullscreen, true)
+		}),
+	)
+}
+
+// instantCandidates picks the streams instant play tries, best first, as
+// "streamed-tui play" does, leaving out streams the last check found dead
+// and SD ones while the Streams column shows HD only.
+func (m Model) instantCandidates(streams []Stream) []Stream {
+	var shown []Stream
+	for _, st := range reorderStreams(streams, m.streamPref) {
+		switch {
+		case m.hdOnly && !st.HD:
+		case m.health[st.EmbedURL].State == healthDead:
+		default:
+			shown = append(shown, st)
+		}
+	}
+	return playCandidates(shown, m.opts.Languages, 1+max(m.opts.FallbackStreams, 0))
+}
+
+// instantFiltersLabel names the filters that narrowed instant play's pick.
+func (m Model) instantFiltersLabel() string {
+	var filters []string
+	if m.hdOnly {
+		filters = append(filters, "HD")
+	}
+	if len(m.opts.Languages) > 0 {
+		filters = append(filters, strings.Join(m.opts.Languages, "/"))
+	}
+	return strings.Join(filters, " ")
+}
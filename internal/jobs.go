@@ -0,0 +1,362 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// EXTRACTION JOBS
+// ────────────────────────────────
+
+// maxQueuedJobs bounds how many jobs can wait for the worker at once.
+const maxQueuedJobs = 32
+
+type jobState int
+
+const (
+	jobQueued jobState = iota
+	jobExtracting
+	jobFound
+	jobFailed
+	jobPlaying
+	jobCanceled
+)
+
+var jobStateNames = [...]string{"queued", "extracting", "found", "failed", "playing", "canceled"}
+
+func (s jobState) String() string { return jobStateNames[s] }
+
+// finished reports whether the worker is done with a job in this state.
+func (s jobState) finished() bool { return s != jobQueued && s != jobExtracting }
+
+// jobInfo is a snapshot of one extraction job for display.
+type jobInfo struct {
+	ID      int
+	Title   string
+	Stream  Stream
+	Match   Match
+	State   jobState
+	Queued  time.Time
+	Elapsed time.Duration
+	Err     error
+	Result  extractResult
+}
+
+type job struct {
+	info   jobInfo
+	ctx    context.Context
+	cancel context.CancelFunc
+	run    func(context.Context) (extractResult, error)
+}
+
+// jobManager extracts queued streams one at a time on its own goroutine, so
+// several can be lined up without starting a browser for each. Jobs stay in
+// the list once finished until they are removed.
+type jobManager struct {
+	mu     sync.Mutex
+	jobs   []*job
+	nextID int
+	queue  chan *job
+	start  sync.Once
+	// onUpdate is told whenever a job changes state.
+	onUpdate func(jobInfo)
+}
+
+func newJobManager(onUpdate func(jobInfo)) *jobManager {
+	return &jobManager{queue: make(chan *job, maxQueuedJobs), onUpdate: onUpdate}
+}
+
+// Add queues an extraction of st; run does the extracting.
+func (q *jobManager) Add(st Stream, mt Match, run func(context.Context) (extractResult, error)) (jobInfo, error) {
+	q.start.Do(func() { go q.work() })
+
+	title := matchDisplayTitle(mt)
+	if title == "" {
+		title = st.EmbedURL
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q.mu.Lock()
+	for _, j := range q.jobs {
+		if j.info.Stream.EmbedURL == st.EmbedURL && !j.info.State.finished() {
+			q.mu.Unlock()
+			cancel()
+			return jobInfo{}, fmt.Errorf("stream #%d of %s is already queued", st.StreamNo, title)
+		}
+	}
+	q.nextID++
+	j := &job{
+		info:   jobInfo{ID: q.nextID, Title: title, Stream: st, Match: mt, State: jobQueued, Queued: time.Now()},
+		ctx:    ctx,
+		cancel: cancel,
+		run:    run,
+	}
+	select {
+	case q.queue <- j:
+	default:
+		q.mu.Unlock()
+		cancel()
+		return jobInfo{}, errors.New("the job queue is full")
+	}
+	q.jobs = append(q.jobs, j)
+	info := j.info
+	q.mu.Unlock()
+	return info, nil
+}
+
+// work runs queued jobs in order until the manager is closed.
+func (q *jobManager) work() {
+	for j := range q.queue {
+		if !q.transition(j, jobExtracting, func(s jobState) bool { return s == jobQueued }) {
+			continue
+		}
+		res, err := j.run(j.ctx)
+		q.mu.Lock()
+		j.info.Elapsed = time.Since(j.info.Queued)
+		switch {
+		case j.ctx.Err() != nil:
+			j.info.State = jobCanceled
+		case err != nil:
+			j.info.State, j.info.Err = jobFailed, err
+		default:
+			j.info.State, j.info.Result = jobFound, res
+		}
+		info := j.info
+		q.mu.Unlock()
+		q.onUpdate(info)
+	}
+}
+
+// transition moves j to state when ok accepts its current state, and
+// reports whether it did.
+func (q *jobManager) transition(j *job, state jobState, ok func(jobState) bool) bool {
+	q.mu.Lock()
+	if !ok(j.info.State) {
+		q.mu.Unlock()
+		return false
+	}
+	j.info.State = state
+	info := j.info
+	q.mu.Unlock()
+	q.onUpdate(info)
+	return true
+}
+
+func (q *jobManager) find(id int) *job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, j := range q.jobs {
+		if j.info.ID == id {
+			return j
+		}
+	}
+	return nil
+}
+
+// Played marks a found job as handed to the player.
+func (q *jobManager) Played(id int) {
+	if j := q.find(id); j != nil {
+		q.transition(j, jobPlaying, func(s jobState) bool { return s == jobFound || s == jobPlaying })
+	}
+}
+
+// Cancel stops a queued or running job.
+func (q *jobManager) Cancel(id int) bool {
+	j := q.find(id)
+	if j == nil {
+		return false
+	}
+	j.cancel()
+	// A running job is marked canceled by the worker once its extractor
+	// returns; a queued one never reaches it.
+	q.transition(j, jobCanceled, func(s jobState) bool { return s == jobQueued })
+	return true
+}
+
+// Remove drops a finished job from the list.
+func (q *jobManager) Remove(id int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, j := range q.jobs {
+		if j.info.ID == id && j.info.State.finished() {
+			q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the jobs in the order they were queued.
+func (q *jobManager) List() []jobInfo {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]jobInfo, 0, len(q.jobs))
+	for _, j := range q.jobs {
+		out = append(out, j.info)
+	}
+	return out
+}
+
+// Close cancels every job, killing a running extractor, and stops the
+// worker.
+func (q *jobManager) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, j := range q.jobs {
+		j.cancel()
+	}
+	close(q.queue)
+}
+
+// ────────────────────────────────
+// JOBS PANEL
+// ────────────────────────────────
+
+type jobUpdatedMsg jobInfo
+
+type jobKeys struct {
+	Play, Cancel key.Binding
+}
+
+func defaultJobKeys() jobKeys {
+	return jobKeys{
+		Play:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "play")),
+		Cancel: key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "cancel/remove")),
+	}
+}
+
+func newJobsList() *ListColumn[jobInfo] {
+	return NewListColumn[jobInfo]("Jobs", func(j jobInfo) string {
+		text := fmt.Sprintf("[%s] %s  #%d %s", j.State, j.Title, j.Stream.StreamNo, j.Stream.Source)
+		if j.State.finished() && j.State != jobCanceled {
+			text += "  " + formatElapsed(j.Elapsed)
+		}
+		switch {
+		case j.Err != nil:
+			text += fmt.Sprintf(" (%v)", j.Err)
+		case j.Result.URL != "":
+			text += " – " + j.Result.URL
+		}
+		return text
+	})
+}
+
+// queueStream adds the highlighted stream to the extraction queue.
+func (m *Model) queueStream(st Stream, mt Match) tea.Cmd {
+	if st.EmbedURL == "" || isAdminStream(st) {
+		return nil
+	}
+	mv := *m
+	info, err := m.jobs.Add(st, mt, func(ctx context.Context) (extractResult, error) {
+		return mv.extractStream(ctx, st, opJobs, mv.ui.Log)
+	})
+	if err != nil {
+		m.status = fmt.Sprintf("Not queued: %v", err)
+		return nil
+	}
+	m.status = fmt.Sprintf("Queued stream #%d of %s – J for jobs", st.StreamNo, info.Title)
+	m.refreshJobs()
+	return nil
+}
+
+// jobUpdated follows a job through its states: the progress indicator shows
+// the running one, and the status line says when a stream is ready.
+func (m *Model) jobUpdated(j jobInfo) tea.Cmd {
+	var cmd tea.Cmd
+	switch j.State {
+	case jobExtracting:
+		cmd = m.progress.start(opJobs, fmt.Sprintf("Job: stream #%d of %s", j.Stream.StreamNo, j.Title))
+	case jobFound:
+		m.progress.done(opJobs)
+		m.status = fmt.Sprintf("Stream #%d of %s is ready – J to play it", j.Stream.StreamNo, j.Title)
+	case jobFailed:
+		m.progress.done(opJobs)
+		m.status = fmt.Sprintf("Job for stream #%d of %s failed: %v", j.Stream.StreamNo, j.Title, j.Err)
+	case jobCanceled:
+		if j.Elapsed > 0 {
+			m.progress.done(opJobs)
+		}
+	}
+	m.refreshJobs()
+	return cmd
+}
+
+func (m *Model) openJobs() {
+	m.jobList.SetItems(m.jobs.List())
+	m.currentView = viewJobs
+}
+
+func (m *Model) refreshJobs() {
+	m.jobList.ReplaceItems(m.jobs.List(), func(a, b jobInfo) bool { return a.ID == b.ID })
+}
+
+// updateJobs handles keys while the jobs panel is open.
+func (m *Model) updateJobs(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.jobList.CursorUp()
+		return nil
+	case key.Matches(msg, m.keys.Down):
+		m.jobList.CursorDown()
+		return nil
+	}
+
+	j, ok := m.jobList.Selected()
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(msg, m.jobKeys.Cancel):
+		if j.State.finished() {
+			m.jobs.Remove(j.ID)
+		} else {
+			m.jobs.Cancel(j.ID)
+			m.status = fmt.Sprintf("Canceled job for stream #%d of %s", j.Stream.StreamNo, j.Title)
+		}
+		m.refreshJobs()
+	case key.Matches(msg, m.jobKeys.Play):
+		if j.State != jobFound && j.State != jobPlaying {
+			return nil
+		}
+		return m.playJob(j)
+	}
+	return nil
+}
+
+// playJob launches the stream a job found, asking for a quality first when
+// the quality setting says so.
+func (m Model) playJob(j jobInfo) tea.Cmd {
+	return safeCmd("player", m.crash, func() tea.Msg {
+		player, err := m.opts.newPlayer()
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("Player error: %v", err))
+		}
+		logcb := m.ui.Log
+		p := pendingLaunch{Stream: j.Stream, Match: j.Match, Result: j.Result, JobID: j.ID}
+		ctx, cancel := context.WithTimeout(context.Background(), m.opts.APITimeout)
+		pick, ask := selectVariant(ctx, proxyHTTPClient(m.opts.proxy()), m.opts.Quality, &p, logcb)
+		cancel()
+		if ask {
+			return pick
+		}
+		return m.launchPending(player, p, logcb)
+	})
+}
+
+func (m Model) renderJobsView() string {
+	header := m.styles.Title.Render("Extraction Jobs")
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter play found stream · x cancel or remove · Esc back"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.jobList.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyScriptDelay is the pause between scripted key presses so each one is
+// rendered and any resulting commands get a chance to start.
+const keyScriptDelay = 150 * time.Millisecond
+
+// namedKeys maps key script tokens to Bubble Tea key types. Any other single
+// character token is sent as a rune key.
+var namedKeys = map[string]tea.KeyType{
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"space":     tea.KeySpace,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"f1":        tea.KeyF1,
+	"ctrl+c":    tea.KeyCtrlC,
+	"ctrl+f":    tea.KeyCtrlF,
+	"ctrl+l":    tea.KeyCtrlL,
+	"ctrl+r":    tea.KeyCtrlR,
+	"ctrl+t":    tea.KeyCtrlT,
+	"ctrl+w":    tea.KeyCtrlW,
+	"ctrl+x":    tea.KeyCtrlX,
+}
+
+// keyStep is one entry of a key script: either a key press or a pause.
+type keyStep struct {
+	key  tea.KeyMsg
+	wait time.Duration
+}
+
+// loadKeyScript reads a key script from the --keys value. A value starting
+// with "@" names a file to read the script from.
+func loadKeyScript(arg string) ([]keyStep, error) {
+	script := arg
+	if path, ok := strings.CutPrefix(arg, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read key script: %w", err)
+		}
+		script = string(data)
+	}
+	return parseKeyScript(script)
+}
+
+// parseKeyScript turns a whitespace-separated list of key names into steps.
+// Tokens like "wait:2s" pause playback (e.g. until data has loaded), and a
+// token starting with "#" starts a comment that runs to the end of the line;
+// "\#" presses "#" itself.
+func parseKeyScript(script string) ([]keyStep, error) {
+	var steps []keyStep
+	for _, line := range strings.Split(script, "\n") {
+		for _, tok := range strings.Fields(line) {
+			if strings.HasPrefix(tok, "#") {
+				break
+			}
+			step, err := parseKeyToken(tok)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
+
+func parseKeyToken(tok string) (keyStep, error) {
+	if d, ok := strings.CutPrefix(tok, "wait:"); ok {
+		wait, err := time.ParseDuration(d)
+		if err != nil {
+			return keyStep{}, fmt.Errorf("key script: bad wait %q: %w", tok, err)
+		}
+		return keyStep{wait: wait}, nil
+	}
+	if kt, ok := namedKeys[strings.ToLower(tok)]; ok {
+		return keyStep{key: tea.KeyMsg{Type: kt}}, nil
+	}
+	if runes := []rune(tok); len(runes) == 1 {
+		return keyStep{key: tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}}, nil
+	}
+	// "alt+" before a single character holds Alt with it, as in "alt+1".
+	if len(tok) > 4 && strings.EqualFold(tok[:4], "alt+") {
+		if runes := []rune(tok[4:]); len(runes) == 1 {
+			return keyStep{key: tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: true}}, nil
+		}
+	}
+	// A backslash escapes a character that would otherwise mean something
+	// else, such as "#".
+	if runes := []rune(tok); len(runes) == 2 && runes[0] == '\\' {
+		return keyStep{key: tea.KeyMsg{Type: tea.KeyRunes, Runes: runes[1:]}}, nil
+	}
+	return keyStep{}, fmt.Errorf("key script: unknown key %q", tok)
+}
+
+// playKeyScript feeds the steps to the running program.
+func playKeyScript(p *tea.Program, steps []keyStep) {
+	for _, step := range steps {
+		if step.wait > 0 {
+			time.Sleep(step.wait)
+			continue
+		}
+		time.Sleep(keyScriptDelay)
+		p.Send(step.key)
+	}
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// FAVORITE KICKOFF ALERTS
+// ────────────────────────────────
+
+// kickoffAlertInterval is how often the match list is polled for favorites
+// that have kicked off. The list is usually served from the cache.
+const kickoffAlertInterval = time.Minute
+
+// kickoffTagPrefix marks the tags of kickoff notifications, so a click on
+// one is told apart from a reminder's.
+const kickoffTagPrefix = "kickoff:"
+
+type (
+	kickoffAlertTickMsg struct{}
+	// favoritesKickedOffMsg lists the favorites found live by a poll.
+	favoritesKickedOffMsg []Match
+)
+
+// kickoffAlerts remembers which favorites have been announced. Only
+// matches that kick off after the TUI started are, so a restart does not
+// repeat them all.
+type kickoffAlerts struct {
+	since    time.Time
+	notified map[string]Match
+}
+
+func newKickoffAlerts() *kickoffAlerts {
+	return &kickoffAlerts{since: time.Now(), notified: map[string]Match{}}
+}
+
+func kickoffAlertTick() tea.Cmd {
+	return tea.Tick(kickoffAlertInterval, func(time.Time) tea.Msg { return kickoffAlertTickMsg{} })
+}
+
+// kickoffAlertsEnabled reports whether favorites going live are announced.
+// Demo mode stays quiet.
+func (o Options) kickoffAlertsEnabled() bool { return !o.NoKickoffAlerts && !o.Demo }
+
+// pollFavoriteKickoffs looks for favorites that are live now, skipping the
+// request when nothing is starred.
+func (m Model) pollFavoriteKickoffs() tea.Cmd {
+	if m.favorites.Empty() {
+		return kickoffAlertTick()
+	}
+	provider, favs, since := m.provider, m.favorites, m.kickoffAlerts.since
+	poll := safeCmd("poll favorite kickoffs", m.crash, func() tea.Msg {
+		matches, err := provider.ListMatches(context.Background(), allSportID)
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("[kickoff] poll failed: %v", err))
+		}
+		return favoritesKickedOffMsg(favoritesKickedOff(matches, favs, since, time.Now()))
+	})
+	return tea.Batch(poll, kickoffAlertTick())
+}
+
+// favoritesKickedOff returns the favorites among matches that kicked off
+// after since and are still live at now.
+func favoritesKickedOff(matches []Match, favs *favoritesStore, since, now time.Time) []Match {
+	var live []Match
+	for _, mt := range matches {
+		start := time.UnixMilli(mt.Date)
+		if mt.Date > 0 && start.After(since) && !now.Before(start) && now.Sub(start) < liveWindow && favs.Matches(mt) {
+			live = append(live, mt)
+		}
+	}
+	return live
+}
+
+// kickoffNotification announces a favorite that kicked off, naming the
+// starred teams playing in it.
+func kickoffNotification(mt Match, favs *favoritesStore) notification {
+	title := matchDisplayTitle(mt)
+	body := fmt.Sprintf("Kicked off at %s", time.UnixMilli(mt.Date).Local().Format("15:04"))
+	var teams []string
+	for _, name := range matchTeams(mt) {
+		if favs.TeamStarred(name) {
+			teams = append(teams, name)
+		}
+	}
+	if len(teams) > 0 {
+		body += " – you follow " + strings.Join(teams, " and ")
+	}
+	return notification{
+		Summary: title + " is live",
+		Body:    body,
+		Tag:     kickoffTagPrefix + mt.ID,
+		Actions: []notificationAction{
+			{Key: notificationDefaultAction, Label: "Open stream"},
+			{Key: "open", Label: "Open stream"},
+		},
+	}
+}
+
+// handleFavoritesKickedOff announces favorites that were not live at the
+// last poll in the status line and as a notification.
+func (m *Model) handleFavoritesKickedOff(live []Match) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, mt := range live {
+		if _, done := m.kickoffAlerts.notified[mt.ID]; done {
+			continue
+		}
+		m.kickoffAlerts.notified[mt.ID] = mt
+		line := fmt.Sprintf("★ %s is live", matchDisplayTitle(mt))
+		m.status = line
+		note := kickoffNotification(mt, m.favorites)
+		m.hooks.Fire(newHookEvent(hookMatchStarted, mt, Stream{}, note.Summary+" – "+note.Body))
+		cmds = append(cmds, m.logToUI("[kickoff] "+line), m.notify(note))
+	}
+	return tea.Batch(cmds...)
+}
+
+// notifiedMatch returns the match behind a notification's tag: a favorite
+// that kicked off, or the match of a reminder.
+func (m Model) notifiedMatch(tag string) (Match, bool) {
+	if id, ok := strings.CutPrefix(tag, kickoffTagPrefix); ok {
+		mt, ok := m.kickoffAlerts.notified[id]
+		return mt, ok
+	}
+	job, ok := m.schedule.Find(jobReminder, tag)
+	if !ok {
+		return Match{}, false
+	}
+	return job.match(), true
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// LIVE STATUS
+// ────────────────────────────────
+
+// liveWindow is how long after kickoff a match is shown as live. The API only
+// sends start times, so this is a guess that covers most events.
+const liveWindow = 3 * time.Hour
+
+// liveBadge marks matches in progress; the filter finds them with "/live".
+const liveBadge = "LIVE"
+
+var liveBadgeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+
+type liveTickMsg struct{}
+
+// liveTick fires on the next minute boundary so countdowns change when the
+// clock does.
+func liveTick() tea.Cmd {
+	return tea.Every(time.Minute, func(time.Time) tea.Msg { return liveTickMsg{} })
+}
+
+// matchStatusLabel returns the LIVE badge for a match in progress, a
+// countdown for one that has not started, and "" for one that is over or has
+// no start time.
+func matchStatusLabel(mt Match, now time.Time) string {
+	if mt.Date <= 0 {
+		return ""
+	}
+	start := time.UnixMilli(mt.Date)
+	switch {
+	case now.Before(start):
+		return "starts in " + formatCountdown(start.Sub(now))
+	case now.Sub(start) < liveWindow:
+		return liveBadge
+	}
+	return ""
+}
+
+// formatCountdown renders a duration as "2d 4h", "1h 23m", or "12m", rounding
+// up so a match never reads "starts in 0m".
+func formatCountdown(d time.Duration) string {
+	mins := int((d + time.Minute - 1) / time.Minute)
+	days, hours, mins := mins/(24*60), mins/60%24, mins%60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	}
+	return fmt.Sprintf("%dm", mins)
+}
+
+// colorLiveBadge highlights the LIVE badge in a rendered match row that
+// starts with the kickoff label. Only the label is searched, so a title with
+// "LIVE" in it is left alone, as is a row truncated inside the label.
+func colorLiveBadge(label, text string) string {
+	idx := strings.Index(label, liveBadge)
+	if idx < 0 || !strings.HasPrefix(text, label) {
+		return text
+	}
+	return text[:idx] + liveBadgeStyle.Render(liveBadge) + text[idx+len(liveBadge):]
+}
+
+// refreshLiveStatus re-renders the match rows so badges, countdowns, and
+// relative kickoff times follow the clock, and re-applies the match filter
+// as matches go live and finish.
+func (m *Model) refreshLiveStatus() tea.Cmd {
+	if m.matchFilter != filterAllMatches {
+		m.applyMatches()
+	}
+	m.matches.Invalidate()
+	m.agenda.list.Invalidate()
+	m.search.results.Invalidate()
+	m.teams.matches.Invalidate()
+	return liveTick()
+}
+
+// ────────────────────────────────
+// KICKOFF TIMES
+// ────────────────────────────────
+
+// kickoffWidth pads relative kickoff times so titles line up.
+const kickoffWidth = 10
+
+// kickoffClock picks how match rows show start times: the date and time, or
+// how far away kickoff is. The list renderers share the model's, so toggling
+// it changes every list at once.
+type kickoffClock struct {
+	relative bool
+}
+
+// label renders mt's kickoff for a match row. Absolute times are followed by
+// the status label; relative ones replace it, since "LIVE" and "in 45m" say
+// the same thing.
+func (c *kickoffClock) label(mt Match, now time.Time) string {
+	if c.relative {
+		return fmt.Sprintf("%-*s", kickoffWidth, relativeKickoff(mt, now))
+	}
+	when := time.UnixMilli(mt.Date).Local().Format("Jan 2 15:04")
+	if status := matchStatusLabel(mt, now); status != "" {
+		when += "  " + status
+	}
+	return when
+}
+
+// relativeKickoff describes a match's start relative to now: LIVE while it is
+// in progress, "in 45m" before it starts, and "2h ago" once it is over.
+func relativeKickoff(mt Match, now time.Time) string {
+	if mt.Date <= 0 {
+		return ""
+	}
+	start := time.UnixMilli(mt.Date)
+	switch {
+	case now.Before(start):
+		return "in " + formatCountdown(start.Sub(now))
+	case now.Sub(start) < liveWindow:
+		return liveBadge
+	}
+	return formatAgo(now.Sub(start)) + " ago"
+}
+
+// formatAgo renders a past duration in its largest unit only, as "3d",
+// "5h", or "40m"; how long ago a match ended needs no more precision.
+func formatAgo(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dm", int(d/time.Minute))
+}
+
+// toggleRelativeTimes switches match rows between absolute and relative
+// kickoff times.
+func (m *Model) toggleRelativeTimes() {
+	m.kickoff.relative = !m.kickoff.relative
+	m.matches.Invalidate()
+	m.agenda.list.Invalidate()
+	m.search.results.Invalidate()
+	m.teams.matches.Invalidate()
+	if m.kickoff.relative {
+		m.status = "Showing kickoff times relative to now"
+	} else {
+		m.status = "Showing kickoff dates and times"
+	}
+}
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// LOG VIEWER
+// ────────────────────────────────
+
+const (
+	// debugLogLimit is how many debug lines are kept for the pane and the
+	// log viewer.
+	debugLogLimit = 1000
+	// logPreviewRows is how much of the highlighted line the viewer shows
+	// wrapped below the list, since long lines are cut off in it.
+	logPreviewRows = 3
+)
+
+// logLine is a debug line with its position since startup, which stays the
+// same when older lines are dropped.
+type logLine struct {
+	no   int
+	text string
+}
+
+type logViewKeys struct {
+	Search, Next, Prev, Copy key.Binding
+}
+
+func defaultLogViewKeys() logViewKeys {
+	return logViewKeys{
+		Search: key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		Next:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		Prev:   key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous match")),
+		Copy:   key.NewBinding(key.WithKeys("y", "c"), key.WithHelp("y", "copy line")),
+	}
+}
+
+// logView is the full-screen debug log: every kept line with a cursor,
+// searching, and copying.
+type logView struct {
+	lines     *ListColumn[logLine]
+	keys      logViewKeys
+	input     textinput.Model
+	searching bool
+	query     string
+}
+
+func newLogView() logView {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search log"
+	ti.CharLimit = 64
+	return logView{
+		lines: NewListColumn[logLine]("Debug log", func(l logLine) string { return l.text }),
+		keys:  defaultLogViewKeys(),
+		input: ti,
+	}
+}
+
+// appendDebugLine adds a line to the debug log, dropping the oldest beyond
+// debugLogLimit.
+func (m *Model) appendDebugLine(line string) {
+	m.debugLines = append(m.debugLines, line)
+	if drop := len(m.debugLines) - debugLogLimit; drop > 0 {
+		m.debugLines = m.debugLines[drop:]
+		m.debugDropped += drop
+	}
+	if m.currentView == viewLog {
+		m.refreshLogView()
+	}
+}
+
+func (m Model) logLines() []logLine {
+	out := make([]logLine, len(m.debugLines))
+	for i, text := range m.debugLines {
+		out[i] = logLine{no: m.debugDropped + i, text: text}
+	}
+	return out
+}
+
+// openLogView shows the debug log full screen with the newest line
+// highlighted.
+func (m *Model) openLogView() {
+	m.logView.lines.SetItems(m.logLines())
+	m.logView.lines.Jump(jumpBottom)
+	m.currentView = viewLog
+}
+
+// refreshLogView takes in new lines, following them when the newest line
+// was highlighted.
+func (m *Model) refreshLogView() {
+	list := m.logView.lines
+	cur, ok := list.Selected()
+	items := list.Items()
+	follow := !ok || cur.no == items[len(items)-1].no
+	list.ReplaceItems(m.logLines(), func(a, b logLine) bool { return a.no == b.no })
+	if follow {
+		list.Jump(jumpBottom)
+	}
+}
+
+// updateLogView handles keys while the log viewer is open.
+func (m *Model) updateLogView(msg tea.KeyMsg) tea.Cmd {
+	lv := &m.logView
+	if lv.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			lv.searching = false
+			lv.input.Blur()
+			lv.query = strings.TrimSpace(lv.input.Value())
+			m.findInLog(true, false)
+			return nil
+		case tea.KeyEsc:
+			lv.searching = false
+			lv.input.Blur()
+			return nil
+		}
+		var cmd tea.Cmd
+		lv.input, cmd = lv.input.Update(msg)
+		return cmd
+	}
+
+	switch {
+	case msg.String() == "esc", key.Matches(msg, m.keys.Log):
+		m.currentView = viewMain
+	case key.Matches(msg, m.keys.Up):
+		lv.lines.CursorUp()
+	case key.Matches(msg, m.keys.Down):
+		lv.lines.CursorDown()
+	case key.Matches(msg, m.keys.PageUp):
+		lv.lines.Jump(jumpPageUp)
+	case key.Matches(msg, m.keys.PageDown):
+		lv.lines.Jump(jumpPageDown)
+	case key.Matches(msg, m.keys.Top):
+		lv.lines.Jump(jumpTop)
+	case key.Matches(msg, m.keys.Bottom):
+		lv.lines.Jump(jumpBottom)
+	case key.Matches(msg, lv.keys.Search):
+		lv.searching = true
+		lv.input.SetValue(lv.query)
+		lv.input.CursorEnd()
+		return lv.input.Focus()
+	case key.Matches(msg, lv.keys.Next):
+		m.findInLog(true, true)
+	case key.Matches(msg, lv.keys.Prev):
+		m.findInLog(false, true)
+	case key.Matches(msg, lv.keys.Copy):
+		if l, ok := lv.lines.Selected(); ok {
+			return copyToClipboard("log line", l.text, m.remote)
+		}
+	}
+	return nil
+}
+
+// findInLog moves the cursor to the next line containing the search query
+// (case-insensitively), searching backwards when forward is false and
+// starting from the highlighted line itself unless skipCurrent is set. The
+// search wraps around.
+func (m *Model) findInLog(forward, skipCurrent bool) {
+	lv := &m.logView
+	if lv.query == "" {
+		return
+	}
+	items := lv.lines.Items()
+	cur, ok := lv.lines.Selected()
+	if !ok {
+		return
+	}
+	start := 0
+	for i, l := range items {
+		if l.no == cur.no {
+			start = i
+			break
+		}
+	}
+	step := 1
+	if !forward {
+		step = -1
+	}
+	if skipCurrent {
+		start += step
+	}
+	query := strings.ToLower(lv.query)
+	for n := 0; n < len(items); n++ {
+		i := ((start+n*step)%len(items) + len(items)) % len(items)
+		if strings.Contains(strings.ToLower(items[i].text), query) {
+			target := items[i].no
+			lv.lines.Select(func(l logLine) bool { return l.no == target })
+			m.status = ""
+			return
+		}
+	}
+	m.status = fmt.Sprintf("No log line matches %q", lv.query)
+}
+
+func (m Model) renderLogView() string {
+	lv := m.logView
+	header := m.styles.Title.Render(fmt.Sprintf("Debug log (%d lines)", len(m.debugLines)))
+	preview := ""
+	if l, ok := lv.lines.Selected(); ok {
+		preview = l.text
+	}
+	width := max(int(float64(m.TerminalWidth)*0.95), 20)
+	wrapped := strings.Split(lipgloss.NewStyle().Width(width).Render(m.styles.Text(preview)), "\n")
+	if len(wrapped) > logPreviewRows {
+		wrapped = wrapped[:logPreviewRows]
+	}
+	for len(wrapped) < logPreviewRows {
+		wrapped = append(wrapped, "")
+	}
+
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ pgup/pgdn home/end scroll · / search · n/N next/prev · y copy line · Esc back"))
+	if lv.searching {
+		hint = lv.input.View()
+	} else if lv.query != "" {
+		hint = m.styles.Subtle.Render(m.styles.Text(fmt.Sprintf("searching %q · ", lv.query))) + hint
+	}
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lv.lines.View(m.styles, true),
+		strings.Join(wrapped, "\n"),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
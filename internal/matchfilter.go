@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ────────────────────────────────
+// MATCH FILTERS
+// ────────────────────────────────
+
+// matchFilter narrows the Matches column to matches at one stage: live,
+// about to start, or not over yet.
+type matchFilter int
+
+const (
+	filterAllMatches matchFilter = iota
+	filterLive
+	// filterUpcoming keeps matches starting within Options.UpcomingWindow.
+	filterUpcoming
+	// filterUnfinished drops matches whose live window has passed.
+	filterUnfinished
+	matchFilterCount
+)
+
+var matchFilterNames = [...]string{"all", "live", "upcoming", "unfinished"}
+
+func (f matchFilter) String() string { return matchFilterNames[f] }
+
+// parseMatchFilter reads a saved filter name; unknown names show every
+// match.
+func parseMatchFilter(name string) matchFilter {
+	for i, n := range matchFilterNames {
+		if n == name {
+			return matchFilter(i)
+		}
+	}
+	return filterAllMatches
+}
+
+// keeps reports whether mt passes the filter at now. Matches without a start
+// time are never clearly finished, so only the live and upcoming filters
+// drop them.
+func (f matchFilter) keeps(mt Match, now time.Time, window time.Duration) bool {
+	if f == filterAllMatches {
+		return true
+	}
+	if mt.Date <= 0 {
+		return f == filterUnfinished
+	}
+	start := time.UnixMilli(mt.Date)
+	switch f {
+	case filterLive:
+		return !now.Before(start) && now.Sub(start) < liveWindow
+	case filterUpcoming:
+		return now.Before(start) && start.Sub(now) <= window
+	}
+	return now.Sub(start) < liveWindow
+}
+
+// label describes the filter in the Matches column title.
+func (f matchFilter) label(window time.Duration) string {
+	switch f {
+	case filterLive:
+		return "live"
+	case filterUpcoming:
+		return "next " + formatLead(window)
+	case filterUnfinished:
+		return "not finished"
+	}
+	return ""
+}
+
+// filterMatches returns the matches that pass f at now.
+func filterMatches(matches []Match, f matchFilter, now time.Time, window time.Duration) []Match {
+	if f == filterAllMatches {
+		return matches
+	}
+	var out []Match
+	for _, mt := range matches {
+		if f.keeps(mt, now, window) {
+			out = append(out, mt)
+		}
+	}
+	return out
+}
+
+// setMatchFilter switches the Matches column to filter f, or back to every
+// match when f is already active.
+func (m *Model) setMatchFilter(f matchFilter) {
+	if m.matchFilter == f {
+		f = filterAllMatches
+	}
+	m.matchFilter = f
+	m.applyMatches()
+	shown, total := len(m.matches.Items()), len(m.allMatches)
+	switch f {
+	case filterLive:
+		m.status = fmt.Sprintf("Showing live matches only (%d of %d)", shown, total)
+	case filterUpcoming:
+		m.status = fmt.Sprintf("Showing matches starting in the next %s (%d of %d)", formatLead(m.opts.UpcomingWindow), shown, total)
+	case filterUnfinished:
+		m.status = fmt.Sprintf("Hiding finished matches (%d of %d)", shown, total)
+	default:
+		m.status = fmt.Sprintf("Showing all %d matches", total)
+	}
+}
+
+// matchFiltersLabel names the active match filter and category for the
+// Matches column title, "" when every match is shown.
+func (m Model) matchFiltersLabel() string {
+	var labels []string
+	if label := m.matchFilter.label(m.opts.UpcomingWindow); label != "" {
+		labels = append(labels, label)
+	}
+	if m.matchCategory != "" {
+		labels = append(labels, categoryLabel(m.matchCategory))
+	}
+	return strings.Join(labels, " · ")
+}
+
+// matchFilterFooter explains an empty Matches column when the filters hid
+// every match.
+func (m Model) matchFilterFooter(shown int) string {
+	label := m.matchFiltersLabel()
+	if label == "" || shown > 0 || len(m.allMatches) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("all %d matches filtered out (%s)", len(m.allMatches), label)
+}
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+// ────────────────────────────────
+// API MIRRORS
+// ────────────────────────────────
+
+// mirrorSet is the list of hosts serving the same API, in order of
+// preference, and the one requests currently go to. Requests run from many
+// goroutines, so access is serialized.
+type mirrorSet struct {
+	mu     sync.Mutex
+	bases  []string
+	active int
+}
+
+// newMirrorSet returns the mirrors with primary first and duplicates
+// dropped, or nil when there is only the primary.
+func newMirrorSet(primary string, mirrors []string) *mirrorSet {
+	bases := []string{strings.TrimRight(primary, "/")}
+	for _, m := range mirrors {
+		m = strings.TrimRight(strings.TrimSpace(m), "/")
+		if m != "" && !containsFold(bases, m) {
+			bases = append(bases, m)
+		}
+	}
+	if len(bases) == 1 {
+		return nil
+	}
+	return &mirrorSet{bases: bases}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *mirrorSet) current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bases[s.active]
+}
+
+// position returns the active mirror's 1-based index and the mirror count.
+func (s *mirrorSet) position() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active + 1, len(s.bases)
+}
+
+// split returns the mirror rawURL is on and the rest of it.
+func (s *mirrorSet) split(rawURL string) (base, path string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.bases {
+		if strings.HasPrefix(rawURL, b+"/") {
+			return b, strings.TrimPrefix(rawURL, b), true
+		}
+	}
+	return "", "", false
+}
+
+// fallbacks returns the mirrors to try after failed did: the active one
+// first unless it is the one that failed, then the others in order.
+func (s *mirrorSet) fallbacks(failed string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, 0, len(s.bases))
+	if b := s.bases[s.active]; b != failed {
+		out = append(out, b)
+	}
+	for _, b := range s.bases {
+		if b != failed && !containsFold(out, b) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// use makes base the active mirror and reports whether it was not already.
+func (s *mirrorSet) use(base string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, b := range s.bases {
+		if b == base {
+			changed := i != s.active
+			s.active = i
+			return changed
+		}
+	}
+	return false
+}
+
+// SetMirrors lets the client fail over to mirrors of its API when the one it
+// uses times out or answers with server errors. The client's own base stays
+// the first choice.
+func (c *Client) SetMirrors(mirrors []string) { c.mirrors = newMirrorSet(c.base, mirrors) }
+
+// MirrorPosition returns the active mirror's 1-based index and how many
+// there are; 1 of 1 without mirrors.
+func (c *Client) MirrorPosition() (int, int) {
+	if c.mirrors == nil {
+		return 1, 1
+	}
+	return c.mirrors.position()
+}
+
+// failover repeats a request that failed transiently on the other mirrors,
+// one attempt each, and switches to the first that answers.
+func (c *Client) failover(ctx context.Context, rawURL string, err error) (response, error) {
+	if c.mirrors == nil {
+		return response{}, err
+	}
+	failed, path, ok := c.mirrors.split(rawURL)
+	if !ok {
+		return response{}, err
+	}
+	for _, base := range c.mirrors.fallbacks(failed) {
+		if ctx.Err() != nil {
+			return response{}, err
+		}
+		res, _, ferr := c.fetchOnce(ctx, base+path)
+		if ferr != nil {
+			c.logf("[mirror] %s failed too: %v", apiHost(base), ferr)
+			continue
+		}
+		if c.mirrors.use(base) {
+			c.logf("[mirror] %s failed (%v) – switched to %s", apiHost(failed), err, apiHost(base))
+		}
+		return res, nil
+	}
+	return response{}, err
+}
+
+// mirrorStatus is how a mirror answered the startup check.
+type mirrorStatus struct {
+	Base    string
+	Latency time.Duration
+	Err     error
+}
+
+// CheckMirrors asks every mirror for the sports list at once and makes the
+// first one that answers, in order of preference, the active one. It
+// returns each mirror's result and whether the active mirror changed.
+func (c *Client) CheckMirrors(ctx context.Context) ([]mirrorStatus, bool) {
+	if c.mirrors == nil {
+		return nil, false
+	}
+	c.mirrors.mu.Lock()
+	bases := append([]string(nil), c.mirrors.bases...)
+	c.mirrors.mu.Unlock()
+
+	results := make([]mirrorStatus, len(bases))
+	var g errgroup.Group
+	for i, base := range bases {
+		g.Go(func() error {
+			start := time.Now()
+			var sports []Sport
+			err := c.getOnce(ctx, base+"/api/sports", &sports)
+			results[i] = mirrorStatus{Base: base, Latency: time.Since(start), Err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	for _, r := range results {
+		if r.Err == nil {
+			return results, c.mirrors.use(r.Base)
+		}
+	}
+	return results, false
+}
+
+// mirrorsCheckedMsg reports the startup mirror check.
+type mirrorsCheckedMsg struct {
+	Results  []mirrorStatus
+	Switched bool
+}
+
+// checkMirrors runs the startup mirror check, logging how each mirror
+// answered.
+func (m Model) checkMirrors() tea.Cmd {
+	if _, n := m.apiClient.MirrorPosition(); n < 2 {
+		return nil
+	}
+	return safeCmd("mirror check", m.crash, func() tea.Msg {
+		results, switched := m.apiClient.CheckMirrors(context.Background())
+		for _, r := range results {
+			if r.Err != nil {
+				m.ui.Log(fmt.Sprintf("[mirror] %s: %v", apiHost(r.Base), r.Err))
+			} else {
+				m.ui.Log(fmt.Sprintf("[mirror] %s answered in %s", apiHost(r.Base), r.Latency.Round(time.Millisecond)))
+			}
+		}
+		return mirrorsCheckedMsg{Results: results, Switched: switched}
+	})
+}
+
+// mirrorsChecked reports in the status line when the preferred mirror is
+// down.
+func (m *Model) mirrorsChecked(msg mirrorsCheckedMsg) {
+	if len(msg.Results) == 0 {
+		return
+	}
+	active := apiHost(m.apiClient.Base())
+	switch first := msg.Results[0]; {
+	case first.Err == nil:
+	case msg.Switched || active != apiHost(first.Base):
+		m.status = fmt.Sprintf("API host %s is down – using mirror %s", apiHost(first.Base), active)
+	default:
+		m.status = fmt.Sprintf("None of the %d API mirrors answered", len(msg.Results))
+	}
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// ────────────────────────────────
+// MPV IPC CLIENT
+// ────────────────────────────────
+
+// mpvIPCTimeout bounds one round trip to mpv's IPC socket.
+const mpvIPCTimeout = 2 * time.Second
+
+var mpvSocketSeq atomic.Int64
+
+// mpvSocketPath returns a fresh path for mpv's --input-ipc-server. It is
+// empty on Windows, where mpv serves IPC on a named pipe instead of a Unix
+// socket. The path lives in the temp directory because Unix socket paths are
+// limited to about 100 bytes.
+func mpvSocketPath() string {
+	if runtime.GOOS == "windows" {
+		return ""
+	}
+	name := fmt.Sprintf("%s-mpv-%d-%d.sock", appName, os.Getpid(), mpvSocketSeq.Add(1))
+	return filepath.Join(os.TempDir(), name)
+}
+
+// mpvIPC talks to a running mpv over its JSON IPC socket. Every call opens its
+// own connection, so once the player exits calls simply fail.
+type mpvIPC struct {
+	path string
+}
+
+// mpvReply is one line mpv writes back. Event lines are interleaved with
+// command replies and are skipped.
+type mpvReply struct {
+	Data      json.RawMessage `json:"data"`
+	Error     string          `json:"error"`
+	RequestID int             `json:"request_id"`
+	Event     string          `json:"event"`
+}
+
+func (r mpvReply) err() error {
+	if r.Error == "success" {
+		return nil
+	}
+	return fmt.Errorf("mpv: %s", r.Error)
+}
+
+// run sends the commands over one connection and returns their replies in
+// order. A failing command does not stop the rest; its reply carries the
+// error.
+func (c mpvIPC) run(commands ...[]any) ([]mpvReply, error) {
+	if c.path == "" {
+		return nil, errors.New("mpv IPC is not available")
+	}
+	conn, err := net.DialTimeout("unix", c.path, mpvIPCTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect to mpv: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(mpvIPCTimeout))
+
+	for i, cmd := range commands {
+		req, err := json.Marshal(map[string]any{"command": cmd, "request_id": i + 1})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(append(req, '\n')); err != nil {
+			return nil, fmt.Errorf("write to mpv: %w", err)
+		}
+	}
+
+	replies := make([]mpvReply, len(commands))
+	pending := len(commands)
+	sc := bufio.NewScanner(conn)
+	for pending > 0 && sc.Scan() {
+		var r mpvReply
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil || r.Event != "" {
+			continue
+		}
+		if r.RequestID < 1 || r.RequestID > len(commands) {
+			continue
+		}
+		replies[r.RequestID-1] = r
+		pending--
+	}
+	if pending > 0 {
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("read from mpv: %w", err)
+		}
+		return nil, errors.New("mpv closed the connection")
+	}
+	return replies, nil
+}
+
+func (c mpvIPC) command(args ...any) error {
+	replies, err := c.run(args)
+	if err != nil {
+		return err
+	}
+	return replies[0].err()
+}
+
+// TogglePause pauses or resumes playback.
+func (c mpvIPC) TogglePause() error { return c.command("cycle", "pause") }
+
+// Seek moves playback by the given number of seconds.
+func (c mpvIPC) Seek(seconds float64) error { return c.command("seek", seconds, "relative") }
+
+// AddVolume changes the volume by delta percent.
+func (c mpvIPC) AddVolume(delta float64) error { return c.command("add", "volume", delta) }
+
+// Quit closes the player.
+func (c mpvIPC) Quit() error { return c.command("quit") }
+
+// mpvStatus is a snapshot of the player's state.
+type mpvStatus struct {
+	Paused   bool
+	Position float64 // seconds; zero until playback starts
+	Volume   float64
+	Title    string
+}
+
+// Status reads the properties shown in the control bar. Properties mpv
+// cannot report yet (no position before the first frame) are left zero.
+func (c mpvIPC) Status() (mpvStatus, error) {
+	replies, err := c.run(
+		[]any{"get_property", "pause"},
+		[]any{"get_property", "time-pos"},
+		[]any{"get_property", "volume"},
+		[]any{"get_property", "media-title"},
+	)
+	if err != nil {
+		return mpvStatus{}, err
+	}
+	var st mpvStatus
+	for i, dst := range []any{&st.Paused, &st.Position, &st.Volume, &st.Title} {
+		if replies[i].err() == nil && len(replies[i].Data) > 0 {
+			_ = json.Unmarshal(replies[i].Data, dst)
+		}
+	}
+	return st, nil
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// ────────────────────────────────
+// DESKTOP NOTIFICATIONS
+// ────────────────────────────────
+
+// notification is a desktop notification. Tag is handed back with the action
+// key when the user clicks one of the actions.
+type notification struct {
+	Summary string
+	Body    string
+	Tag     string
+	Actions []notificationAction
+}
+
+type notificationAction struct {
+	Key   string
+	Label string
+}
+
+// notificationActionMsg reports a clicked notification action to Update.
+type notificationActionMsg struct {
+	Tag    string
+	Action string
+}
+
+// notificationDefaultAction is the action key servers send when the body of
+// the notification itself is clicked.
+const notificationDefaultAction = "default"
+
+// notifySend shows a notification with the notify-send command, for when the
+// notification server cannot be reached over D-Bus directly.
+func notifySend(note notification) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return err
+	}
+	return exec.Command(path, "--app-name="+appName, note.Summary, note.Body).Run()
+}
+
+// ringBell rings the terminal bell, the TUI's last resort when no desktop
+// notification could be shown. It goes to stderr so it is not interleaved
+// with the frames Bubble Tea writes to stdout.
+func ringBell() {
+	_, _ = os.Stderr.WriteString("\a")
+}
+
+// notifyDesktop shows note over D-Bus, or with notify-send when that fails.
+// The error describes why both failed.
+func notifyDesktop(n *desktopNotifier, note notification) error {
+	dbusErr := n.Notify(note)
+	if dbusErr == nil {
+		return nil
+	}
+	if err := notifySend(note); err != nil {
+		return fmt.Errorf("%w; notify-send: %v", dbusErr, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build !windows && !darwin
+
+package internal
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	notifyService   = "org.freedesktop.Notifications"
+	notifyPath      = "/org/freedesktop/Notifications"
+	notifyInterface = "org.freedesktop.Notifications"
+)
+
+// desktopNotifier talks to org.freedesktop.Notifications on the session bus
+// and reports clicked actions through onAction. The bus is dialed on first
+// use so startup never waits on D-Bus.
+type desktopNotifier struct {
+	onAction func(tag, action string)
+
+	once sync.Once
+	conn *dbus.Conn
+	err  error
+
+	mu   sync.Mutex
+	tags map[uint32]string
+}
+
+func newDesktopNotifier(onAction func(tag, action string)) *desktopNotifier {
+	return &desktopNotifier{onAction: onAction, tags: map[uint32]string{}}
+}
+
+func (n *desktopNotifier) connect() error {
+	n.once.Do(func() {
+		conn, err := dbus.ConnectSessionBus()
+		if err != nil {
+			n.err = fmt.Errorf("connect to session bus: %w", err)
+			return
+		}
+		if err := conn.AddMatchSignal(
+			dbus.WithMatchObjectPath(notifyPath),
+			dbus.WithMatchInterface(notifyInterface),
+		); err != nil {
+			conn.Close()
+			n.err = fmt.Errorf("subscribe to notification signals: %w", err)
+			return
+		}
+		signals := make(chan *dbus.Signal, 16)
+		conn.Signal(signals)
+		go n.listen(signals)
+		n.conn = conn
+	})
+	return n.err
+}
+
+func (n *desktopNotifier) listen(signals <-chan *dbus.Signal) {
+	for sig := range signals {
+		switch sig.Name {
+		case notifyInterface + ".ActionInvoked":
+			var id uint32
+			var action string
+			if err := dbus.Store(sig.Body, &id, &action); err != nil {
+				continue
+			}
+			n.mu.Lock()
+			tag, ok := n.tags[id]
+			n.mu.Unlock()
+			if ok && n.onAction != nil {
+				n.onAction(tag, action)
+			}
+		case notifyInterface + ".NotificationClosed":
+			var id, reason uint32
+			if err := dbus.Store(sig.Body, &id, &reason); err != nil {
+				continue
+			}
+			n.mu.Lock()
+			delete(n.tags, id)
+			n.mu.Unlock()
+		}
+	}
+}
+
+// Notify shows a notification. Servers that do not support actions simply
+// show the text.
+func (n *desktopNotifier) Notify(note notification) error {
+	if n == nil {
+		return nil
+	}
+	if err := n.connect(); err != nil {
+		return err
+	}
+	actions := make([]string, 0, len(note.Actions)*2)
+	for _, a := range note.Actions {
+		actions = append(actions, a.Key, a.Label)
+	}
+	hints := map[string]dbus.Variant{"desktop-entry": dbus.MakeVariant(appName)}
+
+	var id uint32
+	call := n.conn.Object(notifyService, notifyPath).Call(notifyInterface+".Notify", 0,
+		appName, uint32(0), "", note.Summary, note.Body, actions, hints, int32(-1))
+	if err := call.Store(&id); err != nil {
+		return fmt.Errorf("send notification: %w", err)
+	}
+	if note.Tag != "" && len(note.Actions) > 0 {
+		n.mu.Lock()
+		n.tags[id] = note.Tag
+		n.mu.Unlock()
+	}
+	return nil
+}
+
+// Close releases the bus connection.
+func (n *desktopNotifier) Close() {
+	if n != nil && n.conn != nil {
+		n.conn.Close()
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows || darwin
+
+package internal
+
+import "errors"
+
+// desktopNotifier is a stub on platforms without a freedesktop notification
+// server.
+type desktopNotifier struct{}
+
+func newDesktopNotifier(func(tag, action string)) *desktopNotifier {
+	return &desktopNotifier{}
+}
+
+func (n *desktopNotifier) Notify(notification) error {
+	return errors.New("desktop notifications are not supported on this platform")
+}
+
+func (n *desktopNotifier) Close() {}
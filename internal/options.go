@@ -0,0 +1,317 @@
+package internal
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultAPITimeout      = 15 * time.Second
+	defaultExtractTimeout  = 45 * time.Second
+	defaultCaptureTimeout  = 20 * time.Second
+	defaultAPIRetries      = 2
+	defaultFallbackStreams = 3
+	defaultWatchdogRetries = 3
+	defaultUpcomingWindow  = 6 * time.Hour
+
+	// extractLaunchSlack is added on top of the navigation and capture
+	// timeouts to cover Chromium startup and shutdown when computing the
+	// overall deadline of an extractor run.
+	extractLaunchSlack = 15 * time.Second
+)
+
+// Options carries the command-line settings shared by the TUI and the CLI
+// entry points.
+type Options struct {
+	// Version is the running build's version tag ("dev" when unset).
+	Version string
+	// NoUpdateCheck disables the startup check for newer releases.
+	NoUpdateCheck bool
+	// JSON makes the command-line modes print JSON on stdout; -e then
+	// prints the extracted stream instead of playing it.
+	JSON bool
+
+	Debug bool
+	// LogFile is where debug lines are logged; empty uses debug.log in the
+	// state directory.
+	LogFile string
+	// LogLevel is the lowest level written to the log file ("debug",
+	// "info", "warn", "error", or "off"); empty is info, or debug with
+	// Debug.
+	LogLevel string
+	// SchemaCheck reports API schema drift (unknown or missing JSON fields)
+	// in the debug pane.
+	SchemaCheck bool
+	// Strict rejects non-JSON or malformed API responses with a clear error
+	// instead of rendering empty lists.
+	Strict bool
+
+	// BaseURL overrides STREAMED_BASE when non-empty.
+	BaseURL string
+	// Mirrors are further hosts of the same API, failed over to in order
+	// when the base URL times out or answers with server errors.
+	Mirrors []string
+	// ViewCountURL is where popular view counts are fetched from; empty
+	// uses the API host's endpoint.
+	ViewCountURL string
+	// Providers are further sources of matches, merged with the streamed
+	// API's in the TUI.
+	Providers []providerConfig
+
+	// Demo serves bundled fixture data and a fake extractor instead of the
+	// live API.
+	Demo bool
+
+	// ASCII replaces box-drawing borders, glyphs, and emoji with plain ASCII.
+	ASCII bool
+	// Glyphs overrides the cursor, focus, and separator characters; empty
+	// fields keep their defaults.
+	Glyphs Glyphs
+	// RelativeTimes shows kickoff times relative to now ("in 45m", "LIVE",
+	// "2h ago") instead of the date and time; Ctrl+T toggles it.
+	RelativeTimes bool
+
+	// Languages lists preferred stream languages, most preferred first.
+	// Streams picked automatically are limited to these when set.
+	Languages []string
+	// StreamPreference ranks streams in the Streams column and for automatic
+	// picks, most preferred first. Entries name a language, a quality ("HD"
+	// or "SD"), or both, such as "English HD".
+	StreamPreference []string
+
+	// NoProbe skips checking that the extracted playlist answers before the
+	// player is started.
+	NoProbe bool
+	// NoKickoffAlerts stops the notifications sent when a starred match or
+	// a starred team's match kicks off.
+	NoKickoffAlerts bool
+	// Webhooks are URLs the notify daemon POSTs each reminder and kickoff
+	// to as JSON.
+	Webhooks []string
+	// Hooks are commands run and URLs POSTed to on events such as a stream
+	// being extracted or a player launched.
+	Hooks []hookConfig
+	// NoAdBlock lets the runner load ad, analytics, and popup domains that
+	// are blocked by default.
+	NoAdBlock bool
+	// LoadAssets lets the runner load images, fonts, and stylesheets, which
+	// are skipped by default since the player does not need them.
+	LoadAssets bool
+
+	// Images shows match posters and team badges in the details column:
+	// "auto" detects the terminal's graphics protocol, "kitty", "iterm2", or
+	// "sixel" force one, and "off" disables them.
+	Images string
+
+	// Proxy routes API requests, extraction, and playback through an HTTP or
+	// SOCKS5 proxy (http://host:port, socks5://host:port); empty falls back
+	// to STREAMED_PROXY.
+	Proxy string
+
+	// Extractors lists the extractor backends to try, in order: "regex",
+	// "chromedp", "puppeteer", or "yt-dlp".
+	Extractors []string
+	// BrowserPath is the Chrome or Chromium binary chromedp launches; empty
+	// searches PATH and the usual install locations.
+	BrowserPath string
+
+	// Runtime selects the JavaScript runtime for the runner ("node", "bun",
+	// or "deno"); empty picks the first one installed.
+	Runtime string
+	// RunnerPath points the extractor at a user-supplied runner script
+	// instead of the built-in one.
+	RunnerPath string
+
+	// Player is the mpv-compatible binary streams are handed to.
+	Player string
+	// PlayerBackend is how streams reach the player: "mpv" launches it
+	// directly, "streamlink" fetches the stream and pipes it into Player.
+	PlayerBackend string
+	// StreamlinkPath is the streamlink executable; empty uses PATH.
+	StreamlinkPath string
+	// PlayerCommand, when set, replaces the player backend with a command
+	// line template such as "vlc --http-referrer={referer} {url}".
+	PlayerCommand string
+	// FallbackStreams is how many of the following streams of a match are
+	// tried, in list order, when extracting the chosen one fails; 0 only
+	// tries the chosen stream.
+	FallbackStreams int
+	// Quality picks among the variants of a master playlist: "ask" shows a
+	// picker, "auto" leaves it to the player, "best" takes the highest
+	// bandwidth, and a height such as "720p" the best variant up to it.
+	Quality string
+
+	// FFmpegPath is the ffmpeg executable used for recording; empty uses
+	// PATH.
+	FFmpegPath string
+	// RecordDir is where recordings are saved; empty uses "recordings" in
+	// the data directory.
+	RecordDir string
+	// DownloadDir is where yt-dlp downloads are saved; empty uses
+	// "downloads" in the data directory.
+	DownloadDir string
+
+	// DefaultSport is the sport (id or name) whose matches are shown on
+	// startup instead of Popular.
+	DefaultSport string
+	// UpcomingWindow is how soon matches must start to pass the upcoming
+	// filter of the Matches column.
+	UpcomingWindow time.Duration
+
+	// Fullscreen opens mpv with --fs on every launch.
+	Fullscreen bool
+	// Watchdog, when positive, relaunches a player that exits with an error
+	// within this long of starting, extracting the stream again (or the next
+	// ones) up to WatchdogRetries times per match.
+	Watchdog        time.Duration
+	WatchdogRetries int
+	// KillPlayersOnQuit stops the players launched from the TUI when it
+	// quits; otherwise they keep playing.
+	KillPlayersOnQuit bool
+	// DiscordClientID, when set, shows the match being watched as the
+	// Discord activity, using the Discord application with this ID.
+	DiscordClientID string
+	// CastPort is the port the cast relay serves streams to Chromecast and
+	// DLNA devices on; 0 picks a free one.
+	CastPort int
+	// FullscreenScreen picks the screen for fullscreen playback (mpv
+	// --fs-screen); negative leaves it to mpv.
+	FullscreenScreen int
+	// MPVArgs are extra mpv arguments added to every launch, such as
+	// "--profile=low-latency"; MPVArgsFullscreen and MPVArgsWindowed are
+	// added only to fullscreen or windowed launches.
+	MPVArgs           []string
+	MPVArgsFullscreen []string
+	MPVArgsWindowed   []string
+	// ForwardCookies also sends the captured cookies to the player, for
+	// hosts that want the embed page's session.
+	ForwardCookies bool
+	// PlaybackHeaders maps playlist domains to the captured headers forwarded
+	// to the player for them, replacing the default User-Agent, Origin, and
+	// Referer; "*" forwards all of them.
+	PlaybackHeaders map[string][]string
+	// HeaderProfiles set the Origin, Referer, and User-Agent sent for streams
+	// embedded from some domains, replacing what extraction captured.
+	HeaderProfiles headerProfiles
+
+	// KeyScript is a list of keys (or "@file") replayed into the TUI on
+	// startup.
+	KeyScript string
+
+	// APITimeout bounds each request to the streamed API.
+	APITimeout time.Duration
+	// APIRetries is how often an API request that failed transiently is
+	// retried.
+	APIRetries int
+	// CacheTTL sets how long sports, match lists, and view counts are reused
+	// before being fetched again.
+	CacheTTL CacheTTLs
+	// DiskCache also keeps cached API responses on disk between runs.
+	DiskCache bool
+	// ExtractTimeout bounds the embed page navigation inside the runner.
+	ExtractTimeout time.Duration
+	// CaptureTimeout is how long the runner waits for an .m3u8 request after
+	// navigation before falling back to scanning the DOM.
+	CaptureTimeout time.Duration
+}
+
+// DefaultOptions returns the settings used when no flags are given.
+func DefaultOptions() Options {
+	return Options{
+		APITimeout:      defaultAPITimeout,
+		ExtractTimeout:  defaultExtractTimeout,
+		CaptureTimeout:  defaultCaptureTimeout,
+		APIRetries:      defaultAPIRetries,
+		FallbackStreams: defaultFallbackStreams,
+		WatchdogRetries: defaultWatchdogRetries,
+		UpcomingWindow:  defaultUpcomingWindow,
+		CacheTTL: CacheTTLs{
+			Sports:  defaultSportsCacheTTL,
+			Matches: defaultMatchesCacheTTL,
+			Viewers: defaultViewersCacheTTL,
+		},
+
+		Player:           "mpv",
+		FullscreenScreen: -1,
+	}
+}
+
+// withDefaults fills zero or negative durations with their defaults.
+func (o Options) withDefaults() Options {
+	def := DefaultOptions()
+	if o.APITimeout <= 0 {
+		o.APITimeout = def.APITimeout
+	}
+	if o.ExtractTimeout <= 0 {
+		o.ExtractTimeout = def.ExtractTimeout
+	}
+	if o.CaptureTimeout <= 0 {
+		o.CaptureTimeout = def.CaptureTimeout
+	}
+	if o.Player == "" {
+		o.Player = def.Player
+	}
+	if o.UpcomingWindow <= 0 {
+		o.UpcomingWindow = def.UpcomingWindow
+	}
+	if len(o.Extractors) == 0 {
+		o.Extractors = defaultExtractors
+	}
+	return o
+}
+
+// extractOptions tunes a single extractor run.
+type extractOptions struct {
+	NavTimeout     time.Duration
+	CaptureTimeout time.Duration
+	// BlockAds aborts requests to known ad/tracker domains and closes popups.
+	BlockAds bool
+	// BlockAssets aborts image, font, and stylesheet requests.
+	BlockAssets bool
+	// Backends are the extractor backends to try, in order.
+	Backends []string
+	// BrowserPath is the browser chromedp launches; empty auto-detects.
+	BrowserPath string
+	// RunnerPath replaces the built-in runner script when set.
+	RunnerPath string
+	// Runtime names the JavaScript runtime; empty auto-detects.
+	Runtime string
+	// Proxy is the proxy URL for every backend; empty connects directly.
+	Proxy string
+	// HeaderProfiles replace the captured headers for some embed domains.
+	HeaderProfiles headerProfiles
+
+	// OnPhase, when set, is told which stage the extraction has reached.
+	OnPhase func(string)
+}
+
+func (e extractOptions) phase(name string) {
+	if e.OnPhase != nil {
+		e.OnPhase(name)
+	}
+}
+
+func (o Options) extractOptions() extractOptions {
+	return extractOptions{
+		NavTimeout:     o.ExtractTimeout,
+		CaptureTimeout: o.CaptureTimeout,
+		BlockAds:       !o.NoAdBlock,
+		BlockAssets:    !o.LoadAssets,
+		Backends:       o.Extractors,
+		BrowserPath:    o.BrowserPath,
+		RunnerPath:     o.RunnerPath,
+		Runtime:        o.Runtime,
+		Proxy:          o.proxy(),
+		HeaderProfiles: o.HeaderProfiles,
+	}
+}
+
+// httpClient returns the client for embed pages and playlists.
+func (e extractOptions) httpClient() *http.Client {
+	return proxyHTTPClient(e.Proxy)
+}
+
+// deadline returns the time budget for one backend's attempt.
+func (e extractOptions) deadline() time.Duration {
+	return e.NavTimeout + e.CaptureTimeout + extractLaunchSlack
+}
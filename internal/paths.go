@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// appName names the per-application directory inside each base directory.
+const appName = "streamed-tui"
+
+// ────────────────────────────────
+// PLATFORM DIRECTORIES
+// ────────────────────────────────
+//
+// Linux and other Unix systems follow the XDG base directory spec. macOS uses
+// ~/Library/Application Support (config, data, state) and ~/Library/Caches.
+// Windows uses %AppData% for config and data and %LocalAppData% for cache and
+// state.
+
+// configDir holds user-edited settings such as the config file.
+func configDir() (string, error) {
+	return appDir("XDG_CONFIG_HOME", ".config", "Application Support", "APPDATA")
+}
+
+// dataDir holds user data the app writes, such as favorites and history.
+func dataDir() (string, error) {
+	return appDir("XDG_DATA_HOME", filepath.Join(".local", "share"), "Application Support", "APPDATA")
+}
+
+// stateDir holds logs, crash reports, and other state worth keeping between
+// runs but not worth backing up.
+func stateDir() (string, error) {
+	return appDir("XDG_STATE_HOME", filepath.Join(".local", "state"), "Application Support", "LOCALAPPDATA")
+}
+
+// cacheDir holds disposable data such as the unpacked node_modules tree.
+func cacheDir() (string, error) {
+	return appDir("XDG_CACHE_HOME", ".cache", "Caches", "LOCALAPPDATA")
+}
+
+func appDir(xdgEnv, xdgDefault, macDir, winEnv string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if base := os.Getenv(winEnv); base != "" {
+			return filepath.Join(base, appName), nil
+		}
+		return "", errors.New("%" + winEnv + "% is not set")
+	case "darwin", "ios":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", macDir, appName), nil
+	default:
+		if base := os.Getenv(xdgEnv); filepath.IsAbs(base) {
+			return filepath.Join(base, appName), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, xdgDefault, appName), nil
+	}
+}
+
+// ensureAppDir resolves one of the directory functions above, falling back to
+// $TMPDIR when the platform directory cannot be determined, and creates it
+// along with any subdirectories given.
+func ensureAppDir(resolve func() (string, error), sub ...string) (string, error) {
+	dir, err := resolve()
+	if err != nil {
+		dir = filepath.Join(os.TempDir(), appName)
+	}
+	dir = filepath.Join(append([]string{dir}, sub...)...)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// expandHome replaces a leading "~/" in a configured path with the home
+// directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// PLAYBACK CONTROLS
+// ────────────────────────────────
+
+const (
+	// playbackPollInterval is how often the running player is queried for
+	// the control bar.
+	playbackPollInterval = time.Second
+	// playbackStartGrace is how long a new player may take to open its IPC
+	// socket before it is considered gone.
+	playbackStartGrace = 10 * time.Second
+	playbackSeekStep   = 10
+	playbackVolumeStep = 5
+)
+
+// playbackState is the mpv instance the control bar talks to: the most
+// recently launched one. The zero value means no player is controllable.
+type playbackState struct {
+	ipc       mpvIPC
+	title     string
+	startedAt time.Time
+	status    mpvStatus
+	connected bool
+}
+
+func (p playbackState) active() bool { return p.ipc.path != "" }
+
+type (
+	playerStartedMsg struct {
+		Socket string
+		Title  string
+	}
+	playbackTickMsg   struct{ Socket string }
+	playbackStatusMsg struct {
+		Socket string
+		Status mpvStatus
+		Err    error
+		// Tick is set for the periodic poll, which schedules the next one.
+		Tick bool
+	}
+)
+
+type playbackKeys struct {
+	Pause, SeekBack, SeekForward key.Binding
+	VolumeDown, VolumeUp, Stop   key.Binding
+}
+
+func defaultPlaybackKeys() playbackKeys {
+	return playbackKeys{
+		Pause:       key.NewBinding(key.WithKeys(" ", "space"), key.WithHelp("space", "pause")),
+		SeekBack:    key.NewBinding(key.WithKeys("["), key.WithHelp("[", "back 10s")),
+		SeekForward: key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "forward 10s")),
+		VolumeDown:  key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "volume down")),
+		VolumeUp:    key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "volume up")),
+		Stop:        key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "stop player")),
+	}
+}
+
+func playbackTick(socket string) tea.Cmd {
+	return tea.Tick(playbackPollInterval, func(time.Time) tea.Msg { return playbackTickMsg{Socket: socket} })
+}
+
+// pollPlayback reads the player's status for the control bar.
+func (m Model) pollPlayback(tick bool) tea.Cmd {
+	ipc := m.playing.ipc
+	return safeCmd("poll player", m.crash, func() tea.Msg {
+		st, err := ipc.Status()
+		return playbackStatusMsg{Socket: ipc.path, Status: st, Err: err, Tick: tick}
+	})
+}
+
+// startPlayback makes a newly launched player the one the control bar
+// drives.
+func (m *Model) startPlayback(msg playerStartedMsg) tea.Cmd {
+	m.playing = playbackState{ipc: mpvIPC{path: msg.Socket}, title: msg.Title, startedAt: time.Now()}
+	return playbackTick(msg.Socket)
+}
+
+// handlePlaybackStatus updates the control bar. The player is dropped once
+// its socket stops answering, allowing for a slow start.
+func (m *Model) handlePlaybackStatus(msg playbackStatusMsg) tea.Cmd {
+	if msg.Socket != m.playing.ipc.path {
+		return nil
+	}
+	if msg.Err != nil {
+		if !m.playing.connected && time.Since(m.playing.startedAt) < playbackStartGrace {
+			if msg.Tick {
+				return playbackTick(msg.Socket)
+			}
+			return nil
+		}
+		title := m.playing.title
+		m.playing = playbackState{}
+		m.status = fmt.Sprintf("Player closed: %s", title)
+		return m.logToUI(fmt.Sprintf("[mpv] IPC closed: %v", msg.Err))
+	}
+	m.playing.connected = true
+	m.playing.status = msg.Status
+	if msg.Tick {
+		return playbackTick(msg.Socket)
+	}
+	return nil
+}
+
+// updatePlayback handles the control keys while a player is running and
+// reports whether the key was one of them.
+func (m *Model) updatePlayback(msg tea.KeyMsg) (tea.Cmd, bool) {
+	k := m.playbackKeys
+	var (
+		action string
+		run    func(mpvIPC) error
+	)
+	switch {
+	case key.Matches(msg, k.Pause):
+		action, run = "pause", mpvIPC.TogglePause
+	case key.Matches(msg, k.SeekBack):
+		action, run = "seek", func(c mpvIPC) error { return c.Seek(-playbackSeekStep) }
+	case key.Matches(msg, k.SeekForward):
+		action, run = "seek", func(c mpvIPC) error { return c.Seek(playbackSeekStep) }
+	case key.Matches(msg, k.VolumeDown):
+		action, run = "volume", func(c mpvIPC) error { return c.AddVolume(-playbackVolumeStep) }
+	case key.Matches(msg, k.VolumeUp):
+		action, run = "volume", func(c mpvIPC) error { return c.AddVolume(playbackVolumeStep) }
+	case key.Matches(msg, k.Stop):
+		action, run = "quit", mpvIPC.Quit
+		m.players.Stopping(m.playing.ipc.path)
+	default:
+		return nil, false
+	}
+
+	ipc := m.playing.ipc
+	return safeCmd("control player", m.crash, func() tea.Msg {
+		if err := run(ipc); err != nil {
+			return debugLogMsg(fmt.Sprintf("[mpv] %s failed: %v", action, err))
+		}
+		// After quit the status read fails, which clears the control bar.
+		st, err := ipc.Status()
+		return playbackStatusMsg{Socket: ipc.path, Status: st, Err: err}
+	}), true
+}
+
+// renderPlaybackBar shows the running player's state and its control keys.
+// It takes the place of the short help line while a player is running.
+func (m Model) renderPlaybackBar() string {
+	p := m.playing
+	state := "▶"
+	if p.status.Paused {
+		state = "⏸"
+	}
+	title := p.title
+	if title == "" {
+		title = p.status.Title
+	}
+	info := fmt.Sprintf("%s %s", state, title)
+	if !p.connected {
+		info += "  (connecting…)"
+	} else {
+		info += fmt.Sprintf("  %s  vol %.0f%%", formatPlaybackPosition(p.status.Position), p.status.Volume)
+	}
+	hint := "space pause · [/] seek 10s · 9/0 volume · X stop"
+	return m.styles.Status.Render(m.styles.Text(info)) + "  " + m.styles.Subtle.Render(m.styles.Text(hint))
+}
+
+// formatPlaybackPosition formats seconds as H:MM:SS, or M:SS under an hour.
+func formatPlaybackPosition(seconds float64) string {
+	d := time.Duration(seconds) * time.Second
+	h, mnt, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, mnt, s)
+	}
+	return fmt.Sprintf("%d:%02d", mnt, s)
+}
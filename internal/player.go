@@ -0,0 +1,424 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ────────────────────────────────
+// PLAYERS
+// ────────────────────────────────
+
+// Player hands a resolved stream to an external program.
+type Player interface {
+	Name() string
+	Launch(req playRequest, log func(string)) error
+}
+
+// playRequest is a stream ready to play.
+type playRequest struct {
+	URL     string
+	Headers map[string]string
+	// Fullscreen asks the player to start fullscreen.
+	Fullscreen bool
+	// Attach keeps the player on the current terminal and waits for it to
+	// exit; otherwise it is started detached.
+	Attach bool
+	// Title names what is playing, when known.
+	Title string
+	// Proxy is the proxy URL the player should fetch through, if any.
+	Proxy string
+	// IPCSocket is where mpv serves its JSON IPC for the playback controls;
+	// empty disables it. Other players ignore it.
+	IPCSocket string
+	// Started, when set, is handed a detached player's process once it has
+	// started, and is then responsible for waiting on it.
+	Started func(*exec.Cmd)
+}
+
+// Player backends accepted in Options.PlayerBackend.
+const (
+	playerMPV        = "mpv"
+	playerStreamlink = "streamlink"
+)
+
+// playbackHeaders are the captured headers forwarded to players unless the
+// header policy says otherwise. Extra headers from the browser session can
+// make mpv reject the request or send malformed values when duplicated.
+var playbackHeaders = []struct {
+	lookup  string
+	display string
+}{
+	{lookup: "user-agent", display: "User-Agent"},
+	{lookup: "origin", display: "Origin"},
+	{lookup: "referer", display: "Referer"},
+}
+
+// newPlayer returns the player backend selected in the options. A player
+// command template takes precedence over the backend.
+func (o Options) newPlayer() (Player, error) {
+	if strings.TrimSpace(o.PlayerCommand) != "" {
+		return newCommandPlayer(o.PlayerCommand)
+	}
+	switch strings.ToLower(o.PlayerBackend) {
+	case "", playerMPV:
+		return o.mpvPlayer(), nil
+	case playerStreamlink:
+		return streamlinkPlayer{binary: o.StreamlinkPath, player: o.Player, fsScreen: o.FullscreenScreen, args: o.mpvArgs(), headers: o.headerPolicy()}, nil
+	}
+	return nil, fmt.Errorf("unknown player backend %q (want %s or %s)", o.PlayerBackend, playerMPV, playerStreamlink)
+}
+
+// mpvFullscreenArgs returns mpv's fullscreen arguments; a negative screen
+// leaves the choice to mpv.
+func mpvFullscreenArgs(fsScreen int) []string {
+	args := []string{"--fs"}
+	if fsScreen >= 0 {
+		args = append(args, fmt.Sprintf("--fs-screen=%d", fsScreen))
+	}
+	return args
+}
+
+type mpvPlayer struct {
+	binary   string
+	fsScreen int
+	args     mpvArgs
+	headers  headerPolicy
+}
+
+// mpvArgs are the user's extra mpv arguments.
+type mpvArgs struct {
+	all, fullscreen, windowed []string
+}
+
+// forLaunch returns the arguments for a fullscreen or windowed launch.
+func (a mpvArgs) forLaunch(fullscreen bool) []string {
+	out := slices.Clone(a.all)
+	if fullscreen {
+		return append(out, a.fullscreen...)
+	}
+	return append(out, a.windowed...)
+}
+
+func (o Options) mpvArgs() mpvArgs {
+	return mpvArgs{all: o.MPVArgs, fullscreen: o.MPVArgsFullscreen, windowed: o.MPVArgsWindowed}
+}
+
+func (o Options) mpvPlayer() mpvPlayer {
+	return mpvPlayer{binary: o.Player, fsScreen: o.FullscreenScreen, args: o.mpvArgs(), headers: o.headerPolicy()}
+}
+
+func (mpvPlayer) Name() string { return playerMPV }
+
+func (p mpvPlayer) Launch(req playRequest, log func(string)) error {
+	if log == nil {
+		log = func(string) {}
+	}
+	var extra []string
+	if req.Fullscreen {
+		extra = mpvFullscreenArgs(p.fsScreen)
+	}
+	if req.IPCSocket != "" {
+		extra = append(extra, "--input-ipc-server="+req.IPCSocket)
+	}
+	// mpv only supports HTTP proxies. Playing around the proxy would give
+	// away the address it is there to hide.
+	if p := httpOnlyProxy(req.Proxy); p != "" {
+		extra = append(extra, "--http-proxy="+p)
+	} else if req.Proxy != "" {
+		return fmt.Errorf("mpv cannot use proxy %s (HTTP proxies only); use --player-backend streamlink to play through it", req.Proxy)
+	}
+	// The user's arguments come last so they can override ours.
+	extra = append(extra, p.args.forLaunch(req.Fullscreen)...)
+	return LaunchMPVWithHeaders(p.binary, req, p.headers.forward(req.URL, req.Headers), log, extra...)
+}
+
+// LaunchMPVWithHeaders spawns mpv to play req.URL sending hdrs, the captured
+// headers the header policy forwards (by default the minimal set required
+// for successful playback: User-Agent, Origin, Referer). When
+// req.Attach is true, mpv stays attached to the current terminal and the call
+// blocks until the player exits; otherwise mpv is started quietly and detached
+// so closing the terminal will not terminate playback. Logs are streamed via
+// the provided callback.
+func LaunchMPVWithHeaders(player string, req playRequest, hdrs []playbackHeader, log func(string), extraArgs ...string) error {
+	if log == nil {
+		log = func(string) {}
+	}
+	m3u8 := req.URL
+	if m3u8 == "" {
+		return fmt.Errorf("empty m3u8 URL")
+	}
+
+	args := []string{}
+	if !req.Attach {
+		args = append(args, "--no-terminal", "--really-quiet")
+	}
+
+	for _, h := range hdrs {
+		args = append(args, fmt.Sprintf("--http-header-fields=%s: %s", h.Name, h.Value))
+	}
+
+	args = append(args, extraArgs...)
+	args = append(args, m3u8)
+	log(fmt.Sprintf("[mpv] launching with %d headers (%s): %s", len(hdrs), headerNames(hdrs), m3u8))
+
+	path, err := findPlayer(player)
+	if err != nil {
+		log(fmt.Sprintf("[mpv] launch error: %v", err))
+		return err
+	}
+	return startPlayer(exec.Command(path, args...), req, "mpv", log)
+}
+
+// startPlayer starts cmd either attached to the terminal, waiting for it to
+// exit, or detached in its own session with stdio discarded so closing the
+// terminal does not stop playback. label prefixes the log lines.
+func startPlayer(cmd *exec.Cmd, req playRequest, label string, log func(string)) error {
+	if req.Attach {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	} else {
+		devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+		if err != nil {
+			return fmt.Errorf("open devnull: %w", err)
+		}
+		cmd.Stdin = devNull
+		cmd.Stdout = devNull
+		cmd.Stderr = devNull
+		detachProcess(cmd)
+	}
+
+	if err := cmd.Start(); err != nil {
+		log(fmt.Sprintf("[%s] launch error: %v", label, err))
+		return err
+	}
+
+	if req.Attach {
+		log(fmt.Sprintf("[%s] started (attached)", label))
+		if err := cmd.Wait(); err != nil {
+			log(fmt.Sprintf("[%s] exited with error: %v", label, err))
+			return err
+		}
+		log(fmt.Sprintf("[%s] exited", label))
+		return nil
+	}
+
+	log(fmt.Sprintf("[%s] started (pid %d)", label, cmd.Process.Pid))
+	if req.Started != nil {
+		req.Started(cmd)
+	}
+	return nil
+}
+
+// ────────────────────────────────
+// STREAMLINK
+// ────────────────────────────────
+
+// streamlinkSegmentAttempts is how often streamlink retries a failed
+// segment download before giving up on the stream.
+const streamlinkSegmentAttempts = 5
+
+// streamlinkPlayer fetches the playlist with streamlink, which sends the
+// captured headers on every request and retries failed segments, and pipes
+// the stream into the configured player. Streams that stutter when mpv
+// fetches them directly often play smoothly this way.
+type streamlinkPlayer struct {
+	binary   string // streamlink executable; empty means "streamlink"
+	player   string // player streamlink pipes into
+	fsScreen int
+	args     mpvArgs
+	headers  headerPolicy
+}
+
+func (streamlinkPlayer) Name() string { return playerStreamlink }
+
+func (p streamlinkPlayer) Launch(req playRequest, log func(string)) error {
+	if log == nil {
+		log = func(string) {}
+	}
+	if req.URL == "" {
+		return fmt.Errorf("empty m3u8 URL")
+	}
+
+	args := []string{
+		"--stream-segment-attempts", strconv.Itoa(streamlinkSegmentAttempts),
+		"--retry-open", "3",
+	}
+	if !req.Attach {
+		args = append(args, "--loglevel", "none")
+	}
+	hdrs := p.headers.forward(req.URL, req.Headers)
+	for _, h := range hdrs {
+		args = append(args, "--http-header", h.Name+"="+h.Value)
+	}
+	if req.Proxy != "" {
+		args = append(args, "--http-proxy", req.Proxy)
+	}
+	// Hand streamlink the discovered player, which may be off its PATH.
+	if path, err := findPlayer(p.player); err == nil {
+		args = append(args, "--player", path)
+	} else if p.player != "" {
+		args = append(args, "--player", p.player)
+	}
+	var playerArgs []string
+	if req.Fullscreen {
+		playerArgs = mpvFullscreenArgs(p.fsScreen)
+	}
+	playerArgs = append(playerArgs, p.args.forLaunch(req.Fullscreen)...)
+	if len(playerArgs) > 0 {
+		// streamlink appends the stream input when {playerinput} is absent.
+		args = append(args, "--player-args", quoteCommandLine(playerArgs))
+	}
+	args = append(args, "hls://"+req.URL, "best")
+	log(fmt.Sprintf("[streamlink] launching with %d headers (%s): %s", len(hdrs), headerNames(hdrs), req.URL))
+
+	binary := p.binary
+	if binary == "" {
+		binary = "streamlink"
+	}
+	return startPlayer(exec.Command(binary, args...), req, "streamlink", log)
+}
+
+// ────────────────────────────────
+// COMMAND TEMPLATES
+// ────────────────────────────────
+
+// commandPlayer runs a user-defined command line, for players other than mpv
+// (VLC, IINA, a wrapper script). Placeholders in its arguments are replaced
+// per launch; see playerPlaceholders.
+type commandPlayer struct {
+	argv []string
+}
+
+// playerPlaceholders lists the placeholders a player command may use.
+var playerPlaceholders = []string{"{url}", "{user_agent}", "{referer}", "{origin}", "{cookie}", "{title}", "{proxy}"}
+
+func newCommandPlayer(template string) (commandPlayer, error) {
+	argv, err := SplitCommandLine(template)
+	if err != nil {
+		return commandPlayer{}, fmt.Errorf("player command: %w", err)
+	}
+	if len(argv) == 0 {
+		return commandPlayer{}, errors.New("player command is empty")
+	}
+	if !strings.Contains(template, "{url}") {
+		return commandPlayer{}, errors.New("player command must contain {url}")
+	}
+	return commandPlayer{argv: argv}, nil
+}
+
+func (p commandPlayer) Name() string { return filepath.Base(p.argv[0]) }
+
+// expand fills in the placeholders. An argument whose placeholders all
+// expand to nothing (say "--http-referrer={referer}" without a referer) is
+// dropped rather than passed half-empty.
+func (p commandPlayer) expand(req playRequest) []string {
+	values := map[string]string{
+		"{url}":        req.URL,
+		"{user_agent}": lookupHeaderValue(req.Headers, "user-agent"),
+		"{referer}":    lookupHeaderValue(req.Headers, "referer"),
+		"{origin}":     lookupHeaderValue(req.Headers, "origin"),
+		"{cookie}":     lookupHeaderValue(req.Headers, "cookie"),
+		"{title}":      req.Title,
+		"{proxy}":      req.Proxy,
+	}
+	argv := make([]string, 0, len(p.argv))
+	for _, arg := range p.argv {
+		used, filled := false, false
+		for _, ph := range playerPlaceholders {
+			if !strings.Contains(arg, ph) {
+				continue
+			}
+			used = true
+			if values[ph] != "" {
+				filled = true
+			}
+			arg = strings.ReplaceAll(arg, ph, values[ph])
+		}
+		if used && !filled {
+			continue
+		}
+		argv = append(argv, arg)
+	}
+	return argv
+}
+
+func (p commandPlayer) Launch(req playRequest, log func(string)) error {
+	if log == nil {
+		log = func(string) {}
+	}
+	if req.URL == "" {
+		return fmt.Errorf("empty m3u8 URL")
+	}
+	argv := p.expand(req)
+	log(fmt.Sprintf("[%s] launching player command: %s", p.Name(), req.URL))
+	return startPlayer(exec.Command(argv[0], argv[1:]...), req, p.Name(), log)
+}
+
+// quoteCommandLine joins args into a command line SplitCommandLine (and
+// streamlink) splits back into the same arguments.
+func quoteCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitCommandLine splits a command line into arguments the way a POSIX
+// shell would for plain words, single and double quotes, and backslash
+// escapes. Variables, globs, and other shell syntax are not interpreted.
+func SplitCommandLine(s string) ([]string, error) {
+	var (
+		args    []string
+		cur     strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
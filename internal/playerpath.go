@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// PLAYER DISCOVERY
+// ────────────────────────────────
+
+// errNoPlayer means mpv is neither on PATH nor in a known install location.
+var errNoPlayer = errors.New("mpv not found; install it from https://mpv.io/installation/ or set player to its path")
+
+// mpvInstallGlobs are well-known mpv install locations off PATH for each OS:
+// Scoop and Chocolatey on Windows, the app bundle and Homebrew on macOS
+// (GUI launches often lack Homebrew on PATH), and Flatpak and Snap wrappers
+// on Linux. "~/" is the home directory and $VAR an environment variable.
+var mpvInstallGlobs = map[string][]string{
+	"windows": {
+		"~/scoop/apps/mpv/current/mpv.exe",
+		"~/scoop/shims/mpv.exe",
+		"$ProgramData/chocolatey/bin/mpv.exe",
+		"$ProgramData/chocolatey/lib/mpv*/tools/mpv.exe",
+		"$ProgramFiles/mpv/mpv.exe",
+		"$LOCALAPPDATA/Programs/mpv/mpv.exe",
+	},
+	"darwin": {
+		"/Applications/mpv.app/Contents/MacOS/mpv",
+		"~/Applications/mpv.app/Contents/MacOS/mpv",
+		"/opt/homebrew/bin/mpv",
+		"/usr/local/bin/mpv",
+		"/opt/local/bin/mpv",
+	},
+	"linux": {
+		"/var/lib/flatpak/exports/bin/io.mpv.Mpv",
+		"~/.local/share/flatpak/exports/bin/io.mpv.Mpv",
+		"/snap/bin/mpv",
+	},
+}
+
+// findPlayer returns the executable for the player setting: a path is used
+// as is, a name is looked up on PATH, and the default mpv is also searched
+// for in mpvInstallGlobs.
+func findPlayer(player string) (string, error) {
+	if player == "" {
+		player = "mpv"
+	}
+	path, err := exec.LookPath(player)
+	if err == nil {
+		return path, nil
+	}
+	if player != "mpv" {
+		return "", fmt.Errorf("player %q not found: %w", player, err)
+	}
+	home, _ := os.UserHomeDir()
+	for _, pattern := range mpvInstallGlobs[runtime.GOOS] {
+		if rest, ok := strings.CutPrefix(pattern, "~/"); ok {
+			if home == "" {
+				continue
+			}
+			pattern = filepath.Join(home, rest)
+		}
+		matches, _ := filepath.Glob(os.ExpandEnv(pattern))
+		for i := len(matches) - 1; i >= 0; i-- {
+			if path, err := exec.LookPath(matches[i]); err == nil {
+				return path, nil
+			}
+		}
+	}
+	return "", errNoPlayer
+}
+
+// checkPlayer reports whether the programs the player backend launches can
+// be found. Player commands are not checked since they are run as written.
+func (o Options) checkPlayer() error {
+	if strings.TrimSpace(o.PlayerCommand) != "" {
+		return nil
+	}
+	if strings.EqualFold(o.PlayerBackend, playerStreamlink) {
+		binary := o.StreamlinkPath
+		if binary == "" {
+			binary = "streamlink"
+		}
+		if _, err := exec.LookPath(binary); err != nil {
+			return fmt.Errorf("streamlink not found; install it or set streamlink_path: %w", err)
+		}
+	}
+	_, err := findPlayer(o.Player)
+	return err
+}
+
+// playerMissingMsg reports on startup that nothing could be played.
+type playerMissingMsg struct{ err error }
+
+// checkPlayerCmd looks for the player once on startup, so a missing mpv is
+// reported before the first stream is extracted for nothing.
+func (m Model) checkPlayerCmd() tea.Cmd {
+	opts := m.opts
+	return safeCmd("player check", m.crash, func() tea.Msg {
+		if err := opts.checkPlayer(); err != nil {
+			return playerMissingMsg{err}
+		}
+		return nil
+	})
+}
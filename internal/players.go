@@ -0,0 +1,289 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// RUNNING PLAYERS
+// ────────────────────────────────
+
+const (
+	// playersRefreshInterval is how often the players panel updates uptimes
+	// while it is open.
+	playersRefreshInterval = time.Second
+	// playerEarlyExit is how soon a player may close on its own before it
+	// is taken to have failed to play the stream.
+	playerEarlyExit = 10 * time.Second
+)
+
+// playerInfo is a snapshot of one running player for display.
+type playerInfo struct {
+	ID      int
+	Name    string
+	Title   string
+	Stream  Stream
+	Match   Match
+	Pid     int
+	Started time.Time
+	// Socket is mpv's IPC socket, when the playback controls can drive it.
+	Socket     string
+	Fullscreen bool
+	// Stopped is set once the player was asked to quit from the TUI.
+	Stopped bool
+}
+
+type trackedPlayer struct {
+	info playerInfo
+	cmd  *exec.Cmd
+}
+
+// playerTracker keeps the players launched from the TUI until they exit, so
+// they can be listed and stopped instead of piling up unnoticed. Players are
+// added from launcher goroutines and exit on their own, so access is
+// serialized.
+type playerTracker struct {
+	mu      sync.Mutex
+	players []*trackedPlayer
+	nextID  int
+	// onExit is told when a player exits, with the error it exited with
+	// unless it was stopped.
+	onExit func(playerInfo, error)
+}
+
+func newPlayerTracker(onExit func(playerInfo, error)) *playerTracker {
+	return &playerTracker{onExit: onExit}
+}
+
+// Add tracks a started player and waits for it in the background.
+func (t *playerTracker) Add(info playerInfo, cmd *exec.Cmd) playerInfo {
+	t.mu.Lock()
+	t.nextID++
+	info.ID, info.Pid, info.Started = t.nextID, cmd.Process.Pid, time.Now()
+	p := &trackedPlayer{info: info, cmd: cmd}
+	t.players = append(t.players, p)
+	t.mu.Unlock()
+
+	go t.wait(p)
+	return info
+}
+
+func (t *playerTracker) wait(p *trackedPlayer) {
+	err := p.cmd.Wait()
+	t.mu.Lock()
+	if p.info.Stopped {
+		err = nil
+	}
+	for i, q := range t.players {
+		if q == p {
+			t.players = append(t.players[:i], t.players[i+1:]...)
+			break
+		}
+	}
+	info := p.info
+	t.mu.Unlock()
+	if t.onExit != nil {
+		t.onExit(info, err)
+	}
+}
+
+// List returns the running players, newest first.
+func (t *playerTracker) List() []playerInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]playerInfo, 0, len(t.players))
+	for i := len(t.players) - 1; i >= 0; i-- {
+		out = append(out, t.players[i].info)
+	}
+	return out
+}
+
+// Stop asks a player to quit.
+func (t *playerTracker) Stop(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.players {
+		if p.info.ID == id {
+			p.info.Stopped = true
+			return stopDetached(p.cmd)
+		}
+	}
+	return errors.New("no such player")
+}
+
+// StopAll asks every running player to quit.
+func (t *playerTracker) StopAll() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.players {
+		p.info.Stopped = true
+		_ = stopDetached(p.cmd)
+	}
+}
+
+// Stopping notes that the mpv serving socket was told to quit over IPC, so
+// its exit is not taken for a failure.
+func (t *playerTracker) Stopping(socket string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.players {
+		if p.info.Socket == socket {
+			p.info.Stopped = true
+		}
+	}
+}
+
+// ────────────────────────────────
+// PLAYERS PANEL
+// ────────────────────────────────
+
+type (
+	playerExitedMsg struct {
+		Info playerInfo
+		Err  error
+	}
+	playersTickMsg struct{}
+)
+
+type playerKeys struct {
+	Control, Stop key.Binding
+}
+
+func defaultPlayerKeys() playerKeys {
+	return playerKeys{
+		Control: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "control")),
+		Stop:    key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "stop")),
+	}
+}
+
+func newPlayersList() *ListColumn[playerInfo] {
+	return NewListColumn[playerInfo]("Players", func(p playerInfo) string {
+		text := fmt.Sprintf("%s  %s  %s  pid %d", formatPlaybackPosition(time.Since(p.Started).Seconds()), p.Title, p.Name, p.Pid)
+		if p.Stream.EmbedURL != "" {
+			text += fmt.Sprintf("  #%d %s", p.Stream.StreamNo, p.Stream.Source)
+		}
+		return text
+	})
+}
+
+func playersTick() tea.Cmd {
+	return tea.Tick(playersRefreshInterval, func(time.Time) tea.Msg { return playersTickMsg{} })
+}
+
+// playerExited reports a player closing in the status line. When it failed
+// or closed right after starting, which usually means the stream died, the
+// retry key extracts the stream again.
+func (m *Model) playerExited(msg playerExitedMsg) tea.Cmd {
+	p := msg.Info
+	if m.currentView == viewPlayers {
+		m.refreshPlayers()
+	}
+	if p.Socket != "" && p.Socket == m.playing.ipc.path {
+		m.playing = playbackState{}
+	}
+	uptime := formatElapsed(time.Since(p.Started))
+	m.retryPlayer = playerInfo{}
+	if cmd := m.watchdogRelaunch(p, msg.Err); cmd != nil {
+		return tea.Batch(m.logToUI(fmt.Sprintf("[%s] pid %d exited after %s: %v", p.Name, p.Pid, uptime, msg.Err)), cmd)
+	}
+	failed := !p.Stopped && (msg.Err != nil || time.Since(p.Started) < playerEarlyExit)
+	retry := ""
+	if failed && p.Stream.EmbedURL != "" {
+		m.retryPlayer = p
+		retry = " – Ctrl+R to extract it again"
+	}
+	switch {
+	case msg.Err != nil:
+		m.status = fmt.Sprintf("%s for %s exited after %s: %v%s", p.Name, p.Title, uptime, msg.Err, retry)
+	case failed:
+		m.status = fmt.Sprintf("%s for %s closed after only %s%s", p.Name, p.Title, uptime, retry)
+	default:
+		m.status = fmt.Sprintf("%s closed: %s", p.Name, p.Title)
+	}
+	if msg.Err != nil {
+		return m.logToUI(fmt.Sprintf("[%s] pid %d exited after %s: %v", p.Name, p.Pid, uptime, msg.Err))
+	}
+	return m.logToUI(fmt.Sprintf("[%s] pid %d exited after %s", p.Name, p.Pid, uptime))
+}
+
+// retryLastPlayer extracts and plays the stream of the player that last
+// failed again.
+func (m *Model) retryLastPlayer() tea.Cmd {
+	p := m.retryPlayer
+	if p.Stream.EmbedURL == "" {
+		return nil
+	}
+	m.retryPlayer = playerInfo{}
+	return tea.Batch(
+		m.logToUI(fmt.Sprintf("Attempting extractor for %s (retry)", p.Stream.EmbedURL)),
+		m.launchStream(p.Stream, p.Match, p.Fullscreen, false),
+	)
+}
+
+// openPlayers switches to the players panel, which refreshes itself while
+// open.
+func (m *Model) openPlayers() tea.Cmd {
+	m.playerList.SetItems(m.players.List())
+	m.currentView = viewPlayers
+	return playersTick()
+}
+
+func (m *Model) refreshPlayers() {
+	m.playerList.ReplaceItems(m.players.List(), func(a, b playerInfo) bool { return a.ID == b.ID })
+}
+
+// updatePlayers handles keys while the players panel is open.
+func (m *Model) updatePlayers(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.playerList.CursorUp()
+		return nil
+	case key.Matches(msg, m.keys.Down):
+		m.playerList.CursorDown()
+		return nil
+	}
+
+	p, ok := m.playerList.Selected()
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(msg, m.playerKeys.Stop):
+		if err := m.players.Stop(p.ID); err != nil {
+			m.lastError = fmt.Errorf("stop player: %w", err)
+			return nil
+		}
+		m.status = fmt.Sprintf("Stopping %s for %s", p.Name, p.Title)
+	case key.Matches(msg, m.playerKeys.Control):
+		if p.Socket == "" {
+			m.status = fmt.Sprintf("%s for %s has no playback controls", p.Name, p.Title)
+			return nil
+		}
+		m.currentView = viewMain
+		m.status = fmt.Sprintf("Controlling the player for %s", p.Title)
+		return m.startPlayback(playerStartedMsg{Socket: p.Socket, Title: p.Title})
+	}
+	return nil
+}
+
+func (m Model) renderPlayersView() string {
+	header := m.styles.Title.Render(fmt.Sprintf("Running Players (%d)", len(m.playerList.Items())))
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter control from the playback bar · x stop · Esc back"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.playerList.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
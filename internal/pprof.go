@@ -0,0 +1,19 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof" // registers /debug/pprof/ on http.DefaultServeMux
+)
+
+// StartPprof serves net/http/pprof on addr (e.g. ":6060") in the background
+// and returns the address it listens on.
+func StartPprof(addr string) (string, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("pprof listen: %w", err)
+	}
+	go http.Serve(ln, http.DefaultServeMux) //nolint:errcheck // runs until exit
+	return ln.Addr().String(), nil
+}
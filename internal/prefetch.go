@@ -0,0 +1,256 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// STREAM PREFETCH
+// ────────────────────────────────
+
+const (
+	// prefetchDelay is how long the cursor has to rest on a match before its
+	// streams are fetched in the background.
+	prefetchDelay = 500 * time.Millisecond
+	// streamCacheTTL bounds how long prefetched streams are shown without
+	// refetching.
+	streamCacheTTL = 2 * time.Minute
+)
+
+type (
+	// prefetchTickMsg fires prefetchDelay after the cursor moved; it is
+	// ignored unless seq still matches the latest cursor move.
+	prefetchTickMsg struct {
+		seq     int
+		matchID string
+	}
+	streamsPrefetchedMsg struct {
+		matchID string
+		streams []Stream
+		err     error
+	}
+)
+
+type streamCacheEntry struct {
+	streams []Stream
+	fetched time.Time
+}
+
+// streamPrefetcher caches stream lists per match and tracks the single
+// background prefetch that may be in flight. It is only touched from Update.
+type streamPrefetcher struct {
+	seq      int
+	cache    map[string]streamCacheEntry
+	inFlight string
+	cancel   context.CancelFunc
+}
+
+func newStreamPrefetcher() *streamPrefetcher {
+	return &streamPrefetcher{cache: map[string]streamCacheEntry{}}
+}
+
+// lookup returns the cached streams for a match and whether they are still
+// fresh enough to show without refetching.
+func (p *streamPrefetcher) lookup(matchID string) (streams []Stream, fresh, ok bool) {
+	entry, ok := p.cache[matchID]
+	if !ok {
+		return nil, false, false
+	}
+	return entry.streams, time.Since(entry.fetched) <= streamCacheTTL, true
+}
+
+func (p *streamPrefetcher) store(matchID string, streams []Stream) {
+	p.cache[matchID] = streamCacheEntry{streams: streams, fetched: time.Now()}
+}
+
+// cancelInFlight aborts the running prefetch, if any.
+func (p *streamPrefetcher) cancelInFlight() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.cancel = nil
+	p.inFlight = ""
+}
+
+// scheduleStreamPrefetch is called whenever the match cursor may have moved.
+// It cancels a prefetch for a match the cursor has left and arms a debounce
+// timer for the newly highlighted one.
+func (m *Model) scheduleStreamPrefetch() tea.Cmd {
+	mt, ok := m.matches.Selected()
+	if !ok {
+		return nil
+	}
+	p := m.prefetch
+	p.seq++
+	if p.inFlight != "" && p.inFlight != mt.ID {
+		p.cancelInFlight()
+	}
+	if _, fresh, _ := p.lookup(mt.ID); fresh || p.inFlight == mt.ID || len(mt.Sources) == 0 {
+		return nil
+	}
+	seq := p.seq
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return prefetchTickMsg{seq: seq, matchID: mt.ID}
+	})
+}
+
+// startStreamPrefetch runs when the debounce timer fires and the cursor is
+// still on the same match.
+func (m *Model) startStreamPrefetch(msg prefetchTickMsg) tea.Cmd {
+	p := m.prefetch
+	mt, ok := m.matches.Selected()
+	if msg.seq != p.seq || !ok || mt.ID != msg.matchID || p.inFlight != "" {
+		return nil
+	}
+	if _, fresh, _ := p.lookup(mt.ID); fresh {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.inFlight = mt.ID
+	p.cancel = cancel
+	provider := m.provider
+	return safeCmd("prefetch streams", m.crash, func() tea.Msg {
+		defer cancel()
+		streams, err := provider.ListStreams(ctx, mt)
+		return streamsPrefetchedMsg{matchID: mt.ID, streams: streams, err: err}
+	})
+}
+
+func (m *Model) handleStreamsPrefetched(msg streamsPrefetchedMsg) tea.Cmd {
+	p := m.prefetch
+	if p.inFlight == msg.matchID {
+		p.inFlight = ""
+		p.cancel = nil
+	}
+	if msg.err != nil {
+		// Cancellation means the cursor moved on; only report real failures.
+		// Partial results are not cached either, so opening the match
+		// fetches again and reports which sources failed.
+		if errors.Is(msg.err, context.Canceled) {
+			return nil
+		}
+		return m.logToUI(fmt.Sprintf("[prefetch] streams for %s failed: %v", msg.matchID, msg.err))
+	}
+	p.store(msg.matchID, reorderStreams(msg.streams, m.streamPref))
+	return nil
+}
+
+// ────────────────────────────────
+// MATCH LIST PREFETCH
+// ────────────────────────────────
+
+type (
+	// sportPrefetchTickMsg fires prefetchDelay after the sport cursor moved;
+	// it is ignored unless seq still matches the latest cursor move.
+	sportPrefetchTickMsg struct {
+		seq   int
+		sport Sport
+	}
+	matchesPrefetchedMsg struct {
+		loaded matchesLoadedMsg
+		err    error
+	}
+)
+
+// sportPrefetcher tracks the background fetch of the highlighted sport's
+// matches and when each sport was last fetched. Results go to the match
+// cache, and through the API client's response cache, so opening the sport
+// shows them at once. It is only touched from Update.
+type sportPrefetcher struct {
+	seq      int
+	inFlight string
+	cancel   context.CancelFunc
+	fetched  map[string]time.Time
+}
+
+func newSportPrefetcher() *sportPrefetcher {
+	return &sportPrefetcher{fetched: map[string]time.Time{}}
+}
+
+// fresh reports whether a sport's matches were fetched within ttl.
+func (p *sportPrefetcher) fresh(sportID string, ttl time.Duration) bool {
+	at, ok := p.fetched[strings.ToLower(sportID)]
+	return ok && time.Since(at) < ttl
+}
+
+func (p *sportPrefetcher) cancelInFlight() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.cancel = nil
+	p.inFlight = ""
+}
+
+// scheduleMatchPrefetch is called whenever the sport cursor may have moved.
+// It cancels a prefetch for a sport the cursor has left and arms a debounce
+// timer for the newly highlighted one. The shown sport, sports being loaded
+// anyway, and Favorites, which fans out over every sport, are skipped.
+func (m *Model) scheduleMatchPrefetch() tea.Cmd {
+	sport, ok := m.sports.Selected()
+	if !ok {
+		return nil
+	}
+	p := m.sportPrefetch
+	p.seq++
+	id := strings.ToLower(sport.ID)
+	if p.inFlight != "" && p.inFlight != id {
+		p.cancelInFlight()
+	}
+	if id == m.shownSport || id == favoritesSportID || p.inFlight == id ||
+		m.requests.busy(matchesTarget(id)) || p.fresh(id, m.opts.CacheTTL.Matches) {
+		return nil
+	}
+	seq := p.seq
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return sportPrefetchTickMsg{seq: seq, sport: sport}
+	})
+}
+
+// startMatchPrefetch runs when the debounce timer fires and the cursor is
+// still on the same sport.
+func (m *Model) startMatchPrefetch(msg sportPrefetchTickMsg) tea.Cmd {
+	p := m.sportPrefetch
+	sport, ok := m.sports.Selected()
+	if msg.seq != p.seq || !ok || !strings.EqualFold(sport.ID, msg.sport.ID) || p.inFlight != "" {
+		return nil
+	}
+	id := strings.ToLower(sport.ID)
+	if m.requests.busy(matchesTarget(id)) {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.inFlight = id
+	p.cancel = cancel
+	provider := m.provider
+	return safeCmd("prefetch matches", m.crash, func() tea.Msg {
+		defer cancel()
+		matches, err := provider.ListMatches(ctx, sport.ID)
+		return matchesPrefetchedMsg{loaded: matchesLoadedMsg{SportID: id, Matches: matches, Title: matchesTitle(sport)}, err: err}
+	})
+}
+
+func (m *Model) handleMatchesPrefetched(msg matchesPrefetchedMsg) tea.Cmd {
+	p := m.sportPrefetch
+	id := msg.loaded.SportID
+	if p.inFlight == id {
+		p.inFlight = ""
+		p.cancel = nil
+	}
+	if msg.err != nil {
+		if errors.Is(msg.err, context.Canceled) {
+			return nil
+		}
+		return m.logToUI(fmt.Sprintf("[prefetch] matches for %s failed: %v", id, msg.err))
+	}
+	p.fetched[id] = time.Now()
+	m.matchCache[id] = msg.loaded
+	return nil
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// VIEW PREFERENCES
+// ────────────────────────────────
+
+// viewPrefs are the sort and filter choices made in the UI. They are saved
+// whenever they change so the next session starts the way this one ended.
+type viewPrefs struct {
+	HDOnly       bool   `json:"hd_only,omitempty"`
+	LanguageOnly bool   `json:"language_only,omitempty"`
+	AdminStreams string `json:"admin_streams,omitempty"`
+	MatchSort    string `json:"match_sort,omitempty"`
+	MatchFilter  string `json:"match_filter,omitempty"`
+	StreamSort   string `json:"stream_sort,omitempty"`
+}
+
+func viewPrefsPath() (string, error) {
+	dir, err := ensureAppDir(stateDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "view.json"), nil
+}
+
+// loadViewPrefs returns the saved preferences, or the defaults when none
+// were saved yet.
+func loadViewPrefs() (viewPrefs, error) {
+	var prefs viewPrefs
+	path, err := viewPrefsPath()
+	if err != nil {
+		return prefs, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return prefs, nil
+	}
+	if err != nil {
+		return prefs, err
+	}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return viewPrefs{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return prefs, nil
+}
+
+func saveViewPrefs(prefs viewPrefs) error {
+	path, err := viewPrefsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// viewPrefs collects the preferences currently in effect.
+func (m Model) viewPrefs() viewPrefs {
+	return viewPrefs{
+		HDOnly:       m.hdOnly,
+		LanguageOnly: m.languageOnly,
+		AdminStreams: m.adminStreams.String(),
+		MatchSort:    m.matchSort.String(),
+		MatchFilter:  m.matchFilter.String(),
+		StreamSort:   m.streamSort.String(),
+	}
+}
+
+// savePrefs persists the current preferences in the background.
+func (m Model) savePrefs() tea.Cmd {
+	prefs := m.viewPrefs()
+	return safeCmd("save preferences", m.crash, func() tea.Msg {
+		if err := saveViewPrefs(prefs); err != nil {
+			return debugLogMsg(fmt.Sprintf("[prefs] save failed: %v", err))
+		}
+		return nil
+	})
+}
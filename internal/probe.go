@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ────────────────────────────────
+// PLAYLIST PROBE
+// ────────────────────────────────
+
+// probeReadBytes is how much of the playlist the probe reads to check that
+// it is one.
+const probeReadBytes = 4096
+
+// probeResult is how an extracted playlist answered a request made with the
+// captured headers.
+type probeResult struct {
+	Status  string
+	Latency time.Duration
+	// Bandwidth is the highest variant bandwidth when the playlist is a
+	// master playlist.
+	Bandwidth int
+	Err       error
+}
+
+func (r probeResult) ok() bool { return r.Err == nil }
+
+func (r probeResult) String() string {
+	switch {
+	case r.Err == nil:
+		return fmt.Sprintf("%s in %s", r.Status, r.Latency.Round(time.Millisecond))
+	case r.Status != "":
+		return fmt.Sprintf("%s in %s: %v", r.Status, r.Latency.Round(time.Millisecond), r.Err)
+	}
+	return fmt.Sprintf("no answer after %s: %v", r.Latency.Round(time.Millisecond), r.Err)
+}
+
+// probePlaylist fetches the start of a playlist the way the player will,
+// with the captured headers, so dead or blocked links are caught before the
+// player is started detached and fails where nobody sees it. Latency is the
+// time to the response headers.
+func probePlaylist(ctx context.Context, client *http.Client, playlistURL string, hdrs map[string]string) probeResult {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, playlistURL, nil)
+	if err != nil {
+		return probeResult{Err: err}
+	}
+	for k, v := range hdrs {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	res := probeResult{Latency: time.Since(start)}
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer resp.Body.Close()
+	res.Status = resp.Status
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		res.Err = errors.New("the playlist host refused it")
+		return res
+	}
+	head, err := io.ReadAll(io.LimitReader(resp.Body, probeReadBytes))
+	if err != nil {
+		res.Err = fmt.Errorf("read playlist: %w", err)
+		return res
+	}
+	if !strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(string(head), "\ufeff")), "#EXTM3U") {
+		res.Err = errors.New("not an HLS playlist")
+		return res
+	}
+	if variants := parseMasterPlaylist(playlistURL, string(head)); len(variants) > 0 {
+		res.Bandwidth = variants[0].Bandwidth
+	}
+	return res
+}
+
+// probeEnabled reports whether playlists are checked before playing. Demo
+// streams are not, so demo mode stays offline.
+func (o Options) probeEnabled() bool { return !o.NoProbe && !o.Demo }
+
+// playlistDeadMsg reports that an extracted playlist failed the probe, so
+// the player was not started.
+type playlistDeadMsg struct {
+	Stream Stream
+	Probe  probeResult
+}
@@ -0,0 +1,29 @@
+//go:build !windows
+
+package internal
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts cmd in its own session so closing the terminal does
+// not stop it.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// stopDetached asks a process started with detachProcess, and everything it
+// started (streamlink's player, say), to quit.
+func stopDetached(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+}
+
+// killGroupOnCancel runs cmd in its own process group and makes canceling
+// its context kill the whole group, taking down any children it spawned.
+func killGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
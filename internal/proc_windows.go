@@ -0,0 +1,34 @@
+//go:build windows
+
+package internal
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// detachProcess starts cmd without the console, so closing the terminal does
+// not stop it.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.DETACHED_PROCESS | windows.CREATE_NEW_PROCESS_GROUP}
+}
+
+// stopDetached ends a process started with detachProcess and its children.
+// Console signals do not reach detached processes, so the tree is killed.
+func stopDetached(cmd *exec.Cmd) error {
+	return killTree(cmd)
+}
+
+// killGroupOnCancel makes canceling cmd's context kill its process tree, as
+// Windows has no process groups to signal.
+func killGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.CREATE_NEW_PROCESS_GROUP}
+	cmd.Cancel = func() error { return killTree(cmd) }
+}
+
+func killTree(cmd *exec.Cmd) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
@@ -0,0 +1,201 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// PROGRESS INDICATOR
+// ────────────────────────────────
+
+// Operation keys shared by the fetchers, the extractor, and the status line.
+const (
+	opSports  = "sports"
+	opMatches = "matches"
+	opStreams = "streams"
+	opExtract = "extract"
+	opSearch  = "search"
+	opAgenda  = "agenda"
+	opTeams   = "teams"
+	opJobs    = "jobs"
+)
+
+type (
+	// opDoneMsg wraps the result of a tracked command so its progress entry
+	// is cleared before the result itself is handled. target identifies what
+	// was requested (e.g. "streams:<matchID>").
+	opDoneMsg struct {
+		key    string
+		target string
+		msg    tea.Msg
+	}
+	// progressPhaseMsg updates the phase label of a running operation. It is
+	// sent from background goroutines through uiLogger.Send.
+	progressPhaseMsg struct {
+		key   string
+		phase string
+	}
+)
+
+type progressOp struct {
+	label   string
+	phase   string
+	started time.Time
+}
+
+// progress is the shared loading indicator: a spinner, the elapsed time, and a
+// phase label for each running operation. The most recently started operation
+// is shown in the status line.
+type progress struct {
+	spinner spinner.Model
+	ops     map[string]*progressOp
+	order   []string
+	// ticking is set while a spinner tick is scheduled, so only one tick
+	// loop runs however often the indicator goes idle and busy again.
+	ticking bool
+}
+
+func newProgress(styles Styles) progress {
+	sp := spinner.Dot
+	if styles.ASCII {
+		sp = spinner.Line
+	}
+	return progress{
+		spinner: spinner.New(spinner.WithSpinner(sp), spinner.WithStyle(styles.Title)),
+		ops:     map[string]*progressOp{},
+	}
+}
+
+func (p progress) active() bool { return len(p.order) > 0 }
+
+// start registers an operation and returns the spinner tick command when the
+// spinner is not ticking yet.
+func (p *progress) start(key, label string) tea.Cmd {
+	p.remove(key)
+	p.ops[key] = &progressOp{label: label, started: time.Now()}
+	p.order = append(p.order, key)
+	if p.ticking {
+		return nil
+	}
+	p.ticking = true
+	return p.spinner.Tick
+}
+
+func (p *progress) setPhase(key, phase string) {
+	if op, ok := p.ops[key]; ok {
+		op.phase = phase
+	}
+}
+
+func (p *progress) done(key string) { p.remove(key) }
+
+func (p *progress) remove(key string) {
+	if _, ok := p.ops[key]; !ok {
+		return
+	}
+	delete(p.ops, key)
+	order := make([]string, 0, len(p.order))
+	for _, k := range p.order {
+		if k != key {
+			order = append(order, k)
+		}
+	}
+	p.order = order
+}
+
+// update advances the spinner while operations are running and lets it stop
+// ticking once the indicator goes idle.
+func (p *progress) update(msg spinner.TickMsg) tea.Cmd {
+	if !p.active() {
+		p.ticking = false
+		return nil
+	}
+	var cmd tea.Cmd
+	p.spinner, cmd = p.spinner.Update(msg)
+	return cmd
+}
+
+func (p progress) View() string {
+	if !p.active() {
+		return ""
+	}
+	op := p.ops[p.order[len(p.order)-1]]
+	text := op.label
+	if op.phase != "" {
+		text += " – " + op.phase
+	}
+	text = fmt.Sprintf("%s %s (%s)", p.spinner.View(), text, formatElapsed(time.Since(op.started)))
+	if extra := len(p.order) - 1; extra > 0 {
+		text += fmt.Sprintf(" +%d more", extra)
+	}
+	return text
+}
+
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+// track starts a progress entry for the operation key and wraps cmd so the
+// entry is cleared when the command's result arrives. Requests for a target
+// that is already in flight are ignored and return nil, as is a nil cmd.
+func (m *Model) track(key, target, label string, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	if m.requests.busy(target) {
+		m.status = fmt.Sprintf("%s – already in progress", label)
+		return nil
+	}
+	m.requests.begin(key, target)
+	tick := m.progress.start(key, label)
+	return tea.Batch(tick, trackedCmd(key, target, cmd))
+}
+
+func trackedCmd(key, target string, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return opDoneMsg{key: key, target: target, msg: cmd()}
+	}
+}
+
+// ────────────────────────────────
+// IN-FLIGHT REQUESTS
+// ────────────────────────────────
+
+// requestTracker records which targets have a request in flight, so repeated
+// Enter presses do not spawn identical requests, and which target each
+// operation asked for last, so late responses from superseded requests do not
+// overwrite newer ones. It is only touched from Update.
+type requestTracker struct {
+	inFlight map[string]struct{}
+	latest   map[string]string
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{inFlight: map[string]struct{}{}, latest: map[string]string{}}
+}
+
+func (r *requestTracker) busy(target string) bool {
+	_, ok := r.inFlight[target]
+	return ok
+}
+
+func (r *requestTracker) begin(key, target string) {
+	r.inFlight[target] = struct{}{}
+	r.latest[key] = target
+}
+
+// finish clears target and reports whether its result is still wanted.
+func (r *requestTracker) finish(key, target string) bool {
+	delete(r.inFlight, target)
+	return r.latest[key] == target
+}
@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ────────────────────────────────
+// STREAM PROVIDERS
+// ────────────────────────────────
+
+// Provider is a source of sports, matches, and streams. The streamed API
+// client is the built-in one; more are configured as [[providers]] of a
+// kind registered in providerKinds, and their lists are merged into the
+// same columns.
+type Provider interface {
+	Name() string
+	ListSports(ctx context.Context) ([]Sport, error)
+	// ListMatches lists a sport's matches, or the popular ones for
+	// popularSportID.
+	ListMatches(ctx context.Context, sportID string) ([]Match, error)
+	ListStreams(ctx context.Context, mt Match) ([]Stream, error)
+}
+
+// primaryProviderName names the streamed API the app was started against.
+const primaryProviderName = "streamed"
+
+// Provider kinds accepted in providerConfig.Kind.
+const providerStreamed = "streamed"
+
+// providerConfig is one [[providers]] entry of the config file.
+type providerConfig struct {
+	Name string `toml:"name"`
+	// Kind selects the implementation; empty means "streamed", an API
+	// compatible with streamed.pk's served from BaseURL.
+	Kind    string `toml:"kind"`
+	BaseURL string `toml:"base_url"`
+}
+
+// providerKinds builds the providers that can be configured, by kind.
+var providerKinds = map[string]func(pc providerConfig, opts Options) (Provider, error){
+	providerStreamed: func(pc providerConfig, opts Options) (Provider, error) {
+		if strings.TrimSpace(pc.BaseURL) == "" {
+			return nil, errors.New("base_url is required")
+		}
+		return NewClient(strings.TrimRight(pc.BaseURL, "/"), opts.APITimeout).Named(pc.Name), nil
+	},
+}
+
+// Named sets the name the client goes by when merged with other providers.
+func (c *Client) Named(name string) *Client {
+	c.name = name
+	return c
+}
+
+func (c *Client) Name() string {
+	if c.name == "" {
+		return primaryProviderName
+	}
+	return c.name
+}
+
+func (c *Client) ListSports(ctx context.Context) ([]Sport, error) { return c.GetSports(ctx) }
+
+// ListMatches lists a sport's matches with duplicate listings of the same
+// fixture merged.
+func (c *Client) ListMatches(ctx context.Context, sportID string) ([]Match, error) {
+	var (
+		matches []Match
+		err     error
+	)
+	if strings.EqualFold(sportID, popularSportID) {
+		matches, err = c.GetPopularMatches(ctx)
+	} else {
+		matches, err = c.GetMatchesBySport(ctx, sportID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	matches, merged := mergeDuplicateMatches(matches)
+	if merged > 0 && c.trace != nil {
+		c.trace(fmt.Sprintf("[dedupe] merged %d duplicate listings of %s matches", merged, sportID))
+	}
+	return matches, nil
+}
+
+func (c *Client) ListStreams(ctx context.Context, mt Match) ([]Stream, error) {
+	return c.GetStreamsForMatch(ctx, mt)
+}
+
+// extraProviders builds the configured providers besides the primary one.
+// Providers are skipped in demo mode, which stays offline.
+func (o Options) extraProviders() ([]Provider, error) {
+	if o.Demo {
+		return nil, nil
+	}
+	var out []Provider
+	names := map[string]bool{primaryProviderName: true}
+	for i, pc := range o.Providers {
+		if pc.Name == "" {
+			return nil, fmt.Errorf("provider %d: name is required", i+1)
+		}
+		if names[pc.Name] {
+			return nil, fmt.Errorf("provider %q: name already used", pc.Name)
+		}
+		names[pc.Name] = true
+		kind := strings.ToLower(pc.Kind)
+		if kind == "" {
+			kind = providerStreamed
+		}
+		build, ok := providerKinds[kind]
+		if !ok {
+			return nil, fmt.Errorf("provider %q: unknown kind %q", pc.Name, pc.Kind)
+		}
+		p, err := build(pc, o)
+		if err != nil {
+			return nil, fmt.Errorf("provider %q: %w", pc.Name, err)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// providerSet merges several providers into one. Sports and matches are
+// fetched from all of them at once and tagged with the provider's name;
+// a match whose ID an earlier provider already listed is dropped, so
+// mirrors of the same API only add what the first one lacks. Streams are
+// fetched from the provider that listed the match.
+type providerSet struct {
+	providers []Provider
+	// log reports providers that failed while others answered.
+	log func(string)
+}
+
+// newProviderSet returns primary alone when there are no others.
+func newProviderSet(primary Provider, others []Provider, log func(string)) Provider {
+	if len(others) == 0 {
+		return primary
+	}
+	if log == nil {
+		log = func(string) {}
+	}
+	return &providerSet{providers: append([]Provider{primary}, others...), log: log}
+}
+
+func (s *providerSet) Name() string {
+	names := make([]string, len(s.providers))
+	for i, p := range s.providers {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, "+")
+}
+
+// gather runs fetch against every provider at once and returns the results
+// in provider order. It only fails when every provider does.
+func gather[T any](ctx context.Context, s *providerSet, what string, fetch func(context.Context, Provider) ([]T, error)) ([][]T, error) {
+	lists := make([][]T, len(s.providers))
+	errs := make([]error, len(s.providers))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, p := range s.providers {
+		g.Go(func() error {
+			lists[i], errs[i] = fetch(gctx, p)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", s.providers[i].Name(), err))
+		}
+	}
+	if len(failed) == len(s.providers) {
+		return nil, errors.Join(failed...)
+	}
+	for _, err := range failed {
+		s.log(fmt.Sprintf("[providers] %s failed: %v", what, err))
+	}
+	return lists, nil
+}
+
+func (s *providerSet) ListSports(ctx context.Context) ([]Sport, error) {
+	lists, err := gather(ctx, s, "sports", func(ctx context.Context, p Provider) ([]Sport, error) {
+		return p.ListSports(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []Sport
+	for _, sports := range lists {
+		for _, sp := range sports {
+			if !slices.ContainsFunc(out, func(o Sport) bool { return strings.EqualFold(o.ID, sp.ID) }) {
+				out = append(out, sp)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s *providerSet) ListMatches(ctx context.Context, sportID string) ([]Match, error) {
+	lists, err := gather(ctx, s, "matches", func(ctx context.Context, p Provider) ([]Match, error) {
+		matches, err := p.ListMatches(ctx, sportID)
+		for i := range matches {
+			matches[i].Provider = p.Name()
+		}
+		return matches, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []Match
+	seen := map[string]bool{}
+	for _, matches := range lists {
+		for _, mt := range matches {
+			if !seen[mt.ID] {
+				seen[mt.ID] = true
+				out = append(out, mt)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s *providerSet) ListStreams(ctx context.Context, mt Match) ([]Stream, error) {
+	return providerFor(s, mt).ListStreams(ctx, mt)
+}
+
+// providerFor returns the provider that listed mt: the first one for
+// matches without a provider tag, such as those saved before providers were
+// configured.
+func providerFor(p Provider, mt Match) Provider {
+	s, ok := p.(*providerSet)
+	if !ok {
+		return p
+	}
+	for _, q := range s.providers {
+		if q.Name() == mt.Provider {
+			return q
+		}
+	}
+	return s.providers[0]
+}
+
+// multiProvider reports whether matches come from more than one provider,
+// so they are worth tagging.
+func multiProvider(p Provider) bool {
+	_, ok := p.(*providerSet)
+	return ok
+}
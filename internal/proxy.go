@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ────────────────────────────────
+// PROXY
+// ────────────────────────────────
+
+// proxyEnv overrides the proxy config key, the way STREAMED_BASE overrides
+// base_url; the --proxy flag overrides both.
+const proxyEnv = "STREAMED_PROXY"
+
+// parseProxy validates a proxy URL. HTTP(S) and SOCKS5 proxies are
+// supported; socks5h resolves host names through the proxy.
+func parseProxy(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("proxy %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("proxy %q: scheme must be http, https, socks5, or socks5h", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy %q: missing host", raw)
+	}
+	return u, nil
+}
+
+// proxy returns the proxy to use: the option when set, otherwise
+// STREAMED_PROXY. Empty means a direct connection (or the standard
+// HTTP_PROXY variables for Go's own requests).
+func (o Options) proxy() string {
+	if p := strings.TrimSpace(o.Proxy); p != "" {
+		return p
+	}
+	return strings.TrimSpace(os.Getenv(proxyEnv))
+}
+
+// checkProxy rejects a malformed proxy setting before anything is fetched.
+func (o Options) checkProxy() error {
+	if p := o.proxy(); p != "" {
+		_, err := parseProxy(p)
+		return err
+	}
+	return nil
+}
+
+// proxyTransport clones the default transport and routes it through the
+// proxy; an empty proxy keeps the default behaviour.
+func proxyTransport(proxy string) http.RoundTripper {
+	if proxy == "" {
+		return http.DefaultTransport
+	}
+	u, err := parseProxy(proxy)
+	if err != nil {
+		return http.DefaultTransport
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyURL(u)
+	return t
+}
+
+// proxyHTTPClient returns the client used for embed pages and playlists.
+func proxyHTTPClient(proxy string) *http.Client {
+	if proxy == "" {
+		return http.DefaultClient
+	}
+	return &http.Client{Transport: proxyTransport(proxy)}
+}
+
+// browserProxy converts a proxy URL to Chromium's --proxy-server form.
+// Chromium resolves names through SOCKS proxies anyway and has no socks5h
+// scheme, and it ignores credentials in the URL.
+func browserProxy(proxy string) string {
+	u, err := parseProxy(proxy)
+	if err != nil {
+		return ""
+	}
+	scheme := u.Scheme
+	if scheme == "socks5h" {
+		scheme = "socks5"
+	}
+	return scheme + "://" + u.Host
+}
+
+// httpOnlyProxy returns the proxy for tools that only speak HTTP proxies
+// (mpv, ffmpeg), or "" for SOCKS proxies.
+func httpOnlyProxy(proxy string) string {
+	u, err := parseProxy(proxy)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,488 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// RECORDINGS
+// ────────────────────────────────
+
+const (
+	// recordingsRefreshInterval is how often the recordings panel updates
+	// file sizes while it is open.
+	recordingsRefreshInterval = time.Second
+	// recordingStopTimeout is how long ffmpeg gets to finish the file after
+	// being interrupted on exit.
+	recordingStopTimeout = 5 * time.Second
+)
+
+type recordingState int
+
+const (
+	recordingActive recordingState = iota
+	recordingDone
+	recordingFailed
+)
+
+func (s recordingState) String() string {
+	switch s {
+	case recordingActive:
+		return "REC"
+	case recordingDone:
+		return "done"
+	default:
+		return "failed"
+	}
+}
+
+// recordingInfo is a snapshot of one recording for display.
+type recordingInfo struct {
+	ID      int
+	Title   string
+	Path    string
+	Started time.Time
+	Ended   time.Time
+	State   recordingState
+	Err     error
+	Size    int64
+	// Download is set for yt-dlp downloads, which share the panel.
+	Download bool
+}
+
+// noun names what the entry is in status messages.
+func (r recordingInfo) noun() string {
+	if r.Download {
+		return "Download"
+	}
+	return "Recording"
+}
+
+type recording struct {
+	info     recordingInfo
+	cmd      *exec.Cmd
+	stopping bool
+	done     chan struct{}
+	stderr   *tailWriter
+}
+
+// recorder runs ffmpeg (or yt-dlp, for downloads) to save extracted streams
+// to disk. Recordings are started from extractor goroutines and finish on
+// their own, so access is serialized.
+type recorder struct {
+	mu          sync.Mutex
+	ffmpeg      string
+	dir         string
+	downloadDir string
+	proxy       string
+	recs        []*recording
+	nextID      int
+	// onEnd is told when a recording stops, whatever the reason.
+	onEnd func(recordingInfo)
+}
+
+func newRecorder(ffmpeg, dir, downloadDir, proxy string, onEnd func(recordingInfo)) *recorder {
+	if ffmpeg == "" {
+		ffmpeg = "ffmpeg"
+	}
+	return &recorder{ffmpeg: ffmpeg, dir: dir, downloadDir: downloadDir, proxy: proxy, onEnd: onEnd}
+}
+
+// recordingsDir returns where recordings are written, creating it.
+func (r *recorder) recordingsDir() (string, error) {
+	if r.dir != "" {
+		dir := expandHome(r.dir)
+		return dir, os.MkdirAll(dir, 0o755)
+	}
+	return ensureAppDir(dataDir, "recordings")
+}
+
+// ffmpegArgs copies the HLS stream into an MPEG-TS file, which stays
+// playable if ffmpeg is stopped abruptly, sending the captured playback
+// headers with every request. ffmpeg only supports HTTP proxies. An existing
+// file is never overwritten.
+func ffmpegArgs(m3u8, path, proxy string, hdrs map[string]string) []string {
+	args := []string{"-hide_banner", "-nostdin", "-loglevel", "error", "-n"}
+	if p := httpOnlyProxy(proxy); p != "" {
+		args = append(args, "-http_proxy", p)
+	}
+	if ua := lookupHeaderValue(hdrs, "user-agent"); ua != "" {
+		args = append(args, "-user_agent", ua)
+	}
+	var extra strings.Builder
+	for _, hk := range playbackHeaders {
+		if hk.lookup == "user-agent" {
+			continue
+		}
+		if v := lookupHeaderValue(hdrs, hk.lookup); v != "" {
+			fmt.Fprintf(&extra, "%s: %s\r\n", hk.display, v)
+		}
+	}
+	if extra.Len() > 0 {
+		args = append(args, "-headers", extra.String())
+	}
+	return append(args, "-i", m3u8, "-c", "copy", "-f", "mpegts", path)
+}
+
+// recordingFileName names a recording after its start time and title.
+func recordingFileName(title string, started time.Time) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 60 {
+			break
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		slug = "stream"
+	}
+	return fmt.Sprintf("%s_%s.ts", started.Format("2006-01-02_150405"), slug)
+}
+
+// recordingPath returns a path in dir for a new recording, numbering it
+// when a file of that name exists already.
+func recordingPath(dir, title string, started time.Time) string {
+	name := recordingFileName(title, started)
+	path := filepath.Join(dir, name)
+	stem := strings.TrimSuffix(name, ".ts")
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); err != nil {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d.ts", stem, n))
+	}
+}
+
+// Start launches ffmpeg recording the playlist.
+func (r *recorder) Start(title, m3u8 string, hdrs map[string]string) (recordingInfo, error) {
+	dir, err := r.recordingsDir()
+	if err != nil {
+		return recordingInfo{}, fmt.Errorf("recordings directory: %w", err)
+	}
+	// ffmpeg only supports HTTP proxies; recording around the proxy would
+	// give away the address it is there to hide.
+	if r.proxy != "" && httpOnlyProxy(r.proxy) == "" {
+		return recordingInfo{}, fmt.Errorf("ffmpeg cannot use proxy %s (HTTP proxies only); d downloads the stream with yt-dlp through it", r.proxy)
+	}
+	started := time.Now()
+	path := recordingPath(dir, title, started)
+
+	cmd := exec.Command(r.ffmpeg, ffmpegArgs(m3u8, path, r.proxy, hdrs)...)
+	return r.start(recordingInfo{Title: title, Path: path, Started: started}, cmd)
+}
+
+// start runs cmd as a new entry in the list.
+func (r *recorder) start(info recordingInfo, cmd *exec.Cmd) (recordingInfo, error) {
+	stderr := &tailWriter{max: 2048}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return recordingInfo{}, fmt.Errorf("start %s: %w", filepath.Base(cmd.Path), err)
+	}
+
+	r.mu.Lock()
+	r.nextID++
+	info.ID, info.State = r.nextID, recordingActive
+	rec := &recording{
+		info:   info,
+		cmd:    cmd,
+		done:   make(chan struct{}),
+		stderr: stderr,
+	}
+	r.recs = append(r.recs, rec)
+	info = rec.info
+	r.mu.Unlock()
+
+	go r.wait(rec)
+	return info, nil
+}
+
+// wait records how ffmpeg or yt-dlp exited. Exits after Stop count as
+// success since both report being interrupted as an error.
+func (r *recorder) wait(rec *recording) {
+	err := rec.cmd.Wait()
+	r.mu.Lock()
+	rec.info.Ended = time.Now()
+	switch {
+	case err == nil || rec.stopping:
+		rec.info.State = recordingDone
+	default:
+		rec.info.State = recordingFailed
+		if msg := strings.TrimSpace(rec.stderr.String()); msg != "" {
+			lines := strings.Split(msg, "\n")
+			err = fmt.Errorf("%w: %s", err, lines[len(lines)-1])
+		}
+		rec.info.Err = err
+	}
+	info := rec.snapshot()
+	r.mu.Unlock()
+	close(rec.done)
+	if r.onEnd != nil {
+		r.onEnd(info)
+	}
+}
+
+func (rec *recording) snapshot() recordingInfo {
+	info := rec.info
+	if fi, err := os.Stat(info.Path); err == nil {
+		info.Size = fi.Size()
+	}
+	return info
+}
+
+// List returns the recordings of this session, newest first.
+func (r *recorder) List() []recordingInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]recordingInfo, 0, len(r.recs))
+	for i := len(r.recs) - 1; i >= 0; i-- {
+		out = append(out, r.recs[i].snapshot())
+	}
+	return out
+}
+
+// Stop asks ffmpeg or yt-dlp to finish the file. On Unix it is interrupted so the
+// file is closed cleanly; Windows has no such signal, so it is killed.
+func (r *recorder) Stop(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range r.recs {
+		if rec.info.ID != id {
+			continue
+		}
+		if rec.info.State != recordingActive {
+			return nil
+		}
+		rec.stopping = true
+		if runtime.GOOS == "windows" {
+			return rec.cmd.Process.Kill()
+		}
+		return rec.cmd.Process.Signal(os.Interrupt)
+	}
+	return errors.New("no such recording")
+}
+
+// StopAll stops every active recording and waits briefly for ffmpeg to
+// finish writing.
+func (r *recorder) StopAll() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	var waiting []*recording
+	for _, rec := range r.recs {
+		if rec.info.State == recordingActive {
+			waiting = append(waiting, rec)
+		}
+	}
+	r.mu.Unlock()
+	for _, rec := range waiting {
+		_ = r.Stop(rec.info.ID)
+	}
+	deadline := time.After(recordingStopTimeout)
+	for _, rec := range waiting {
+		select {
+		case <-rec.done:
+		case <-deadline:
+			return
+		}
+	}
+}
+
+// tailWriter keeps the last max bytes written, for ffmpeg's error output.
+type tailWriter struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > w.max {
+		w.buf = w.buf[len(w.buf)-w.max:]
+	}
+	return len(p), nil
+}
+
+func (w *tailWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return string(w.buf)
+}
+
+// ────────────────────────────────
+// RECORDINGS PANEL
+// ────────────────────────────────
+
+type (
+	recordingStartedMsg recordingInfo
+	recordingEndedMsg   recordingInfo
+	recordingsTickMsg   struct{}
+)
+
+type recordingKeys struct {
+	Play, Stop key.Binding
+}
+
+func defaultRecordingKeys() recordingKeys {
+	return recordingKeys{
+		Play: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "play file")),
+		Stop: key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "stop")),
+	}
+}
+
+func newRecordingsList() *ListColumn[recordingInfo] {
+	return NewListColumn[recordingInfo]("Recordings", func(r recordingInfo) string {
+		end := r.Ended
+		state := r.State.String()
+		if r.State == recordingActive {
+			end = time.Now()
+			if r.Download {
+				state = "DL"
+			}
+		}
+		text := fmt.Sprintf("[%s] %s  %s  %s  %s – %s",
+			state, r.Started.Local().Format("Jan 2 15:04"), r.Title,
+			formatPlaybackPosition(end.Sub(r.Started).Seconds()), formatByteSize(r.Size), r.Path)
+		if r.Err != nil {
+			text += fmt.Sprintf(" (%v)", r.Err)
+		}
+		return text
+	})
+}
+
+func recordingsTick() tea.Cmd {
+	return tea.Tick(recordingsRefreshInterval, func(time.Time) tea.Msg { return recordingsTickMsg{} })
+}
+
+// recordStream extracts a stream of mt and records it with ffmpeg.
+func (m *Model) recordStream(st Stream, mt Match) tea.Cmd {
+	return m.trackExtraction("record:"+st.EmbedURL, fmt.Sprintf("Extracting stream #%d for recording", st.StreamNo), func(ctx context.Context) tea.Cmd {
+		return m.runRecorder(ctx, st, m.fallbackStreams(st, mt), mt)
+	})
+}
+
+func (m Model) runRecorder(ctx context.Context, st Stream, fallbacks []Stream, mt Match) tea.Cmd {
+	return safeCmd("recorder", m.crash, func() tea.Msg {
+		if st.EmbedURL == "" {
+			return debugLogMsg("Recorder aborted: empty embed URL")
+		}
+		logcb := m.ui.Log
+		st, res, err := m.extractWithFallback(ctx, append([]Stream{st}, fallbacks...), logcb)
+		if ctx.Err() != nil {
+			return debugLogMsg(fmt.Sprintf("Extraction of stream #%d canceled", st.StreamNo))
+		}
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("Extractor failed: %v", err))
+		}
+		title := matchDisplayTitle(mt)
+		if title == "" {
+			title = fmt.Sprintf("%s stream %d", st.Source, st.StreamNo)
+		}
+		info, err := m.recorder.Start(title, res.URL, res.Headers)
+		if err != nil {
+			return errorMsg(fmt.Errorf("record: %w", err))
+		}
+		logcb(fmt.Sprintf("[record] ⏺ recording %s to %s", res.URL, info.Path))
+		return recordingStartedMsg(info)
+	})
+}
+
+// openRecordings switches to the recordings panel, which refreshes itself
+// while open.
+func (m *Model) openRecordings() tea.Cmd {
+	m.recordings.SetItems(m.recorder.List())
+	m.currentView = viewRecordings
+	return recordingsTick()
+}
+
+func (m *Model) refreshRecordings() {
+	m.recordings.ReplaceItems(m.recorder.List(), func(a, b recordingInfo) bool { return a.ID == b.ID })
+}
+
+// updateRecordings handles keys while the recordings panel is open.
+func (m *Model) updateRecordings(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.recordings.CursorUp()
+		return nil
+	case key.Matches(msg, m.keys.Down):
+		m.recordings.CursorDown()
+		return nil
+	}
+
+	r, ok := m.recordings.Selected()
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(msg, m.recordingKeys.Stop):
+		if r.State != recordingActive {
+			return nil
+		}
+		if err := m.recorder.Stop(r.ID); err != nil {
+			m.lastError = fmt.Errorf("stop recording: %w", err)
+			return nil
+		}
+		m.status = fmt.Sprintf("Stopping %s of %s", strings.ToLower(r.noun()), r.Title)
+	case key.Matches(msg, m.recordingKeys.Play):
+		return m.playRecording(r)
+	}
+	return nil
+}
+
+// playRecording opens a recording file in the player; mpv can play it while
+// ffmpeg is still writing.
+func (m Model) playRecording(r recordingInfo) tea.Cmd {
+	opts := m.opts
+	return safeCmd("play recording", m.crash, func() tea.Msg {
+		// Streamlink only handles URLs, so files go to the player directly.
+		var player Player = opts.mpvPlayer()
+		if opts.PlayerCommand != "" {
+			cmd, err := newCommandPlayer(opts.PlayerCommand)
+			if err != nil {
+				return errorMsg(err)
+			}
+			player = cmd
+		}
+		if err := player.Launch(playRequest{URL: r.Path, Title: r.Title}, m.ui.Log); err != nil {
+			return errorMsg(fmt.Errorf("play recording: %w", err))
+		}
+		return debugLogMsg(fmt.Sprintf("[record] playing %s", r.Path))
+	})
+}
+
+func (m Model) renderRecordingsView() string {
+	header := m.styles.Title.Render("Recordings")
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter play file · x stop · Esc back"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.recordings.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
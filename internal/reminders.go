@@ -0,0 +1,327 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// REMINDERS
+// ────────────────────────────────
+
+// scheduleTickInterval is how often armed jobs are checked.
+const scheduleTickInterval = 30 * time.Second
+
+type (
+	scheduleTickMsg struct{}
+	jobsDueMsg      []scheduledJob
+)
+
+type reminderKeys struct {
+	Snooze, LeadUp, LeadDown, Cancel key.Binding
+}
+
+func defaultReminderKeys() reminderKeys {
+	return reminderKeys{
+		Snooze:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "snooze 5m")),
+		LeadUp:   key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "remind earlier")),
+		LeadDown: key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "remind later")),
+		Cancel:   key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "cancel")),
+	}
+}
+
+func scheduleTick() tea.Cmd {
+	return tea.Tick(scheduleTickInterval, func(time.Time) tea.Msg { return scheduleTickMsg{} })
+}
+
+// checkSchedule fires every job that has come due.
+func (m Model) checkSchedule() tea.Cmd {
+	store := m.schedule
+	return safeCmd("check schedule", m.crash, func() tea.Msg {
+		due, err := store.TakeDue(time.Now())
+		if err != nil {
+			return debugLogMsg(fmt.Sprintf("[schedule] save failed: %v", err))
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		return jobsDueMsg(due)
+	})
+}
+
+// handleJobsDue announces due reminders in the status line and as a desktop
+// notification. Other job kinds are dispatched by their own features.
+func (m *Model) handleJobsDue(jobs []scheduledJob) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range jobs {
+		if job.Kind != jobReminder {
+			continue
+		}
+		line := fmt.Sprintf("⏰ %s starts %s", job.Title, formatUntil(job.Start, time.Now()))
+		m.status = line
+		note := reminderNotification(job, time.Now())
+		m.hooks.Fire(newHookEvent(hookReminder, job.match(), Stream{}, note.Summary+" – "+note.Body))
+		cmds = append(cmds, m.logToUI("[reminder] "+line), m.notify(note))
+	}
+	return tea.Batch(cmds...)
+}
+
+// reminderNotification announces a due reminder.
+func reminderNotification(job scheduledJob, now time.Time) notification {
+	return notification{
+		Summary: job.Title,
+		Body:    fmt.Sprintf("Starts %s (%s)", formatUntil(job.Start, now), job.Start.Local().Format("15:04")),
+		Tag:     job.MatchID,
+		Actions: []notificationAction{
+			{Key: notificationDefaultAction, Label: "Open stream"},
+			{Key: "open", Label: "Open stream"},
+		},
+	}
+}
+
+func (m Model) notify(note notification) tea.Cmd {
+	notifier := m.notifier
+	return safeCmd("notify", m.crash, func() tea.Msg {
+		if err := notifyDesktop(notifier, note); err != nil {
+			ringBell()
+			return debugLogMsg(fmt.Sprintf("[notify] %v – rang the terminal bell instead", err))
+		}
+		return nil
+	})
+}
+
+// openFromNotification plays the match behind a clicked reminder or kickoff
+// notification: its streams are fetched and the first playable one is
+// handed to the extractor.
+func (m *Model) openFromNotification(msg notificationActionMsg) tea.Cmd {
+	if msg.Action != "open" && msg.Action != notificationDefaultAction {
+		return nil
+	}
+	mt, ok := m.notifiedMatch(msg.Tag)
+	if !ok {
+		return nil
+	}
+	return m.autoplayMatch(mt)
+}
+
+// autoplayMatch fetches a match's streams and plays the first playable one.
+func (m *Model) autoplayMatch(mt Match) tea.Cmd {
+	provider, pref := m.provider, m.streamPref
+	fetch := safeCmd("fetch streams", m.crash, func() tea.Msg {
+		streams, err := provider.ListStreams(context.Background(), mt)
+		// Play from the sources that answered if some did not.
+		if _, err := partialStreams(err); err != nil {
+			return errorMsg(err)
+		}
+		return autoplayStreamsMsg{Match: mt, Streams: reorderStreams(streams, pref)}
+	})
+	return m.track(opStreams, "streams:"+mt.ID, fmt.Sprintf("Loading streams for %s", mt.Title), fetch)
+}
+
+// autoplayStreamsMsg carries streams fetched to start playback without the
+// user picking a stream.
+type autoplayStreamsMsg struct {
+	Match   Match
+	Streams []Stream
+}
+
+func (m *Model) handleAutoplayStreams(msg autoplayStreamsMsg) tea.Cmd {
+	m.prefetch.store(msg.Match.ID, msg.Streams)
+	st, ok := autoPickStream(msg.Streams, m.opts.Languages)
+	if !ok {
+		m.status = fmt.Sprintf("No playable streams for %s yet", msg.Match.Title)
+		if len(m.opts.Languages) > 0 {
+			m.status = fmt.Sprintf("No playable %s streams for %s yet", strings.Join(m.opts.Languages, "/"), msg.Match.Title)
+		}
+		return nil
+	}
+	return tea.Batch(
+		m.logToUI(fmt.Sprintf("Auto-playing stream #%d for %s", st.StreamNo, msg.Match.Title)),
+		m.launchStream(st, msg.Match, m.opts.Fullscreen, true),
+	)
+}
+
+// armReminder schedules a reminder for the highlighted match, or cancels the
+// one already armed.
+func (m *Model) armReminder() tea.Cmd {
+	mt, ok := m.matches.Selected()
+	if !ok {
+		return nil
+	}
+	if existing, ok := m.schedule.Find(jobReminder, mt.ID); ok {
+		if err := m.schedule.Remove(existing.ID); err != nil {
+			m.lastError = err
+			return nil
+		}
+		m.status = fmt.Sprintf("Reminder cancelled for %s", mt.Title)
+		return nil
+	}
+	start := time.UnixMilli(mt.Date)
+	if !start.After(time.Now()) {
+		m.status = fmt.Sprintf("%s has already started", mt.Title)
+		return nil
+	}
+	job, err := m.schedule.Add(scheduledJob{
+		Kind:     jobReminder,
+		MatchID:  mt.ID,
+		Title:    mt.Title,
+		Sources:  mt.Sources,
+		Provider: mt.Provider,
+		Start:    start,
+		Lead:     defaultReminderLead,
+	})
+	if err != nil {
+		m.lastError = err
+		return nil
+	}
+	m.status = fmt.Sprintf("⏰ Reminder set for %s (%s before start)", mt.Title, formatLead(job.Lead))
+	return nil
+}
+
+// openReminders switches to the reminders view.
+func (m *Model) openReminders() {
+	_ = m.schedule.Prune(time.Now().Add(-6 * time.Hour))
+	m.reminderCursor = 0
+	m.currentView = viewReminders
+}
+
+// updateReminders handles keys while the reminders view is open.
+func (m *Model) updateReminders(msg tea.KeyMsg) tea.Cmd {
+	jobs := m.schedule.List()
+	if m.reminderCursor >= len(jobs) {
+		m.reminderCursor = len(jobs) - 1
+	}
+	if m.reminderCursor < 0 {
+		m.reminderCursor = 0
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.reminderCursor > 0 {
+			m.reminderCursor--
+		}
+		return nil
+	case key.Matches(msg, m.keys.Down):
+		if m.reminderCursor < len(jobs)-1 {
+			m.reminderCursor++
+		}
+		return nil
+	}
+
+	if len(jobs) == 0 {
+		return nil
+	}
+	job := jobs[m.reminderCursor]
+
+	var err error
+	switch {
+	case key.Matches(msg, m.reminderKeys.Snooze):
+		err = m.schedule.Update(job.ID, func(j *scheduledJob) {
+			base := time.Now()
+			if j.due().After(base) {
+				base = j.due()
+			}
+			j.SnoozedUntil = base.Add(snoozeStep)
+			j.Fired = false
+		})
+		m.status = fmt.Sprintf("Snoozed %s for %s", job.Title, formatLead(snoozeStep))
+	case key.Matches(msg, m.reminderKeys.LeadUp):
+		err = m.schedule.Update(job.ID, func(j *scheduledJob) {
+			if j.Lead+leadStep <= maxLead {
+				j.Lead += leadStep
+			}
+			j.SnoozedUntil = time.Time{}
+			if j.due().After(time.Now()) {
+				j.Fired = false
+			}
+		})
+	case key.Matches(msg, m.reminderKeys.LeadDown):
+		err = m.schedule.Update(job.ID, func(j *scheduledJob) {
+			if j.Lead >= leadStep {
+				j.Lead -= leadStep
+			}
+			j.SnoozedUntil = time.Time{}
+			if j.due().After(time.Now()) {
+				j.Fired = false
+			}
+		})
+	case key.Matches(msg, m.reminderKeys.Cancel):
+		err = m.schedule.Remove(job.ID)
+		m.status = fmt.Sprintf("Cancelled %s for %s", job.Kind, job.Title)
+	}
+	if err != nil {
+		m.lastError = err
+	}
+	return nil
+}
+
+func (m Model) renderRemindersPanel() string {
+	header := m.styles.Title.Render("Reminders")
+	jobs := m.schedule.List()
+	now := time.Now()
+
+	var sb strings.Builder
+	sb.WriteString(header + "\n\n")
+	if len(jobs) == 0 {
+		sb.WriteString("No reminders armed. Press a on a match to set one.\n")
+	}
+	cursorWidth := lipgloss.Width(m.styles.Glyphs.Cursor)
+	for i, job := range jobs {
+		cursor := strings.Repeat(" ", cursorWidth)
+		if i == m.reminderCursor {
+			cursor = m.styles.Glyphs.Cursor
+		}
+		state := "fires " + formatUntil(job.due(), now)
+		if job.Fired {
+			state = "fired"
+		} else if !job.SnoozedUntil.IsZero() {
+			state = "snoozed, " + state
+		}
+		line := fmt.Sprintf("%s %-10s %s – starts %s, %s before (%s)",
+			cursor, job.Kind, job.Title, job.Start.Local().Format("Jan 2 15:04"), formatLead(job.Lead), state)
+		if i == m.reminderCursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("#FA8072")).Bold(true).Render(line)
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString("↑/↓ select · s snooze 5m · +/- change lead time · x cancel · Esc back")
+
+	return lipgloss.NewStyle().
+		Border(m.styles.Border).
+		BorderForeground(lipgloss.Color("#FA8072")).
+		Padding(1, 2).
+		Width(int(float64(m.TerminalWidth) * 0.95)).
+		Render(m.styles.Text(sb.String()))
+}
+
+// formatLead renders a lead time compactly, e.g. "5m" or "1h30m".
+func formatLead(d time.Duration) string {
+	s := d.Round(time.Minute).String()
+	s = strings.TrimSuffix(s, "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	if s == "" {
+		return "0m"
+	}
+	return s
+}
+
+// formatUntil describes t relative to now, e.g. "in 12m" or "now".
+func formatUntil(t, now time.Time) string {
+	d := t.Sub(now)
+	if d < time.Minute {
+		if d > -time.Minute {
+			return "now"
+		}
+		return formatLead(-d) + " ago"
+	}
+	return "in " + formatLead(d)
+}
@@ -0,0 +1,227 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ────────────────────────────────
+// SCHEDULER STORE
+// ────────────────────────────────
+//
+// Reminders, auto-launch jobs, and recordings are all "run something around
+// a match's start time", so they share one store in the data directory.
+
+type jobKind string
+
+const (
+	jobReminder   jobKind = "reminder"
+	jobAutoLaunch jobKind = "autolaunch"
+	jobRecording  jobKind = "recording"
+)
+
+const (
+	defaultReminderLead = 5 * time.Minute
+	snoozeStep          = 5 * time.Minute
+	leadStep            = 5 * time.Minute
+	maxLead             = 2 * time.Hour
+)
+
+// scheduledJob is one armed job for a match.
+type scheduledJob struct {
+	ID      string  `json:"id"`
+	Kind    jobKind `json:"kind"`
+	MatchID string  `json:"match_id"`
+	Title   string  `json:"title"`
+	// Sources lets a fired job fetch the match's streams without reloading
+	// the match list.
+	Sources []MatchSource `json:"sources,omitempty"`
+	// Provider names the provider that listed the match when several are
+	// configured.
+	Provider string        `json:"provider,omitempty"`
+	Start    time.Time     `json:"start"`
+	Lead     time.Duration `json:"lead"`
+	// SnoozedUntil overrides the due time after the job has been snoozed.
+	SnoozedUntil time.Time `json:"snoozed_until,omitempty"`
+	Fired        bool      `json:"fired,omitempty"`
+}
+
+// match rebuilds the job's match from what the job saved of it.
+func (j scheduledJob) match() Match {
+	return Match{ID: j.MatchID, Title: j.Title, Date: j.Start.UnixMilli(), Sources: j.Sources, Provider: j.Provider}
+}
+
+// due is when the job should fire.
+func (j scheduledJob) due() time.Time {
+	if !j.SnoozedUntil.IsZero() {
+		return j.SnoozedUntil
+	}
+	return j.Start.Add(-j.Lead)
+}
+
+// scheduleStore persists jobs as JSON. It is shared between the UI and
+// background commands, so access is serialized.
+type scheduleStore struct {
+	mu   sync.Mutex
+	path string
+	jobs []scheduledJob
+}
+
+// openScheduleStore loads the store from the data directory. A missing file
+// yields an empty store; the path is empty (memory only) when the directory
+// cannot be created.
+func openScheduleStore() (*scheduleStore, error) {
+	s := &scheduleStore{}
+	dir, err := ensureAppDir(dataDir)
+	if err != nil {
+		return s, err
+	}
+	s.path = filepath.Join(dir, "schedule.json")
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.jobs); err != nil {
+		return s, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// List returns the jobs sorted by due time.
+func (s *scheduleStore) List() []scheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]scheduledJob(nil), s.jobs...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].due().Before(out[j].due()) })
+	return out
+}
+
+// Find returns the job of the given kind for a match.
+func (s *scheduleStore) Find(kind jobKind, matchID string) (scheduledJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		if j.Kind == kind && j.MatchID == matchID {
+			return j, true
+		}
+	}
+	return scheduledJob{}, false
+}
+
+// Add arms a job, replacing any job of the same kind for the same match.
+func (s *scheduleStore) Add(job scheduledJob) (scheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job.ID == "" {
+		job.ID = string(job.Kind) + ":" + job.MatchID + ":" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	kept := s.jobs[:0]
+	for _, j := range s.jobs {
+		if j.Kind != job.Kind || j.MatchID != job.MatchID {
+			kept = append(kept, j)
+		}
+	}
+	s.jobs = append(kept, job)
+	return job, s.saveLocked()
+}
+
+// Update applies fn to the job with the given id.
+func (s *scheduleStore) Update(id string, fn func(*scheduledJob)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.jobs {
+		if s.jobs[i].ID == id {
+			fn(&s.jobs[i])
+			return s.saveLocked()
+		}
+	}
+	return fmt.Errorf("no scheduled job %q", id)
+}
+
+// Remove cancels the job with the given id.
+func (s *scheduleStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.jobs[:0]
+	for _, j := range s.jobs {
+		if j.ID != id {
+			kept = append(kept, j)
+		}
+	}
+	s.jobs = kept
+	return s.saveLocked()
+}
+
+// TakeDue marks every unfired job due at or before now as fired and returns
+// them.
+func (s *scheduleStore) TakeDue(now time.Time) ([]scheduledJob, error) {
+	return s.takeDue(now, "")
+}
+
+// TakeDueOf is TakeDue for the jobs of one kind, leaving the others armed.
+func (s *scheduleStore) TakeDueOf(now time.Time, kind jobKind) ([]scheduledJob, error) {
+	return s.takeDue(now, kind)
+}
+
+// takeDue takes the due jobs of kind, or of every kind when it is empty.
+func (s *scheduleStore) takeDue(now time.Time, kind jobKind) ([]scheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []scheduledJob
+	for i := range s.jobs {
+		if kind != "" && s.jobs[i].Kind != kind {
+			continue
+		}
+		if !s.jobs[i].Fired && !s.jobs[i].due().After(now) {
+			s.jobs[i].Fired = true
+			due = append(due, s.jobs[i])
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	return due, s.saveLocked()
+}
+
+// Prune drops fired jobs for matches that started before cutoff.
+func (s *scheduleStore) Prune(cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.jobs[:0]
+	for _, j := range s.jobs {
+		if !j.Fired || !j.Start.Before(cutoff) {
+			kept = append(kept, j)
+		}
+	}
+	if len(kept) == len(s.jobs) {
+		return nil
+	}
+	s.jobs = kept
+	return s.saveLocked()
+}
+
+func (s *scheduleStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
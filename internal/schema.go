@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// schemaChecker compares raw API payloads against the Go types they decode
+// into and reports drift: keys the API sends that we do not model, and modeled
+// keys that are absent from every object in a response. Each finding is
+// reported once per session to keep the debug pane readable.
+type schemaChecker struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+	warn func(string)
+}
+
+func newSchemaChecker(warn func(string)) *schemaChecker {
+	return &schemaChecker{seen: map[string]struct{}{}, warn: warn}
+}
+
+// objectStats aggregates the keys observed for one struct position in the
+// payload (e.g. every element of a []Match shares the "Match" position).
+type objectStats struct {
+	typ     reflect.Type
+	objects int
+	present map[string]int
+}
+
+func (s *schemaChecker) check(rawURL string, data []byte, v any) {
+	if s == nil || s.warn == nil {
+		return
+	}
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return
+	}
+
+	stats := map[string]*objectStats{}
+	var order []string
+	collectSchema(t.Elem(), typeLabel(t.Elem()), json.RawMessage(data), stats, &order)
+
+	endpoint := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Path != "" {
+		endpoint = u.Path
+	}
+
+	for _, path := range order {
+		st := stats[path]
+		if st.objects == 0 {
+			continue
+		}
+		expected := jsonFieldNames(st.typ)
+
+		var unknown []string
+		for key := range st.present {
+			if _, ok := expected[key]; !ok {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			s.report(fmt.Sprintf("[schema] %s: unknown field %q in %s", endpoint, key, path))
+		}
+
+		var missing []string
+		for key, optional := range expected {
+			if !optional && st.present[key] == 0 {
+				missing = append(missing, key)
+			}
+		}
+		sort.Strings(missing)
+		for _, key := range missing {
+			s.report(fmt.Sprintf("[schema] %s: expected field %q missing from all %d %s objects", endpoint, key, st.objects, path))
+		}
+	}
+}
+
+func (s *schemaChecker) report(line string) {
+	s.mu.Lock()
+	if _, ok := s.seen[line]; ok {
+		s.mu.Unlock()
+		return
+	}
+	s.seen[line] = struct{}{}
+	s.mu.Unlock()
+	s.warn(line)
+}
+
+func collectSchema(t reflect.Type, path string, raw json.RawMessage, stats map[string]*objectStats, order *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return
+		}
+		for _, item := range items {
+			collectSchema(t.Elem(), path, item, stats, order)
+		}
+
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return
+		}
+		st, ok := stats[path]
+		if !ok {
+			st = &objectStats{typ: t, present: map[string]int{}}
+			stats[path] = st
+			*order = append(*order, path)
+		}
+		st.objects++
+		for key := range obj {
+			st.present[key]++
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := jsonName(f)
+			if name == "" {
+				continue
+			}
+			if child, ok := obj[name]; ok {
+				collectSchema(f.Type, path+"."+name, child, stats, order)
+			}
+		}
+	}
+}
+
+// jsonFieldNames returns the JSON keys a struct type decodes, mapped to
+// whether the key is optional and so never reported missing: tagged
+// omitempty, or schema:"optional" for fields the API may leave out but
+// --json output always carries.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	out := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name := jsonName(f); name != "" {
+			out[name] = strings.Contains(f.Tag.Get("json"), ",omitempty") || f.Tag.Get("schema") == "optional"
+		}
+	}
+	return out
+}
+
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name
+}
+
+func typeLabel(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return "payload"
+}
@@ -0,0 +1,243 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/sync/errgroup"
+)
+
+// ────────────────────────────────
+// GLOBAL SEARCH
+// ────────────────────────────────
+
+const (
+	// searchIndexTTL is how long the fetched-across-sports match list is
+	// reused before the search view refetches it.
+	searchIndexTTL = 5 * time.Minute
+	// searchFetchConcurrency bounds parallel per-sport requests.
+	searchFetchConcurrency = 4
+)
+
+// searchEntry is one match in the search index with the text it is matched
+// against.
+type searchEntry struct {
+	Match    Match
+	Sport    string
+	haystack string
+}
+
+type searchHit struct {
+	searchEntry
+	score int
+}
+
+type allMatchesLoadedMsg struct {
+	Entries []searchEntry
+	Failed  int
+}
+
+// searchModel is the state of the global search view.
+type searchModel struct {
+	input   textinput.Model
+	results *ListColumn[searchHit]
+	index   []searchEntry
+	loaded  time.Time
+}
+
+func newSearchModel(kickoff *kickoffClock) searchModel {
+	ti := textinput.New()
+	ti.Prompt = "Search: "
+	ti.Placeholder = "team, title, or competition"
+	ti.CharLimit = 80
+	results := NewListColumn[searchHit]("Results", func(h searchHit) string {
+		return fmt.Sprintf("%s  %s (%s) [%s]", kickoff.label(h.Match, time.Now()), matchDisplayTitle(h.Match), h.Match.Category, h.Sport)
+	})
+	results.SetDecorator(func(h searchHit, text string) string {
+		return colorLiveBadge(kickoff.label(h.Match, time.Now()), text)
+	})
+	return searchModel{input: ti, results: results}
+}
+
+// matchDisplayTitle prefers "Home vs Away" over the raw title.
+func matchDisplayTitle(mt Match) string {
+	if mt.Teams != nil && mt.Teams.Home != nil && mt.Teams.Away != nil {
+		return fmt.Sprintf("%s vs %s", mt.Teams.Home.Name, mt.Teams.Away.Name)
+	}
+	return mt.Title
+}
+
+// openSearch switches to the search view, fetching matches across all sports
+// when the index is missing or stale.
+func (m *Model) openSearch() tea.Cmd {
+	m.currentView = viewSearch
+	m.search.input.SetValue("")
+	m.refreshSearchResults()
+	cmds := []tea.Cmd{m.search.input.Focus()}
+	if m.search.index == nil || time.Since(m.search.loaded) > searchIndexTTL {
+		cmds = append(cmds, m.track(opSearch, opSearch, "Loading matches across all sports", m.fetchAllMatches()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchAllMatches builds the search index.
+func (m Model) fetchAllMatches() tea.Cmd {
+	provider := m.provider
+	return safeCmd("fetch all matches", m.crash, func() tea.Msg {
+		entries, failed, err := loadAllMatches(context.Background(), provider)
+		if err != nil {
+			return errorMsg(err)
+		}
+		return allMatchesLoadedMsg{Entries: entries, Failed: failed}
+	})
+}
+
+// loadAllMatches loads every sport's match list in parallel, dedupes matches
+// listed under more than one sport, and sorts them by start time. failed
+// counts sports whose list could not be loaded.
+func loadAllMatches(ctx context.Context, provider Provider) (entries []searchEntry, failed int, err error) {
+	sports, err := provider.ListSports(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = map[string]bool{}
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(searchFetchConcurrency)
+	for _, sport := range sports {
+		g.Go(func() error {
+			matches, err := provider.ListMatches(gctx, sport.ID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				// One sport failing should not hide the others.
+				failed++
+				return nil
+			}
+			for _, mt := range matches {
+				if seen[mt.ID] {
+					continue
+				}
+				seen[mt.ID] = true
+				entries = append(entries, newSearchEntry(mt, sport.Name))
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Match.Date < entries[j].Match.Date })
+	return entries, failed, nil
+}
+
+func newSearchEntry(mt Match, sport string) searchEntry {
+	parts := []string{mt.Title, matchDisplayTitle(mt), mt.Category, sport, mt.Provider}
+	return searchEntry{Match: mt, Sport: sport, haystack: strings.Join(parts, " ")}
+}
+
+func (m *Model) handleAllMatchesLoaded(msg allMatchesLoadedMsg) tea.Cmd {
+	m.search.index = msg.Entries
+	m.search.loaded = time.Now()
+	m.refreshSearchResults()
+	m.status = fmt.Sprintf("Indexed %d matches for search", len(msg.Entries))
+	if msg.Failed > 0 {
+		m.status += fmt.Sprintf(" (%d sports failed to load)", msg.Failed)
+	}
+	return nil
+}
+
+// refreshSearchResults reruns the query against the index.
+func (m *Model) refreshSearchResults() {
+	query := strings.TrimSpace(m.search.input.Value())
+	hits := make([]searchHit, 0, len(m.search.index))
+	for _, e := range m.search.index {
+		if query == "" {
+			hits = append(hits, searchHit{searchEntry: e})
+			continue
+		}
+		if score, ok := fuzzyScore(query, e.haystack); ok {
+			hits = append(hits, searchHit{searchEntry: e, score: score})
+		}
+	}
+	if query != "" {
+		sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	}
+	m.search.results.SetItems(hits)
+}
+
+// updateSearch handles keys in the search view: typing refines the results,
+// arrows move, Enter opens the match's streams, and Esc closes the view.
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.search.input.Blur()
+		m.currentView = viewMain
+		return nil
+	case tea.KeyUp:
+		m.search.results.CursorUp()
+		return nil
+	case tea.KeyDown:
+		m.search.results.CursorDown()
+		return nil
+	case tea.KeyEnter:
+		hit, ok := m.search.results.Selected()
+		if !ok {
+			return nil
+		}
+		m.search.input.Blur()
+		m.currentView = viewMain
+		return m.openMatch(hit.Match)
+	}
+	var cmd tea.Cmd
+	m.search.input, cmd = m.search.input.Update(msg)
+	m.refreshSearchResults()
+	return cmd
+}
+
+func (m Model) renderSearchView() string {
+	header := m.styles.Title.Render("Search all matches")
+	hint := m.styles.Subtle.Render(m.styles.Text("↑/↓ select · Enter open streams · Esc back"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.search.input.View(),
+		m.search.results.View(m.styles, true),
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
+
+// fuzzyScore reports whether every rune of query (spaces ignored) appears in
+// text in order, case-insensitively, and scores the match: consecutive runs
+// and matches at word starts score higher, gaps cost a little.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(text))
+	score, qi, run := 0, 0, 0
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			run = 0
+			score--
+			continue
+		}
+		score += 10
+		if run > 0 {
+			score += 5 * run
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 15
+		}
+		run++
+		qi++
+	}
+	return score, qi == len(q)
+}
@@ -0,0 +1,247 @@
+package internal
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// settingsFile is one file bundled by `config export`. It is stored in the
+// archive under the name of its store rather than its directory, so it is
+// restored to wherever that store lives on another machine, whatever its
+// platform layout.
+type settingsFile struct {
+	name string
+	path string
+}
+
+// settingsFiles lists the files holding user settings: the config file, the
+// favorites, history, schedule, and slot stores, and the saved view
+// preferences. Recordings, downloads, and keys are left out.
+func settingsFiles() ([]settingsFile, error) {
+	config, err := configDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate config directory: %w", err)
+	}
+	data, err := dataDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate data directory: %w", err)
+	}
+	state, err := stateDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate state directory: %w", err)
+	}
+	return []settingsFile{
+		{name: configFileName, path: filepath.Join(config, configFileName)},
+		{name: "favorites.json", path: filepath.Join(data, "favorites.json")},
+		{name: "history.json", path: filepath.Join(data, "history.json")},
+		{name: "schedule.json", path: filepath.Join(data, "schedule.json")},
+		{name: "slots.json", path: filepath.Join(data, "slots.json")},
+		{name: "view.json", path: filepath.Join(state, "view.json")},
+	}, nil
+}
+
+// RunConfigCommand implements `streamed-tui config export|import <archive>`.
+func RunConfigCommand(args []string) error {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	force := fs.Bool("force", false, "overwrite existing files on init or import")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: streamed-tui config init [--force]")
+		fmt.Fprintln(fs.Output(), "       streamed-tui config path")
+		fmt.Fprintln(fs.Output(), "       streamed-tui config export [archive.tar.gz]")
+		fmt.Fprintln(fs.Output(), "       streamed-tui config import [--force] <archive.tar.gz>")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errors.New("missing config subcommand")
+	}
+	sub := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	files, err := settingsFiles()
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "init":
+		path, err := initConfig(*force)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", path)
+		return nil
+	case "path":
+		path, err := ConfigPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	case "export":
+		path := fs.Arg(0)
+		if path == "" {
+			path = fmt.Sprintf("streamed-tui-settings-%s.tar.gz", time.Now().Format("20060102"))
+		}
+		n, err := exportSettings(path, files)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("exported %d files to %s\n", n, path)
+		return nil
+	case "import":
+		path := fs.Arg(0)
+		if path == "" {
+			fs.Usage()
+			return errors.New("missing archive path")
+		}
+		n, skipped, err := importSettings(path, files, *force)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported %d files from %s\n", n, path)
+		if skipped > 0 {
+			fmt.Printf("skipped %d existing files (use --force to overwrite)\n", skipped)
+		}
+		return nil
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown config subcommand %q", sub)
+	}
+}
+
+// exportSettings writes the settings files that exist into a gzipped tar
+// archive and returns how many files were written.
+func exportSettings(path string, files []settingsFile) (int, error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	count := 0
+	for _, sf := range files {
+		err := func() error {
+			f, err := os.Open(sf.path)
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			hdr, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			hdr.Name = sf.name
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, f); err != nil {
+				return err
+			}
+			count++
+			return nil
+		}()
+		if err != nil {
+			return count, fmt.Errorf("export %s: %w", sf.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return count, err
+	}
+	if err := gz.Close(); err != nil {
+		return count, err
+	}
+	return count, out.Close()
+}
+
+// importSettings restores an archive written by exportSettings. Existing files
+// are kept unless force is set. Entries that are not settings files are
+// ignored; archives from older versions, which stored whole directories
+// under config/ and data/, are matched by file name.
+func importSettings(path string, files []settingsFile, force bool) (imported, skipped int, err error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return 0, 0, fmt.Errorf("not a settings archive: %w", err)
+	}
+	defer gz.Close()
+
+	targets := map[string]string{}
+	for _, sf := range files {
+		targets[sf.name] = sf.path
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, skipped, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := hdr.Name
+		if dir, file, ok := strings.Cut(name, "/"); ok && (dir == "config" || dir == "data") {
+			name = file
+		}
+		target, known := targets[name]
+		if !known {
+			continue
+		}
+
+		if _, err := os.Stat(target); err == nil && !force {
+			skipped++
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return imported, skipped, err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return imported, skipped, err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return imported, skipped, err
+		}
+		if err := f.Close(); err != nil {
+			return imported, skipped, err
+		}
+		imported++
+	}
+	return imported, skipped, nil
+}
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// QUICK-LAUNCH SLOTS
+// ────────────────────────────────
+
+// maxSlots is how many quick-launch slots there are, one per number key.
+const maxSlots = 9
+
+// quickSlot is a stream, or a match whose best stream is picked when it is
+// launched, kept under a number key. Enough of the match is saved to fetch
+// its streams without reloading the match list.
+type quickSlot struct {
+	Slot       int           `json:"slot"`
+	MatchID    string        `json:"match_id"`
+	MatchTitle string        `json:"match_title"`
+	Sources    []MatchSource `json:"sources,omitempty"`
+	Provider   string        `json:"provider,omitempty"`
+	// Stream is unset for a match slot.
+	Stream *Stream `json:"stream,omitempty"`
+}
+
+// match rebuilds the slot's match from what the slot saved of it.
+func (q quickSlot) match() Match {
+	return Match{ID: q.MatchID, Title: q.MatchTitle, Sources: q.Sources, Provider: q.Provider}
+}
+
+func (q quickSlot) label() string {
+	if q.Stream == nil {
+		return q.MatchTitle
+	}
+	return fmt.Sprintf("%s #%d (%s)", q.MatchTitle, q.Stream.StreamNo, q.Stream.Source)
+}
+
+// same reports whether two slots launch the same thing.
+func (q quickSlot) same(o quickSlot) bool {
+	if q.MatchID != o.MatchID || (q.Stream == nil) != (o.Stream == nil) {
+		return false
+	}
+	return q.Stream == nil || q.Stream.EmbedURL == o.Stream.EmbedURL
+}
+
+// slotsStore persists the quick-launch slots as JSON in the data directory.
+type slotsStore struct {
+	mu    sync.Mutex
+	path  string
+	slots []quickSlot
+}
+
+// openSlotsStore loads slots.json. A missing file yields an empty store;
+// the path is empty (memory only) when the directory cannot be created.
+func openSlotsStore() (*slotsStore, error) {
+	s := &slotsStore{}
+	dir, err := ensureAppDir(dataDir)
+	if err != nil {
+		return s, err
+	}
+	s.path = filepath.Join(dir, "slots.json")
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.slots); err != nil {
+		return s, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// Get returns what slot n holds.
+func (s *slotsStore) Get(n int) (quickSlot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.slots, func(q quickSlot) bool { return q.Slot == n })
+	if i < 0 {
+		return quickSlot{}, false
+	}
+	return s.slots[i], true
+}
+
+// List returns the filled slots in slot order.
+func (s *slotsStore) List() []quickSlot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.slots)
+}
+
+// Toggle puts q in its slot, or empties the slot when it already holds the
+// same stream or match. It reports whether the slot is filled afterwards.
+func (s *slotsStore) Toggle(q quickSlot) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.slots, func(o quickSlot) bool { return o.Slot == q.Slot })
+	filled := true
+	switch {
+	case i >= 0 && s.slots[i].same(q):
+		s.slots = slices.Delete(s.slots, i, i+1)
+		filled = false
+	case i >= 0:
+		s.slots[i] = q
+	default:
+		s.slots = append(s.slots, q)
+		slices.SortFunc(s.slots, func(a, b quickSlot) int { return a.Slot - b.Slot })
+	}
+	return filled, s.saveLocked()
+}
+
+func (s *slotsStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.slots, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// slotNumber reads the slot a "1"–"9" or "alt+1"–"alt+9" key stands for.
+func slotNumber(msg tea.KeyMsg) (int, bool) {
+	k := strings.TrimPrefix(msg.String(), "alt+")
+	if len(k) != 1 || k[0] < '1' || k[0] > '0'+maxSlots {
+		return 0, false
+	}
+	return int(k[0] - '0'), true
+}
+
+// startSlotAssign waits for the number of the slot to put the highlighted
+// stream or match in.
+func (m *Model) startSlotAssign() {
+	if m.focus == focusSports {
+		m.status = "Highlight a match or a stream to put in a slot"
+		return
+	}
+	m.pendingSlot = true
+	var filled []string
+	for _, q := range m.slots.List() {
+		filled = append(filled, fmt.Sprintf("%d %s", q.Slot, q.label()))
+	}
+	what := "match"
+	if m.focus == focusStreams {
+		what = "stream"
+	}
+	m.status = "Slot 1-9 for the highlighted " + what + "?"
+	if len(filled) > 0 {
+		m.status += "  (" + strings.Join(filled, " · ") + ")"
+	}
+}
+
+// assignSlot puts the highlighted stream, or match when the Matches column
+// is focused, in slot n. Putting the same one in again empties the slot.
+func (m *Model) assignSlot(n int) tea.Cmd {
+	var q quickSlot
+	switch m.focus {
+	case focusMatches:
+		mt, ok := m.matches.Selected()
+		if !ok {
+			return nil
+		}
+		q = quickSlot{MatchID: mt.ID, MatchTitle: matchDisplayTitle(mt), Sources: mt.Sources, Provider: mt.Provider}
+	case focusStreams:
+		st, ok := m.streams.Selected()
+		if !ok || isAdminStream(st) || st.EmbedURL == "" {
+			m.status = "Browser-only streams cannot be put in a slot"
+			return nil
+		}
+		st.Viewers = 0 // stale by the time the slot is launched
+		mt := m.currentMatch()
+		q = quickSlot{MatchID: mt.ID, MatchTitle: matchDisplayTitle(mt), Sources: mt.Sources, Provider: mt.Provider, Stream: &st}
+		if q.MatchTitle == "" {
+			q.MatchTitle = st.EmbedURL
+		}
+	default:
+		return nil
+	}
+	q.Slot = n
+	filled, err := m.slots.Toggle(q)
+	if err != nil {
+		m.lastError = err
+		return nil
+	}
+	if !filled {
+		m.status = fmt.Sprintf("Emptied slot %d", n)
+		return nil
+	}
+	m.status = fmt.Sprintf("Slot %d: %s – Alt+%d launches it from anywhere", n, q.label(), n)
+	return nil
+}
+
+// launchSlot plays what slot n holds: its stream, or the best stream of its
+// match as picked for reminders.
+func (m *Model) launchSlot(n int) tea.Cmd {
+	q, ok := m.slots.Get(n)
+	if !ok {
+		m.status = fmt.Sprintf("Slot %d is empty – m then %d puts the highlighted stream or match in it", n, n)
+		return nil
+	}
+	m.lastError = nil
+	m.status = fmt.Sprintf("Launching slot %d: %s", n, q.label())
+	if q.Stream == nil {
+		return tea.Batch(
+			m.logToUI(fmt.Sprintf("Slot %d: finding the best stream for %s", n, q.MatchTitle)),
+			m.autoplayMatch(q.match()),
+		)
+	}
+	return tea.Batch(
+		m.logToUI(fmt.Sprintf("Slot %d: launching stream #%d for %s", n, q.Stream.StreamNo, q.MatchTitle)),
+		m.launchStream(*q.Stream, q.match(), m.opts.Fullscreen, true),
+	)
+}
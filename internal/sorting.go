@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ────────────────────────────────
+// SORTING
+// ────────────────────────────────
+
+// matchSort is the order of the Matches column.
+type matchSort int
+
+const (
+	sortByTime matchSort = iota
+	sortByViewers
+	sortByTitle
+	matchSortCount
+)
+
+var matchSortNames = [...]string{"time", "viewers", "title"}
+
+func (s matchSort) String() string { return matchSortNames[s] }
+
+// next returns the sort the sort key cycles to.
+func (s matchSort) next() matchSort { return (s + 1) % matchSortCount }
+
+// parseMatchSort reads a saved sort name; unknown names sort by time.
+func parseMatchSort(name string) matchSort {
+	for i, n := range matchSortNames {
+		if n == name {
+			return matchSort(i)
+		}
+	}
+	return sortByTime
+}
+
+// sortMatches returns a sorted copy of matches. The API order (by start
+// time) breaks ties.
+func sortMatches(matches []Match, by matchSort) []Match {
+	out := slices.Clone(matches)
+	switch by {
+	case sortByTime:
+		slices.SortStableFunc(out, func(a, b Match) int { return compareInt64(a.Date, b.Date) })
+	case sortByViewers:
+		slices.SortStableFunc(out, func(a, b Match) int { return b.Viewers - a.Viewers })
+	case sortByTitle:
+		slices.SortStableFunc(out, func(a, b Match) int {
+			return strings.Compare(strings.ToLower(matchDisplayTitle(a)), strings.ToLower(matchDisplayTitle(b)))
+		})
+	}
+	return out
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// matchDaySeparator starts a new day's matches with its date. It only makes
+// sense while matches are sorted by time.
+func matchDaySeparator(prev, curr Match) (string, bool) {
+	currDay := time.UnixMilli(curr.Date).Local().Format("Jan 2")
+	prevDay := ""
+	if prev.Date != 0 {
+		prevDay = time.UnixMilli(prev.Date).Local().Format("Jan 2")
+	}
+
+	if prevDay == "" || prevDay != currDay {
+		return currDay, true
+	}
+	return "", false
+}
+
+// applyMatches shows the current sport's matches that pass the match filter
+// and category in the chosen order, keeping the cursor on the same match
+// where possible.
+func (m *Model) applyMatches() {
+	title := m.matchesTitle + " · by " + m.matchSort.String()
+	if label := m.matchFiltersLabel(); label != "" {
+		title += " · " + label
+	}
+	if m.matchesStale {
+		title += staleSuffix
+	}
+	m.matches.SetTitle(title)
+	if m.matchSort == sortByTime {
+		m.matches.SetSeparator(matchDaySeparator)
+	} else {
+		m.matches.SetSeparator(nil)
+	}
+	shown := filterMatches(m.allMatches, m.matchFilter, time.Now(), m.opts.UpcomingWindow)
+	if m.matchCategory != "" {
+		shown = inCategory(shown, m.matchCategory)
+	}
+	m.matches.ReplaceItems(sortMatches(shown, m.matchSort), func(a, b Match) bool { return a.ID == b.ID })
+	m.matches.SetFooter(m.matchFilterFooter(len(shown)))
+}
+
+// cycleMatchSort switches the Matches column to the next sort order.
+func (m *Model) cycleMatchSort() {
+	m.matchSort = m.matchSort.next()
+	m.applyMatches()
+	m.status = "Sorting matches by " + m.matchSort.String()
+}
+
+// streamSort is the order of the Streams column. Ranked is the preference
+// order from reorderStreams; the others re-sort it for display.
+type streamSort int
+
+const (
+	sortStreamsRanked streamSort = iota
+	sortStreamsViewers
+	sortStreamsHD
+	sortStreamsLanguage
+	sortStreamsHealth
+	streamSortCount
+)
+
+var streamSortNames = [...]string{"ranked", "viewers", "HD", "language", "health"}
+
+func (s streamSort) String() string { return streamSortNames[s] }
+
+func (s streamSort) next() streamSort { return (s + 1) % streamSortCount }
+
+// parseStreamSort reads a saved sort name; unknown names keep the ranked
+// order.
+func parseStreamSort(name string) streamSort {
+	for i, n := range streamSortNames {
+		if n == name {
+			return streamSort(i)
+		}
+	}
+	return sortStreamsRanked
+}
+
+// sortStreams returns a sorted copy of streams. Admin streams stay at the end
+// under their separator, and the ranked order breaks ties. Sorting by health
+// uses the last stream check.
+func sortStreams(streams []Stream, by streamSort, health map[string]streamHealth) []Stream {
+	out := slices.Clone(streams)
+	slices.SortStableFunc(out, func(a, b Stream) int {
+		if isAdminStream(a) != isAdminStream(b) {
+			if isAdminStream(a) {
+				return 1
+			}
+			return -1
+		}
+		switch by {
+		case sortStreamsViewers:
+			return b.Viewers - a.Viewers
+		case sortStreamsHD:
+			if a.HD != b.HD {
+				if a.HD {
+					return -1
+				}
+				return 1
+			}
+		case sortStreamsLanguage:
+			return strings.Compare(strings.ToLower(a.Language), strings.ToLower(b.Language))
+		case sortStreamsHealth:
+			return compareHealth(health[a.EmbedURL], health[b.EmbedURL])
+		}
+		return 0
+	})
+	return out
+}
+
+// cycleStreamSort switches the Streams column to the next sort order.
+func (m *Model) cycleStreamSort() {
+	m.streamSort = m.streamSort.next()
+	m.applyStreams()
+	m.status = "Sorting streams by " + m.streamSort.String()
+}
+
+// streamRule is one entry of the stream preference: a language, a quality
+// ("HD" or "SD"), or both, as in "English HD".
+type streamRule struct {
+	language string
+	hd, sd   bool
+}
+
+// streamPreference ranks streams by the first rule they match, most
+// preferred first.
+type streamPreference []streamRule
+
+// parseStreamPreference reads entries such as "English HD", "Spanish", or
+// "HD". Words other than HD and SD name the language.
+func parseStreamPreference(entries []string) streamPreference {
+	var pref streamPreference
+	for _, entry := range entries {
+		var r streamRule
+		var lang []string
+		for _, word := range strings.Fields(entry) {
+			switch strings.ToUpper(word) {
+			case "HD":
+				r.hd = true
+			case "SD":
+				r.sd = true
+			default:
+				lang = append(lang, word)
+			}
+		}
+		r.language = strings.Join(lang, " ")
+		if r.language != "" || r.hd || r.sd {
+			pref = append(pref, r)
+		}
+	}
+	return pref
+}
+
+func (r streamRule) matches(st Stream) bool {
+	if r.language != "" && !strings.EqualFold(strings.TrimSpace(st.Language), r.language) {
+		return false
+	}
+	return (!r.hd || st.HD) && (!r.sd || !st.HD)
+}
+
+// rank is the index of the first rule a stream matches, or len(p) when it
+// matches none.
+func (p streamPreference) rank(st Stream) int {
+	for i, r := range p {
+		if r.matches(st) {
+			return i
+		}
+	}
+	return len(p)
+}
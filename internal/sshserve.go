@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// ────────────────────────────────
+// SSH SERVER
+// ────────────────────────────────
+
+const (
+	defaultSSHListen = ":2222"
+	sshHostKeyName   = "ssh_host_ed25519"
+	// sshShutdownGrace is how long open sessions get to end when the server
+	// is stopped.
+	sshShutdownGrace = 10 * time.Second
+)
+
+// sshShutdownKey holds a session's Model shutdown in its context, so it runs
+// once the session's program has exited.
+type sshShutdownKey struct{}
+
+// RunServeSSH handles "streamed-tui serve-ssh": it serves the TUI over SSH
+// until interrupted, with a Model of its own for every session. The
+// sessions share one set of stores. Extraction
+// and players run on the server, so streams play on its screen while the
+// TUI is driven from anywhere. Only keys in the authorized keys file may log
+// in.
+func RunServeSSH(args []string, opts Options) error {
+	fs := flag.NewFlagSet("serve-ssh", flag.ContinueOnError)
+	listen := fs.String("listen", defaultSSHListen, "address to accept SSH connections on")
+	hostKey := fs.String("host-key", "", "server host key, created when missing (default: "+sshHostKeyName+" in the config directory)")
+	authorizedKeys := fs.String("authorized-keys", "~/.ssh/authorized_keys", "public keys allowed to log in")
+	display := fs.String("display", "", `X display players and the browser open on, e.g. ":0" (default: the server's DISPLAY)`)
+	demo := fs.Bool("demo", opts.Demo, "serve the bundled demo fixtures")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: streamed-tui serve-ssh [--listen :2222] [--authorized-keys FILE] [--host-key FILE] [--display :0]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts.Demo = *demo
+	if err := opts.checkTUI(); err != nil {
+		return err
+	}
+
+	keys := expandHome(*authorizedKeys)
+	if _, err := os.Stat(keys); err != nil {
+		return fmt.Errorf("authorized keys: %w (serve-ssh only lets listed keys in)", err)
+	}
+	keyPath := expandHome(*hostKey)
+	if keyPath == "" {
+		var err error
+		if keyPath, err = defaultSSHHostKey(); err != nil {
+			return err
+		}
+	}
+	stores := openUserStores()
+	if *display != "" {
+		// Players and the browser inherit the environment.
+		os.Setenv("DISPLAY", *display)
+	}
+	// Sessions are rendered by the server, which has no terminal of its own
+	// to take colors from.
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	lipgloss.SetHasDarkBackground(true)
+
+	host, _ := os.Hostname()
+	srv, err := wish.NewServer(
+		wish.WithAddress(*listen),
+		wish.WithHostKeyPath(keyPath),
+		wish.WithAuthorizedKeys(keys),
+		wish.WithMiddleware(
+			sshSessionEnded,
+			bubbletea.MiddlewareWithProgramHandler(func(sess ssh.Session) *tea.Program {
+				return sshProgram(sess, opts, stores, host)
+			}, termenv.ANSI256),
+			activeterm.Middleware(),
+			sshSessionLogged,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	done := make(chan error, 1)
+	go func() { done <- srv.ListenAndServe() }()
+	log.Printf("serve-ssh: listening on %s (host key %s, authorized keys %s)", *listen, keyPath, keys)
+	select {
+	case err := <-done:
+		if errors.Is(err, ssh.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+	log.Println("serve-ssh: stopping")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), sshShutdownGrace)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// defaultSSHHostKey returns where the host key is kept when --host-key is
+// not given: in the config directory, next to the settings that decide who
+// may log in. A key created in the data directory by earlier versions is
+// moved there so the server keeps its fingerprint.
+func defaultSSHHostKey() (string, error) {
+	dir, err := ensureAppDir(configDir)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, sshHostKeyName)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	if old, err := dataDir(); err == nil {
+		old = filepath.Join(old, sshHostKeyName)
+		if _, err := os.Stat(old); err == nil {
+			if err := os.Rename(old, path); err != nil {
+				return old, nil
+			}
+			os.Rename(old+".pub", path+".pub")
+		}
+	}
+	return path, nil
+}
+
+// sshProgram builds a session's Model and program. The session's terminal
+// is not the server's, so images are off and the clipboard goes through the
+// session.
+func sshProgram(sess ssh.Session, opts Options, stores userStores, host string) *tea.Program {
+	opts.Images = imagesOff
+	opts.KeyScript = ""
+	m := newModel(opts, stores)
+	m.remote = sess
+	m.debugLines = append(m.debugLines, fmt.Sprintf("(served over SSH to %s; players open on %s)", sess.User(), host))
+	p := tea.NewProgram(m, append(bubbletea.MakeOptions(sess), tea.WithAltScreen())...)
+	m.ui.attach(p)
+	sess.Context().SetValue(sshShutdownKey{}, m.shutdown)
+	return p
+}
+
+// sshSessionEnded runs the session's Model shutdown after its program.
+func sshSessionEnded(next ssh.Handler) ssh.Handler {
+	return func(sess ssh.Session) {
+		if shutdown, ok := sess.Context().Value(sshShutdownKey{}).(func()); ok {
+			shutdown()
+		}
+		next(sess)
+	}
+}
+
+// sshSessionLogged logs sessions as they start and end.
+func sshSessionLogged(next ssh.Handler) ssh.Handler {
+	return func(sess ssh.Session) {
+		start := time.Now()
+		log.Printf("serve-ssh: %s connected from %s", sess.User(), sess.RemoteAddr())
+		next(sess)
+		log.Printf("serve-ssh: %s disconnected after %s", sess.User(), formatElapsed(time.Since(start)))
+	}
+}
@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// TABS
+// ────────────────────────────────
+
+// maxTabs is how many tabs can be open, one per number key.
+const maxTabs = 9
+
+// tabTitleWidth bounds a tab's title in the tab bar.
+const tabTitleWidth = 28
+
+// workspace is what a tab keeps of the main view: its Matches and Streams
+// columns and the sport and match they show. The Sports column and the
+// filters are shared by every tab. The active tab's workspace lives in the
+// Model's own fields, so Model.tabs only holds it while another tab is
+// active.
+type workspace struct {
+	matches       *ListColumn[Match]
+	streams       *ListColumn[Stream]
+	focus         focusCol
+	shownSport    string
+	shownMatch    string
+	openedMatch   Match
+	allMatches    []Match
+	matchesTitle  string
+	matchesStale  bool
+	matchCategory string
+	allStreams    []Stream
+	streamsStale  bool
+}
+
+func (m Model) saveWorkspace() workspace {
+	return workspace{
+		matches:       m.matches,
+		streams:       m.streams,
+		focus:         m.focus,
+		shownSport:    m.shownSport,
+		shownMatch:    m.shownMatch,
+		openedMatch:   m.openedMatch,
+		allMatches:    m.allMatches,
+		matchesTitle:  m.matchesTitle,
+		matchesStale:  m.matchesStale,
+		matchCategory: m.matchCategory,
+		allStreams:    m.allStreams,
+		streamsStale:  m.streamsStale,
+	}
+}
+
+// loadWorkspace makes w the main view's, sizing its columns and applying
+// the filters, which may have changed in another tab.
+func (m *Model) loadWorkspace(w workspace) {
+	m.matches = w.matches
+	m.streams = w.streams
+	m.focus = w.focus
+	m.shownSport = w.shownSport
+	m.shownMatch = w.shownMatch
+	m.openedMatch = w.openedMatch
+	m.allMatches = w.allMatches
+	m.matchesTitle = w.matchesTitle
+	m.matchesStale = w.matchesStale
+	m.matchCategory = w.matchCategory
+	m.allStreams = w.allStreams
+	m.streamsStale = w.streamsStale
+	m.layoutColumns()
+	if m.matchesTitle != "" {
+		m.applyMatches()
+	}
+	m.applyStreams()
+}
+
+// inTab runs fn with tab i as the main view's and switches back, so a
+// response can land in the tab that asked for it.
+func (m *Model) inTab(i int, fn func()) {
+	active := m.saveWorkspace()
+	m.loadWorkspace(m.tabs[i])
+	fn()
+	m.tabs[i] = m.saveWorkspace()
+	m.loadWorkspace(active)
+}
+
+// workspaceOf returns tab i's workspace, the active one included.
+func (m Model) workspaceOf(i int) workspace {
+	if i == m.tab {
+		return m.saveWorkspace()
+	}
+	return m.tabs[i]
+}
+
+// newTab opens a tab on the shown match list, opening the highlighted match
+// in it when the Matches column is focused.
+func (m *Model) newTab() tea.Cmd {
+	if len(m.tabs) >= maxTabs {
+		m.status = fmt.Sprintf("All %d tabs are open – Ctrl+W closes one", maxTabs)
+		return nil
+	}
+	mt, selected := m.matches.Selected()
+	open := selected && m.focus == focusMatches
+	m.tabs[m.tab] = m.saveWorkspace()
+	w := workspace{
+		matches:       m.newMatchesColumn(),
+		streams:       m.newStreamsColumn(),
+		focus:         focusMatches,
+		shownSport:    m.shownSport,
+		allMatches:    m.allMatches,
+		matchesTitle:  m.matchesTitle,
+		matchesStale:  m.matchesStale,
+		matchCategory: m.matchCategory,
+	}
+	m.tabs = append(m.tabs, w)
+	m.tab = len(m.tabs) - 1
+	m.loadWorkspace(w)
+	m.lastError = nil
+	if selected {
+		m.matches.Select(func(x Match) bool { return x.ID == mt.ID })
+	}
+	m.status = fmt.Sprintf("Opened tab %d", m.tab+1)
+	if open {
+		return tea.Batch(m.openMatch(mt), m.loadMatchArt())
+	}
+	return m.loadMatchArt()
+}
+
+// switchTab makes tab i the main view's.
+func (m *Model) switchTab(i int) tea.Cmd {
+	if i < 0 || i >= len(m.tabs) {
+		m.status = fmt.Sprintf("No tab %d – Shift+N opens the highlighted match in a new tab", i+1)
+		return nil
+	}
+	if i == m.tab {
+		return nil
+	}
+	m.tabs[m.tab] = m.saveWorkspace()
+	m.tab = i
+	m.loadWorkspace(m.tabs[i])
+	m.lastError = nil
+	m.status = fmt.Sprintf("Tab %d: %s", i+1, m.tabTitle(i))
+	return m.loadMatchArt()
+}
+
+// closeTab closes the active tab, leaving at least one open.
+func (m *Model) closeTab() tea.Cmd {
+	if len(m.tabs) == 1 {
+		m.status = "Only one tab is open"
+		return nil
+	}
+	closed := m.tab
+	m.tabs = append(m.tabs[:closed:closed], m.tabs[closed+1:]...)
+	m.tab = min(closed, len(m.tabs)-1)
+	m.loadWorkspace(m.tabs[m.tab])
+	m.lastError = nil
+	m.status = fmt.Sprintf("Closed tab %d", closed+1)
+	return m.loadMatchArt()
+}
+
+// streamsTab returns the tab other than the active one that opened a match,
+// or -1 when its streams are the active tab's.
+func (m Model) streamsTab(matchID string) int {
+	if m.openedMatch.ID == matchID || m.shownMatch == matchID {
+		return -1
+	}
+	for i, w := range m.tabs {
+		if i != m.tab && (w.openedMatch.ID == matchID || w.shownMatch == matchID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// showMatchesInTabs refreshes the other tabs showing a sport and reports
+// whether the active tab should leave the list alone: it neither shows that
+// sport nor has it highlighted in the Sports column, as when the Popular
+// list requested on startup arrives after the default sport was opened.
+// Until a sport is shown or highlighted, any list is the active tab's.
+func (m *Model) showMatchesInTabs(msg matchesLoadedMsg) bool {
+	for i, w := range m.tabs {
+		if i != m.tab && w.shownSport == msg.SportID {
+			m.inTab(i, func() { m.showMatches(msg, false) })
+		}
+	}
+	if m.shownSport == msg.SportID {
+		return false
+	}
+	s, ok := m.sports.Selected()
+	if !ok {
+		return m.shownSport != ""
+	}
+	return !strings.EqualFold(s.ID, msg.SportID)
+}
+
+// tabTitle names a tab after its match, or its match list before a match
+// is opened.
+func (m Model) tabTitle(i int) string {
+	w := m.workspaceOf(i)
+	title := w.matchesTitle
+	if w.openedMatch.ID != "" && w.openedMatch.ID == w.shownMatch {
+		title = matchDisplayTitle(w.openedMatch)
+	}
+	if title == "" {
+		title = "Loading…"
+	}
+	if lipgloss.Width(title) > tabTitleWidth {
+		title = truncateToWidth(title, tabTitleWidth-1) + m.styles.Glyphs.Ellipsis
+	}
+	return title
+}
+
+// renderTabBar lists the open tabs above the columns; one tab needs no bar.
+func (m Model) renderTabBar() string {
+	if len(m.tabs) < 2 {
+		return ""
+	}
+	parts := make([]string, len(m.tabs))
+	for i := range m.tabs {
+		label := fmt.Sprintf(" %d %s ", i+1, m.tabTitle(i))
+		if i == m.tab {
+			parts[i] = m.styles.Title.Reverse(true).Render(m.styles.Text(label))
+		} else {
+			parts[i] = m.styles.Subtle.Render(m.styles.Text(label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
@@ -0,0 +1,245 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ────────────────────────────────
+// TEAMS
+// ────────────────────────────────
+
+// teamsTTL is how long the team index is reused before the all-matches list
+// is fetched again.
+const teamsTTL = 5 * time.Minute
+
+type teamsLoadedMsg struct {
+	Matches []Match
+}
+
+// teamEntry is a team of the index with its live and upcoming matches,
+// soonest first.
+type teamEntry struct {
+	Name    string
+	Matches []Match
+	// Categories are the categories the team's matches are in, in order of
+	// first appearance.
+	Categories []string
+}
+
+// teamsModel is the state of the teams view: an index of every team with a
+// live or upcoming match, and the highlighted team's matches next to it.
+type teamsModel struct {
+	input   textinput.Model
+	index   []teamEntry
+	loaded  time.Time
+	list    *ListColumn[teamEntry]
+	matches *ListColumn[Match]
+	// onMatches is set while the cursor is in the match list.
+	onMatches bool
+}
+
+func newTeamsModel(kickoff *kickoffClock, favs *favoritesStore) teamsModel {
+	ti := textinput.New()
+	ti.Prompt = "Team: "
+	ti.Placeholder = "type to narrow the list"
+	ti.CharLimit = 60
+	list := NewListColumn[teamEntry]("Teams", func(t teamEntry) string {
+		name := t.Name
+		if favs.TeamStarred(t.Name) {
+			name = "★ " + name
+		}
+		return fmt.Sprintf("%s (%d) – %s", name, len(t.Matches), strings.Join(t.Categories, ", "))
+	})
+	matches := NewListColumn[Match]("Matches", func(mt Match) string {
+		return fmt.Sprintf("%s  %s (%s)", kickoff.label(mt, time.Now()), matchDisplayTitle(mt), mt.Category)
+	})
+	matches.SetSeparator(matchDaySeparator)
+	matches.SetDecorator(func(mt Match, text string) string {
+		return colorLiveBadge(kickoff.label(mt, time.Now()), text)
+	})
+	return teamsModel{input: ti, list: list, matches: matches}
+}
+
+// buildTeamIndex groups the matches that are live or still to come by team.
+// Team names are matched the way favorites match them, keeping the first
+// spelling seen.
+func buildTeamIndex(matches []Match, now time.Time) []teamEntry {
+	sorted := slices.Clone(matches)
+	slices.SortStableFunc(sorted, func(a, b Match) int { return compareInt64(a.Date, b.Date) })
+	byKey := map[string]int{}
+	var index []teamEntry
+	for _, mt := range sorted {
+		if mt.Date <= 0 || now.Sub(time.UnixMilli(mt.Date)) >= liveWindow {
+			continue
+		}
+		for _, name := range matchTeams(mt) {
+			k := teamKey(name)
+			i, ok := byKey[k]
+			if !ok {
+				i = len(index)
+				byKey[k] = i
+				index = append(index, teamEntry{Name: strings.TrimSpace(name)})
+			}
+			t := &index[i]
+			t.Matches = append(t.Matches, mt)
+			if cat := strings.TrimSpace(mt.Category); cat != "" && !containsFold(t.Categories, cat) {
+				t.Categories = append(t.Categories, cat)
+			}
+		}
+	}
+	slices.SortStableFunc(index, func(a, b teamEntry) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return index
+}
+
+// openTeams switches to the teams view, fetching every sport's matches when
+// the index is missing or stale.
+func (m *Model) openTeams() tea.Cmd {
+	m.currentView = viewTeams
+	m.teams.onMatches = false
+	cmds := []tea.Cmd{m.teams.input.Focus()}
+	if m.teams.index == nil || time.Since(m.teams.loaded) > teamsTTL {
+		cmds = append(cmds, m.track(opTeams, opTeams, "Loading teams", m.fetchTeams(false)))
+	}
+	m.refreshTeams()
+	return tea.Batch(cmds...)
+}
+
+// fetchTeams loads every sport's matches from the all-matches endpoint.
+func (m Model) fetchTeams(fresh bool) tea.Cmd {
+	provider := m.provider
+	return safeCmd("fetch teams", m.crash, func() tea.Msg {
+		matches, err := provider.ListMatches(apiContext(fresh), allSportID)
+		if err != nil {
+			return errorMsg(err)
+		}
+		return teamsLoadedMsg{Matches: matches}
+	})
+}
+
+func (m *Model) handleTeamsLoaded(msg teamsLoadedMsg) {
+	m.teams.index = buildTeamIndex(msg.Matches, time.Now())
+	m.teams.loaded = time.Now()
+	m.lastError = nil
+	m.status = fmt.Sprintf("Indexed %d teams from %d matches", len(m.teams.index), len(msg.Matches))
+	m.refreshTeams()
+}
+
+// refreshTeams narrows the index to the typed name, keeping the cursor on
+// the same team.
+func (m *Model) refreshTeams() {
+	query := strings.TrimSpace(m.teams.input.Value())
+	var shown []teamEntry
+	for _, t := range m.teams.index {
+		if query == "" || strings.Contains(teamKey(t.Name), teamKey(query)) {
+			shown = append(shown, t)
+		}
+	}
+	m.teams.list.ReplaceItems(shown, func(a, b teamEntry) bool { return teamKey(a.Name) == teamKey(b.Name) })
+	switch {
+	case m.teams.index == nil:
+		m.teams.list.SetFooter("loading…")
+	case len(shown) == 0:
+		m.teams.list.SetFooter("no teams")
+	default:
+		m.teams.list.SetFooter("")
+	}
+	m.showTeamMatches()
+}
+
+// showTeamMatches lists the highlighted team's matches.
+func (m *Model) showTeamMatches() {
+	t, ok := m.teams.list.Selected()
+	if !ok {
+		m.teams.matches.SetTitle("Matches")
+		m.teams.matches.SetItems(nil)
+		return
+	}
+	m.teams.matches.SetTitle(t.Name)
+	m.teams.matches.SetItems(t.Matches)
+}
+
+// updateTeams handles keys in the teams view: typing narrows the index,
+// arrows move in the focused list, Enter or Tab moves to the team's matches,
+// and Enter there opens the match's streams.
+func (m *Model) updateTeams(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		if m.teams.onMatches {
+			m.teams.onMatches = false
+			return nil
+		}
+		m.teams.input.Blur()
+		m.currentView = viewMain
+		return nil
+	case tea.KeyTab, tea.KeyShiftTab:
+		m.teams.onMatches = !m.teams.onMatches && len(m.teams.matches.Items()) > 0
+		return nil
+	case tea.KeyUp, tea.KeyDown, tea.KeyPgUp, tea.KeyPgDown:
+		if m.teams.onMatches {
+			moveListCursor(m.teams.matches, msg.Type)
+		} else {
+			moveListCursor(m.teams.list, msg.Type)
+			m.showTeamMatches()
+		}
+		return nil
+	case tea.KeyEnter:
+		if !m.teams.onMatches {
+			m.teams.onMatches = len(m.teams.matches.Items()) > 0
+			return nil
+		}
+		mt, ok := m.teams.matches.Selected()
+		if !ok {
+			return nil
+		}
+		m.teams.input.Blur()
+		m.currentView = viewMain
+		return m.openMatch(mt)
+	}
+	if m.teams.onMatches {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.teams.input, cmd = m.teams.input.Update(msg)
+	m.refreshTeams()
+	return cmd
+}
+
+// moveListCursor moves a list's cursor for an arrow or page key.
+func moveListCursor[T any](list *ListColumn[T], k tea.KeyType) {
+	switch k {
+	case tea.KeyUp:
+		list.CursorUp()
+	case tea.KeyDown:
+		list.CursorDown()
+	case tea.KeyPgUp:
+		list.Jump(jumpPageUp)
+	case tea.KeyPgDown:
+		list.Jump(jumpPageDown)
+	}
+}
+
+func (m Model) renderTeamsView() string {
+	header := m.styles.Title.Render("Teams with live or upcoming matches")
+	hint := m.styles.Subtle.Render(m.styles.Text("type to narrow · ↑/↓ select · Enter/Tab the team's matches · Enter open streams · Esc back"))
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.teams.list.View(m.styles, !m.teams.onMatches),
+		" ",
+		m.teams.matches.View(m.styles, m.teams.onMatches),
+	)
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.teams.input.View(),
+		columns,
+		hint,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderStatusLine())
+}
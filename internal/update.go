@@ -0,0 +1,336 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	latestReleaseURL = "https://api.github.com/repos/Salastil/streamed-tui/releases/latest"
+	releasesPageURL  = "https://github.com/Salastil/streamed-tui/releases/latest"
+
+	// updateCheckInterval rate-limits release lookups; in between, the
+	// last result is reused from the state directory.
+	updateCheckInterval = 24 * time.Hour
+)
+
+type updateAvailableMsg struct{ Latest string }
+
+// updateCheckState is persisted between runs to rate-limit the check.
+type updateCheckState struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// updateChecksDisabled reports whether the user opted out through the
+// environment (the --no-update-check flag is handled via Options).
+func updateChecksDisabled() bool {
+	val := strings.TrimSpace(os.Getenv("STREAMED_TUI_NO_UPDATE_CHECK"))
+	return val != "" && val != "0"
+}
+
+// checkForUpdate looks up the latest release tag (at most once per
+// updateCheckInterval), through proxy when one is set, and reports it when it
+// is newer than current.
+func checkForUpdate(current, proxy string) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := parseVersion(current); !ok {
+			return nil // development build
+		}
+		latest, err := latestReleaseTag(proxyHTTPClient(proxy))
+		if err != nil || !versionNewer(latest, current) {
+			return nil
+		}
+		return updateAvailableMsg{Latest: latest}
+	}
+}
+
+// latestReleaseTag returns the latest release tag, asking GitHub at most once
+// per updateCheckInterval. A failed check counts too, so an unreachable
+// GitHub is not asked again on every start.
+func latestReleaseTag(client *http.Client) (string, error) {
+	state := loadUpdateState()
+	if time.Since(state.CheckedAt) < updateCheckInterval {
+		return state.Latest, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	rel, err := fetchLatestRelease(ctx, client)
+	state.CheckedAt = time.Now()
+	if err == nil {
+		state.Latest = rel.TagName
+	}
+	saveUpdateState(state)
+	return state.Latest, err
+}
+
+// updateStatePath returns where the last check is remembered, or "" when the
+// state directory is unavailable.
+func updateStatePath() string {
+	dir, err := ensureAppDir(stateDir)
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "update-check.json")
+}
+
+func loadUpdateState() updateCheckState {
+	var state updateCheckState
+	if path := updateStatePath(); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			_ = json.Unmarshal(data, &state)
+		}
+	}
+	return state
+}
+
+func saveUpdateState(state updateCheckState) {
+	path := updateStatePath()
+	if path == "" {
+		return
+	}
+	if data, err := json.Marshal(state); err == nil {
+		_ = os.WriteFile(path, data, 0o644)
+	}
+}
+
+// githubRelease is the part of a GitHub release the update check and
+// self-update read.
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func fetchLatestRelease(ctx context.Context, client *http.Client) (githubRelease, error) {
+	var release githubRelease
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return release, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "StreamedTUI/1.0 (+https://github.com/Salastil/streamed-tui)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return release, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return release, fmt.Errorf("GET %s: %s", latestReleaseURL, resp.Status)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&release)
+	return release, err
+}
+
+// ────────────────────────────────
+// SELF-UPDATE
+// ────────────────────────────────
+
+const (
+	// selfUpdateTimeout bounds looking up and downloading a release.
+	selfUpdateTimeout = 5 * time.Minute
+	// checksumsAsset lists the SHA-256 of every build in a release, in
+	// sha256sum's "hash  name" format.
+	checksumsAsset = "SHA256SUMS"
+)
+
+// RunSelfUpdate handles "streamed-tui self-update": it replaces the running
+// binary with the latest release's build for this platform, which releases
+// publish as streamed-tui_<os>_<arch> next to a SHA256SUMS file the download
+// must match.
+func RunSelfUpdate(args []string, opts Options) error {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: streamed-tui self-update")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, ok := parseVersion(opts.Version); !ok {
+		return fmt.Errorf("%s is not a release build; update it the way it was built (git pull, go install)", opts.Version)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), selfUpdateTimeout)
+	defer cancel()
+	client := proxyHTTPClient(opts.proxy())
+	rel, err := fetchLatestRelease(ctx, client)
+	if err != nil {
+		return err
+	}
+	saveUpdateState(updateCheckState{CheckedAt: time.Now(), Latest: rel.TagName})
+	if !versionNewer(rel.TagName, opts.Version) {
+		fmt.Printf("streamed-tui %s is up to date\n", opts.Version)
+		return nil
+	}
+	name := fmt.Sprintf("streamed-tui_%s_%s", runtime.GOOS, runtime.GOARCH)
+	url := rel.assetURL(name)
+	if url == "" {
+		return fmt.Errorf("release %s has no build for %s/%s; see %s", rel.TagName, runtime.GOOS, runtime.GOARCH, releasesPageURL)
+	}
+	sumsURL := rel.assetURL(checksumsAsset)
+	if sumsURL == "" {
+		return fmt.Errorf("release %s has no %s to verify the download against; see %s", rel.TagName, checksumsAsset, releasesPageURL)
+	}
+	sum, err := fetchChecksum(ctx, client, sumsURL, name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("downloading %s %s\n", rel.TagName, name)
+	if err := replaceExecutable(ctx, client, url, sum, exe); err != nil {
+		return fmt.Errorf("update %s: %w", exe, err)
+	}
+	fmt.Printf("updated %s from %s to %s\n", exe, opts.Version, rel.TagName)
+	return nil
+}
+
+// assetURL returns the download URL of the release asset called name, or ""
+// when the release has none.
+func (r githubRelease) assetURL(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.URL
+		}
+	}
+	return ""
+}
+
+// fetchChecksum downloads a SHA256SUMS file and returns the lowercase hex
+// SHA-256 it lists for name.
+func fetchChecksum(ctx context.Context, client *http.Client, url, name string) (string, error) {
+	resp, err := getRelease(ctx, client, url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	sc := bufio.NewScanner(io.LimitReader(resp.Body, 1<<20))
+	for sc.Scan() {
+		// sha256sum marks files hashed in binary mode with a leading '*'.
+		sum, file, ok := strings.Cut(strings.TrimSpace(sc.Text()), " ")
+		if ok && strings.TrimPrefix(strings.TrimSpace(file), "*") == name {
+			if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+				return "", fmt.Errorf("%s: malformed checksum for %s", checksumsAsset, name)
+			}
+			return strings.ToLower(sum), nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s", checksumsAsset, name)
+}
+
+// getRelease starts downloading a release asset and fails on anything but
+// 200 OK. The caller closes the body.
+func getRelease(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// replaceExecutable downloads url next to exe, checks it against the
+// expected SHA-256 and only then renames it over exe, so a failed or
+// tampered download leaves the running binary in place.
+func replaceExecutable(ctx context.Context, client *http.Client, url, wantSum, exe string) error {
+	resp, err := getRelease(ctx, client, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".streamed-tui-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != wantSum {
+		return fmt.Errorf("checksum mismatch: downloaded %s, %s lists %s; not installing it", got, checksumsAsset, wantSum)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), exe)
+}
+
+// parseVersion parses "v1.2.3" style tags into numeric components.
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if v == "" {
+		return nil, false
+	}
+	v, _, _ = strings.Cut(v, "-")
+	parts := strings.Split(v, ".")
+	out := make([]int, 0, len(parts))
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		out = append(out, n)
+	}
+	return out, true
+}
+
+func versionNewer(candidate, current string) bool {
+	a, okA := parseVersion(candidate)
+	b, okB := parseVersion(current)
+	if !okA || !okB {
+		return false
+	}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ────────────────────────────────
+// WATCHDOG
+// ────────────────────────────────
+
+// watchdogKey groups a player's relaunches by match, or by stream when the
+// match is unknown.
+func watchdogKey(p playerInfo) string {
+	if p.Match.ID != "" {
+		return p.Match.ID
+	}
+	return p.Stream.EmbedURL
+}
+
+// watchdogRelaunch restarts a player that died with an error within the
+// watchdog window: first on the same stream, then on the streams listed after
+// it. Relaunches are counted per match in watchdogCount. It returns nil when
+// the watchdog is off, the player ran long enough, or the match is out of
+// retries.
+func (m *Model) watchdogRelaunch(p playerInfo, err error) tea.Cmd {
+	key := watchdogKey(p)
+	if m.opts.Watchdog <= 0 || p.Stopped || p.Stream.EmbedURL == "" {
+		return nil
+	}
+	uptime := time.Since(p.Started)
+	if err == nil || uptime > m.opts.Watchdog {
+		delete(m.watchdogCount, key)
+		return nil
+	}
+	n := m.watchdogCount[key] + 1
+	if n > m.opts.WatchdogRetries {
+		delete(m.watchdogCount, key)
+		m.retryPlayer = p
+		m.status = fmt.Sprintf("%s for %s exited after %s: %v – watchdog gave up after %d relaunches, Ctrl+R to try again",
+			p.Name, p.Title, formatElapsed(uptime), err, n-1)
+		return m.logToUI(fmt.Sprintf("[watchdog] giving up on %s after %d relaunches", p.Title, n-1))
+	}
+	m.watchdogCount[key] = n
+
+	candidates := append([]Stream{p.Stream}, m.fallbackStreams(p.Stream, p.Match)...)
+	st := candidates[(n-1)%len(candidates)]
+	m.status = fmt.Sprintf("%s for %s exited after %s: %v – watchdog relaunching stream #%d (%d/%d)",
+		p.Name, p.Title, formatElapsed(uptime), err, st.StreamNo, n, m.opts.WatchdogRetries)
+	return tea.Batch(
+		m.logToUI(fmt.Sprintf("[watchdog] %s died after %s (%v); extracting stream #%d (%s), relaunch %d/%d",
+			p.Name, formatElapsed(uptime), err, st.StreamNo, st.Source, n, m.opts.WatchdogRetries)),
+		m.launchStream(st, p.Match, p.Fullscreen, true),
+	)
+}
@@ -2,26 +2,247 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"runtime/debug"
+	"strings"
 
 	"github.com/Salastil/streamed-tui/internal"
 )
 
+// hiddenFlags are left out of -h output; they are meant for field debugging.
+var hiddenFlags = map[string]bool{"pprof": true}
+
+// usage prints the flag defaults without the hidden flags.
+func usage() {
+	visible := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	visible.SetOutput(flag.CommandLine.Output())
+	flag.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			visible.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+	visible.PrintDefaults()
+}
+
+// version is set at build time with -ldflags "-X main.version=<tag>".
+var version = "dev"
+
+func buildVersion() string {
+	if version != "dev" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return version
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "config":
+			if err := internal.RunConfigCommand(os.Args[2:]); err != nil {
+				log.Println("error:", err)
+				os.Exit(1)
+			}
+			return
+		case "play":
+			opts, err := internal.LoadOptions()
+			if err == nil {
+				err = internal.RunPlayCommand(os.Args[2:], opts)
+			}
+			if err != nil {
+				log.Println("error:", err)
+				os.Exit(1)
+			}
+			return
+		case "notify-daemon":
+			opts, err := internal.LoadOptions()
+			if err == nil {
+				err = internal.RunNotifyDaemon(os.Args[2:], opts)
+			}
+			if err != nil {
+				log.Println("error:", err)
+				os.Exit(1)
+			}
+			return
+		case "serve-ssh":
+			opts, err := internal.LoadOptions()
+			if err == nil {
+				opts.Version = buildVersion()
+				err = internal.RunServeSSH(os.Args[2:], opts)
+			}
+			if err != nil {
+				log.Println("error:", err)
+				os.Exit(1)
+			}
+			return
+		case "self-update":
+			opts, err := internal.LoadOptions()
+			if err == nil {
+				opts.Version = buildVersion()
+				err = internal.RunSelfUpdate(os.Args[2:], opts)
+			}
+			if err != nil {
+				log.Println("error:", err)
+				os.Exit(1)
+			}
+			return
+		case "sports", "matches", "streams", "list":
+			args := os.Args[1:]
+			if args[0] == "list" {
+				args = args[1:]
+			}
+			opts, err := internal.LoadOptions()
+			if err == nil {
+				err = internal.RunListCommand(args, opts)
+			}
+			if err != nil {
+				log.Println("error:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	embedURL := flag.String("e", "", "extract a single embed URL and launch mpv")
-	debug := flag.Bool("debug", false, "enable verbose extractor/debug output")
+	opts, err := internal.LoadOptions()
+	if err != nil {
+		log.Println("error:", err)
+		os.Exit(1)
+	}
+	opts.Version = buildVersion()
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.BoolVar(&opts.NoUpdateCheck, "no-update-check", opts.NoUpdateCheck, "do not check GitHub for newer releases on startup")
+	flag.BoolVar(&opts.Debug, "debug", opts.Debug, "enable verbose extractor/debug output")
+	flag.BoolVar(&opts.JSON, "json", opts.JSON, "with -e, print the extracted stream (URL, headers, timing) as JSON instead of playing it")
+	flag.StringVar(&opts.LogFile, "log-file", opts.LogFile, "file the debug log is written to (default: debug.log in the state directory)")
+	flag.StringVar(&opts.LogLevel, "log-level", opts.LogLevel, `lowest level written to the log file: debug, info, warn, error, or off (default "info", "debug" with --debug)`)
+	flag.BoolVar(&opts.SchemaCheck, "schema-check", opts.SchemaCheck, "warn in the debug log when API responses gain or lose fields")
+	flag.BoolVar(&opts.Strict, "strict", opts.Strict, "reject non-JSON or malformed API responses instead of showing empty lists")
+	flag.StringVar(&opts.BaseURL, "base", opts.BaseURL, "API base URL (overrides STREAMED_BASE)")
+	flag.Func("mirrors", "comma-separated API mirrors to fail over to, in order (e.g. https://streamed.su)", func(v string) error {
+		opts.Mirrors = nil
+		for _, mirror := range strings.Split(v, ",") {
+			if mirror = strings.TrimSpace(mirror); mirror != "" {
+				opts.Mirrors = append(opts.Mirrors, mirror)
+			}
+		}
+		return nil
+	})
+	flag.StringVar(&opts.ViewCountURL, "viewcount-url", opts.ViewCountURL, "popular view-count endpoint (default: the API host's)")
+	flag.BoolVar(&opts.Demo, "demo", opts.Demo, "run against bundled fixture data with a fake extractor (no network)")
+	flag.BoolVar(&opts.ASCII, "ascii", opts.ASCII, "use plain ASCII borders, glyphs, and status text")
+	flag.BoolVar(&opts.RelativeTimes, "relative-times", opts.RelativeTimes, `show kickoff times relative to now ("in 45m", "2h ago")`)
+	flag.StringVar(&opts.Images, "images", opts.Images, `match posters and team badges in the details column: auto, kitty, iterm2, sixel, or off (default "auto")`)
+	flag.StringVar(&opts.Glyphs.Cursor, "cursor-glyph", opts.Glyphs.Cursor, `selected-row marker (default "▸")`)
+	flag.StringVar(&opts.Glyphs.Focus, "focus-glyph", opts.Glyphs.Focus, `focused column title marker (default "▶")`)
+	flag.StringVar(&opts.Glyphs.Separator, "separator-glyph", opts.Glyphs.Separator, `separator row fill character (default "─")`)
+	flag.Func("languages", `preferred stream languages for automatic picks, e.g. "English,Spanish"`, func(v string) error {
+		opts.Languages = nil
+		for _, lang := range strings.Split(v, ",") {
+			if lang = strings.TrimSpace(lang); lang != "" {
+				opts.Languages = append(opts.Languages, lang)
+			}
+		}
+		return nil
+	})
+	flag.Func("stream-preference", `stream ranking, most preferred first, e.g. "English HD,English,HD"`, func(v string) error {
+		opts.StreamPreference = nil
+		for _, entry := range strings.Split(v, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				opts.StreamPreference = append(opts.StreamPreference, entry)
+			}
+		}
+		return nil
+	})
+	flag.BoolVar(&opts.NoProbe, "no-probe", opts.NoProbe, "launch the player without checking that the extracted playlist answers")
+	flag.BoolVar(&opts.NoKickoffAlerts, "no-kickoff-alerts", opts.NoKickoffAlerts, "do not notify when starred matches or teams kick off")
+	flag.BoolVar(&opts.NoAdBlock, "no-adblock", opts.NoAdBlock, "let the extractor load ad, analytics, and popup domains")
+	flag.BoolVar(&opts.LoadAssets, "load-assets", opts.LoadAssets, "let the extractor load images, fonts, and stylesheets")
+	flag.StringVar(&opts.Proxy, "proxy", opts.Proxy, "HTTP or SOCKS5 proxy URL for API requests, extraction, and playback (overrides STREAMED_PROXY)")
+	flag.Func("extractors", `extractor backends to try in order: regex, chromedp, puppeteer, yt-dlp (default "regex,chromedp,puppeteer")`, func(v string) error {
+		opts.Extractors = nil
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				opts.Extractors = append(opts.Extractors, name)
+			}
+		}
+		return nil
+	})
+	flag.StringVar(&opts.BrowserPath, "browser-path", opts.BrowserPath, "Chrome or Chromium binary for the chromedp extractor (default: auto-detect)")
+	flag.StringVar(&opts.RunnerPath, "runner-path", opts.RunnerPath, "run this extractor script instead of the built-in runner")
+	flag.StringVar(&opts.Runtime, "runtime", opts.Runtime, "JavaScript runtime for the extractor: node, bun, or deno (default: first installed)")
+	dumpRunner := flag.Bool("dump-runner", false, "print the built-in extractor runner script and exit")
+	flag.StringVar(&opts.Player, "player", opts.Player, "mpv-compatible player binary")
+	flag.StringVar(&opts.PlayerBackend, "player-backend", opts.PlayerBackend, `how streams reach the player: "mpv" (direct) or "streamlink" (default "mpv")`)
+	flag.StringVar(&opts.PlayerCommand, "player-command", opts.PlayerCommand, `custom player command with {url}, {user_agent}, {referer}, {origin}, {cookie}, {title}, {proxy} placeholders`)
+	flag.StringVar(&opts.StreamlinkPath, "streamlink-path", opts.StreamlinkPath, "streamlink executable for --player-backend streamlink")
+	flag.StringVar(&opts.Quality, "quality", opts.Quality, `stream quality when several are offered: ask, auto, best, or a height such as 720p (default "ask"; -e treats ask as auto)`)
+	flag.IntVar(&opts.FallbackStreams, "fallback-streams", opts.FallbackStreams, "how many of the next streams of a match to try when extraction fails (0 disables)")
+	flag.StringVar(&opts.FFmpegPath, "ffmpeg-path", opts.FFmpegPath, "ffmpeg executable used for recording streams")
+	flag.StringVar(&opts.RecordDir, "record-dir", opts.RecordDir, "directory recordings are saved to (default: recordings in the data directory)")
+	flag.StringVar(&opts.DownloadDir, "download-dir", opts.DownloadDir, "directory yt-dlp downloads are saved to (default: downloads in the data directory)")
+	flag.StringVar(&opts.DefaultSport, "sport", opts.DefaultSport, "sport to open on startup instead of Popular (id or name)")
+	flag.DurationVar(&opts.UpcomingWindow, "upcoming-window", opts.UpcomingWindow, "how soon matches must start to pass the upcoming filter (u)")
+	flag.BoolVar(&opts.Fullscreen, "fullscreen", opts.Fullscreen, "open mpv in fullscreen (--fs)")
+	flag.DurationVar(&opts.Watchdog, "watchdog", opts.Watchdog, "relaunch a player that fails within this long of starting, re-extracting the stream (0 disables)")
+	flag.IntVar(&opts.WatchdogRetries, "watchdog-retries", opts.WatchdogRetries, "how often the watchdog relaunches a match's player")
+	flag.BoolVar(&opts.KillPlayersOnQuit, "kill-players-on-quit", opts.KillPlayersOnQuit, "stop the players started from the TUI when it quits")
+	flag.StringVar(&opts.DiscordClientID, "discord-client-id", opts.DiscordClientID, "Discord application ID to show the match being watched as your Discord activity")
+	flag.IntVar(&opts.CastPort, "cast-port", opts.CastPort, "port the relay serves cast streams to Chromecast and DLNA devices on (default: a free one)")
+	flag.BoolVar(&opts.ForwardCookies, "forward-cookies", opts.ForwardCookies, "also send the cookies captured during extraction to the player")
+	flag.IntVar(&opts.FullscreenScreen, "fs-screen", opts.FullscreenScreen, "screen number for fullscreen mpv (--fs-screen)")
+	flag.Func("mpv-args", `extra mpv arguments for every launch, quoted as in a shell, e.g. "--profile=low-latency --cache=no"`, func(v string) error {
+		args, err := internal.SplitCommandLine(v)
+		opts.MPVArgs = args
+		return err
+	})
+	flag.StringVar(&opts.KeyScript, "keys", opts.KeyScript, `replay keys on startup, e.g. "wait:2s down enter right enter" (or @file)`)
+	flag.DurationVar(&opts.APITimeout, "api-timeout", opts.APITimeout, "timeout for each streamed API request")
+	flag.IntVar(&opts.APIRetries, "api-retries", opts.APIRetries, "retries for API requests that fail transiently (timeouts, resets, 5xx)")
+	noCache := flag.Bool("no-cache", false, "always fetch sports, matches, and view counts instead of reusing recent responses")
+	flag.BoolVar(&opts.DiskCache, "cache-disk", opts.DiskCache, "keep cached API responses on disk between runs")
+	flag.DurationVar(&opts.ExtractTimeout, "extract-timeout", opts.ExtractTimeout, "timeout for loading the embed page during extraction")
+	flag.DurationVar(&opts.CaptureTimeout, "capture-timeout", opts.CaptureTimeout, "how long to wait for an .m3u8 request after the embed page loads")
+	pprofAddr := flag.String("pprof", "", "serve net/http/pprof on this address, e.g. :6060")
+	flag.Usage = usage
 	flag.Parse()
+	if *noCache {
+		opts.CacheTTL = internal.CacheTTLs{}
+	}
+
+	if *pprofAddr != "" {
+		addr, err := internal.StartPprof(*pprofAddr)
+		if err != nil {
+			log.Println("error:", err)
+			os.Exit(1)
+		}
+		log.Printf("pprof listening on http://%s/debug/pprof/", addr)
+	}
+
+	if *showVersion {
+		fmt.Println("streamed-tui", opts.Version)
+		return
+	}
+
+	if *dumpRunner {
+		fmt.Print(internal.DefaultRunnerScript())
+		return
+	}
 
 	if *embedURL != "" {
-		if err := internal.RunExtractorCLI(*embedURL, *debug); err != nil {
+		if err := internal.RunExtractorCLI(*embedURL, opts); err != nil {
 			log.Println("error:", err)
 			os.Exit(1)
 		}
 		return
 	}
 
-	if err := internal.Run(*debug); err != nil {
+	if err := internal.Run(opts); err != nil {
 		log.Println("error:", err)
 		os.Exit(1)
 	}